@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/docker"
+	"github.com/eanda22/devhud/internal/service"
+	"github.com/eanda22/devhud/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Work with databases",
+}
+
+var dbOpenCmd = &cobra.Command{
+	Use:   "open <file>",
+	Short: "Browse a SQLite database file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", args[0])
+		}
+
+		svc := &service.Service{
+			ID:     "sqlite-" + path,
+			Name:   filepath.Base(path),
+			DBType: "sqlite",
+			DBPath: path,
+		}
+		runTUI(func(app *tui.App) { app.OpenDatabase(svc) })
+		return nil
+	},
+}
+
+var dbConnectCmd = &cobra.Command{
+	Use:   "connect [name]",
+	Short: "Browse a saved connection, or list them",
+	Long: "Browse a database connection saved under \"connections\" in config.json,\n" +
+		"such as a native server or a port-forwarded database. Without a name the\n" +
+		"saved connections are listed.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			for _, conn := range cfg.Connections {
+				fmt.Printf("%-20s %-10s %s\n", conn.Name, conn.Type, connectionAddress(conn))
+			}
+			return nil
+		}
+
+		conn, ok := cfg.Connection(args[0])
+		if !ok {
+			return fmt.Errorf("no saved connection named %q", args[0])
+		}
+		if _, err := db.Lookup(conn.Type); err != nil {
+			return fmt.Errorf("connection %s: %w", conn.Name, err)
+		}
+		runTUI(func(app *tui.App) { app.OpenConnection(conn) })
+		return nil
+	},
+}
+
+// formats where a saved connection points, leaving out unset parts.
+func connectionAddress(conn config.Connection) string {
+	addr := conn.Host
+	if addr == "" {
+		addr = "localhost"
+	}
+	if conn.Port != "" {
+		addr += ":" + conn.Port
+	}
+	if conn.Database != "" {
+		addr += "/" + conn.Database
+	}
+	if conn.User != "" {
+		addr = conn.User + "@" + addr
+	}
+	return addr
+}
+
+var (
+	exportFormat string
+	exportOutput string
+	exportWhere  []string
+	exportOrder  string
+)
+
+var dbExportCmd = &cobra.Command{
+	Use:   "export <container|file|connection> <table>",
+	Short: "Export a table to CSV, NDJSON or SQL INSERTs",
+	Long: "Export every row of a table from a database container, a saved connection or a SQLite file.\n" +
+		"Rows are streamed, so large tables do not need to fit in memory.",
+	Example: "  devhud db export postgres users --format csv -o users.csv\n" +
+		"  devhud db export ./dev.sqlite3 orders --where \"status = paid\" --format ndjson",
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		q := db.TableQuery{OrderBy: exportOrder}
+		for _, where := range exportWhere {
+			column, expr, _ := strings.Cut(strings.TrimSpace(where), " ")
+			f, err := db.ParseCondition(column, expr)
+			if err != nil {
+				return fmt.Errorf("--where %q: %w", where, err)
+			}
+			q.Filters = append(q.Filters, f)
+		}
+
+		// ctrl+c cancels the export so the partial file is removed
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		client, err := connectSQL(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		table := args[1]
+		if q.Keys, err = client.PrimaryKey(ctx, table); err != nil {
+			return err
+		}
+
+		if exportOutput == "" || exportOutput == "-" {
+			_, err := client.ExportTable(ctx, table, q, exportFormat, os.Stdout)
+			return err
+		}
+		rows, err := db.ExportFile(exportOutput, func(w io.Writer) (int, error) {
+			return client.ExportTable(ctx, table, q, exportFormat, w)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d row(s) to %s\n", rows, exportOutput)
+		return nil
+	},
+}
+
+// connects to a SQLite file, a saved connection, or the SQL database in a
+// container by name or ID.
+func connectSQL(ctx context.Context, target string) (*db.Client, error) {
+	if info, err := os.Stat(target); err == nil && !info.IsDir() {
+		return db.NewClient(ctx, &db.ConnectionConfig{Database: target}, "sqlite")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if conn, ok := cfg.Connection(target); ok {
+		connConfig, err := db.SavedConfig(ctx, conn)
+		if err != nil {
+			return nil, err
+		}
+		return db.NewClient(ctx, connConfig, conn.Type)
+	}
+
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	defer dockerClient.Close()
+
+	inspect, err := dockerClient.GetRawClient().ContainerInspect(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("inspect container: %w", err)
+	}
+	dbType := db.DetectType(inspect.Config.Image)
+	if dbType == "" {
+		return nil, fmt.Errorf("%s does not run a known database image (%s)", target, inspect.Config.Image)
+	}
+	config, err := db.DiscoverConfig(ctx, dockerClient.GetRawClient(), inspect.ID, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("discover config: %w", err)
+	}
+	browser, err := db.Open(ctx, config, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	client, ok := browser.(*db.Client)
+	if !ok {
+		browser.Close()
+		return nil, fmt.Errorf("%s is not a SQL database", dbType)
+	}
+	return client, nil
+}
+
+func init() {
+	dbExportCmd.Flags().StringVarP(&exportFormat, "format", "f", db.FormatCSV, "output format: "+strings.Join(db.ExportFormats, ", "))
+	dbExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "file to write (default stdout)")
+	dbExportCmd.Flags().StringArrayVar(&exportWhere, "where", nil, `filter as "column condition", e.g. "age >= 18" (repeatable)`)
+	dbExportCmd.Flags().StringVar(&exportOrder, "order-by", "", "column to sort by (default primary key)")
+
+	dbCmd.AddCommand(dbOpenCmd)
+	dbCmd.AddCommand(dbConnectCmd)
+	dbCmd.AddCommand(dbExportCmd)
+	rootCmd.AddCommand(dbCmd)
+}
@@ -5,29 +5,53 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/config"
 	"github.com/eanda22/devhud/internal/tui"
 	"github.com/spf13/cobra"
 )
 
+var notifyCmd string
+
 var rootCmd = &cobra.Command{
 	Use:   "devhud",
 	Short: "Unified local development environment manager",
 	Long:  "devhud is a TUI tool for managing Docker containers, processes, databases, logs, and more.",
 	Run: func(cmd *cobra.Command, args []string) {
-		app, err := tui.NewApp()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error initializing: %v\n", err)
-			os.Exit(1)
-		}
-
-		p := tea.NewProgram(app, tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
-			os.Exit(1)
-		}
+		runTUI(nil)
 	},
 }
 
+// loads the config and runs the TUI; setup can adjust the app before it starts.
+func runTUI(setup func(app *tui.App)) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	if notifyCmd != "" {
+		cfg.Notify.Command = notifyCmd
+	}
+
+	app, err := tui.NewApp(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing: %v\n", err)
+		os.Exit(1)
+	}
+	if setup != nil {
+		setup(app)
+	}
+
+	p := tea.NewProgram(app, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.Flags().StringVar(&notifyCmd, "notify-cmd", "", "command run with a title and body when a service crashes (e.g. notify-send)")
+}
+
 func Execute() error {
 	return rootCmd.Execute()
 }
@@ -0,0 +1,186 @@
+package alert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eanda22/devhud/internal/service"
+)
+
+type Kind string
+
+const (
+	KindCrashed     Kind = "crashed"
+	KindRestartLoop Kind = "restart-loop"
+	KindDisappeared Kind = "disappeared"
+)
+
+// Alert describes a service that died or is failing to stay up.
+type Alert struct {
+	ID          string
+	ServiceID   string
+	ServiceName string
+	ContainerID string
+	Kind        Kind
+	ExitCode    int
+	Restarts    int
+	Message     string
+	LastLogs    []string
+	At          time.Time
+}
+
+// Detector compares successive scans and reports crashes, restart loops and
+// processes that vanished. Stops initiated by the user are expected and ignored.
+type Detector struct {
+	loopThreshold int
+	loopWindow    time.Duration
+	prev          map[string]*service.Service
+	restarts      map[string][]time.Time
+	looping       map[string]bool
+	expected      map[string]time.Time
+}
+
+// creates a detector that flags a restart loop after threshold restarts within window.
+func NewDetector(threshold int, window time.Duration) *Detector {
+	return &Detector{
+		loopThreshold: threshold,
+		loopWindow:    window,
+		prev:          make(map[string]*service.Service),
+		restarts:      make(map[string][]time.Time),
+		looping:       make(map[string]bool),
+		expected:      make(map[string]time.Time),
+	}
+}
+
+// marks a service as about to stop on purpose, suppressing alerts for the next minute.
+func (d *Detector) Expect(serviceID string) {
+	d.expected[serviceID] = time.Now().Add(time.Minute)
+}
+
+// compares a scan with the previous one and returns new alerts.
+// The first scan only establishes a baseline.
+func (d *Detector) Check(at time.Time, services []*service.Service) []Alert {
+	var alerts []Alert
+	first := len(d.prev) == 0
+
+	current := make(map[string]*service.Service, len(services))
+	alivePIDs := make(map[int]bool)
+	for _, svc := range services {
+		current[svc.ID] = svc
+		if svc.PID != 0 {
+			alivePIDs[svc.PID] = true
+		}
+	}
+
+	for id, until := range d.expected {
+		if at.After(until) {
+			delete(d.expected, id)
+		}
+	}
+
+	for _, svc := range services {
+		prev, existed := d.prev[svc.ID]
+		if first || !existed {
+			continue
+		}
+		expected := d.isExpected(svc.ID)
+
+		if isContainer(svc) && svc.RestartCount > prev.RestartCount {
+			for i := prev.RestartCount; i < svc.RestartCount; i++ {
+				d.restarts[svc.ID] = append(d.restarts[svc.ID], at)
+			}
+		}
+		if a, ok := d.checkRestartLoop(at, svc); ok && !expected {
+			alerts = append(alerts, a)
+		}
+
+		if isContainer(svc) && prev.Status == service.StatusRunning &&
+			svc.Status == service.StatusStopped && svc.ExitCode != 0 && !expected {
+			alerts = append(alerts, Alert{
+				ID:          fmt.Sprintf("%s-%s-%d", svc.ID, KindCrashed, at.Unix()),
+				ServiceID:   svc.ID,
+				ServiceName: svc.Name,
+				ContainerID: svc.ContainerID,
+				Kind:        KindCrashed,
+				ExitCode:    svc.ExitCode,
+				Message:     fmt.Sprintf("%s exited with code %d", svc.Name, svc.ExitCode),
+				At:          at,
+			})
+		}
+	}
+
+	if !first {
+		// a process can be listed once by PID and once per port; expecting
+		// any of its rows covers them all
+		expectedPIDs := make(map[int]bool)
+		for id, prev := range d.prev {
+			if prev.PID != 0 && d.isExpected(id) {
+				expectedPIDs[prev.PID] = true
+			}
+		}
+		reported := make(map[int]bool)
+		for id, prev := range d.prev {
+			if _, ok := current[id]; ok || prev.Type != service.ServiceTypeProcess || prev.PID == 0 {
+				continue
+			}
+			if alivePIDs[prev.PID] || reported[prev.PID] || expectedPIDs[prev.PID] {
+				continue
+			}
+			reported[prev.PID] = true
+			alerts = append(alerts, Alert{
+				ID:          fmt.Sprintf("%s-%s-%d", id, KindDisappeared, at.Unix()),
+				ServiceID:   id,
+				ServiceName: prev.Name,
+				Kind:        KindDisappeared,
+				Message:     fmt.Sprintf("process %s (PID %d) is gone", prev.Name, prev.PID),
+				At:          at,
+			})
+		}
+	}
+
+	d.prev = current
+	return alerts
+}
+
+// reports a restart loop once when restarts within the window reach the threshold,
+// and re-arms after the window passes without restarts.
+func (d *Detector) checkRestartLoop(at time.Time, svc *service.Service) (Alert, bool) {
+	var recent []time.Time
+	for _, t := range d.restarts[svc.ID] {
+		if at.Sub(t) <= d.loopWindow {
+			recent = append(recent, t)
+		}
+	}
+	d.restarts[svc.ID] = recent
+
+	if len(recent) == 0 {
+		delete(d.looping, svc.ID)
+		return Alert{}, false
+	}
+	if len(recent) < d.loopThreshold || d.looping[svc.ID] {
+		return Alert{}, false
+	}
+
+	d.looping[svc.ID] = true
+	return Alert{
+		ID:          fmt.Sprintf("%s-%s-%d", svc.ID, KindRestartLoop, at.Unix()),
+		ServiceID:   svc.ID,
+		ServiceName: svc.Name,
+		ContainerID: svc.ContainerID,
+		Kind:        KindRestartLoop,
+		ExitCode:    svc.ExitCode,
+		Restarts:    svc.RestartCount,
+		Message: fmt.Sprintf("%s is restarting repeatedly (%d restarts in %s, last exit code %d)",
+			svc.Name, len(recent), d.loopWindow, svc.ExitCode),
+		At: at,
+	}, true
+}
+
+func (d *Detector) isExpected(id string) bool {
+	_, ok := d.expected[id]
+	return ok
+}
+
+func isContainer(svc *service.Service) bool {
+	return svc.Type == service.ServiceTypeDocker || svc.Type == service.ServiceTypeCompose
+}
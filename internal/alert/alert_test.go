@@ -0,0 +1,124 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eanda22/devhud/internal/service"
+)
+
+func container(id string, status service.Status, exitCode, restarts int) *service.Service {
+	return &service.Service{
+		ID:           id,
+		Name:         id,
+		ContainerID:  id,
+		Type:         service.ServiceTypeDocker,
+		Status:       status,
+		ExitCode:     exitCode,
+		RestartCount: restarts,
+	}
+}
+
+func TestDetectorCrash(t *testing.T) {
+	d := NewDetector(3, time.Minute)
+	now := time.Now()
+
+	if alerts := d.Check(now, []*service.Service{container("api", service.StatusRunning, 0, 0)}); len(alerts) != 0 {
+		t.Fatalf("baseline scan produced alerts: %+v", alerts)
+	}
+
+	alerts := d.Check(now.Add(2*time.Second), []*service.Service{container("api", service.StatusStopped, 137, 0)})
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %+v", alerts)
+	}
+	if alerts[0].Kind != KindCrashed || alerts[0].ExitCode != 137 {
+		t.Errorf("alert = %+v, want crashed with exit code 137", alerts[0])
+	}
+}
+
+func TestDetectorCleanExitIgnored(t *testing.T) {
+	d := NewDetector(3, time.Minute)
+	now := time.Now()
+	d.Check(now, []*service.Service{container("job", service.StatusRunning, 0, 0)})
+
+	if alerts := d.Check(now.Add(time.Second), []*service.Service{container("job", service.StatusStopped, 0, 0)}); len(alerts) != 0 {
+		t.Errorf("clean exit produced alerts: %+v", alerts)
+	}
+}
+
+func TestDetectorExpectedStop(t *testing.T) {
+	d := NewDetector(3, time.Minute)
+	now := time.Now()
+	d.Check(now, []*service.Service{container("api", service.StatusRunning, 0, 0)})
+	d.Expect("api")
+
+	if alerts := d.Check(now.Add(time.Second), []*service.Service{container("api", service.StatusStopped, 143, 0)}); len(alerts) != 0 {
+		t.Errorf("user-initiated stop produced alerts: %+v", alerts)
+	}
+}
+
+func TestDetectorRestartLoop(t *testing.T) {
+	d := NewDetector(3, time.Minute)
+	now := time.Now()
+	d.Check(now, []*service.Service{container("worker", service.StatusRunning, 0, 0)})
+
+	var loops int
+	for i := 1; i <= 5; i++ {
+		at := now.Add(time.Duration(i) * 5 * time.Second)
+		for _, a := range d.Check(at, []*service.Service{container("worker", service.StatusRunning, 1, i)}) {
+			if a.Kind == KindRestartLoop {
+				loops++
+			}
+		}
+	}
+	if loops != 1 {
+		t.Errorf("expected exactly one restart-loop alert, got %d", loops)
+	}
+
+	// Quiet for longer than the window re-arms the detector.
+	d.Check(now.Add(3*time.Minute), []*service.Service{container("worker", service.StatusRunning, 0, 5)})
+	var rearmed bool
+	for i := 6; i <= 8; i++ {
+		at := now.Add(3*time.Minute + time.Duration(i)*time.Second)
+		for _, a := range d.Check(at, []*service.Service{container("worker", service.StatusRunning, 1, i)}) {
+			if a.Kind == KindRestartLoop {
+				rearmed = true
+			}
+		}
+	}
+	if !rearmed {
+		t.Error("expected a new restart-loop alert after a quiet period")
+	}
+}
+
+func TestDetectorProcessDisappeared(t *testing.T) {
+	d := NewDetector(3, time.Minute)
+	now := time.Now()
+	byPID := &service.Service{ID: "4242", Name: "next dev", Type: service.ServiceTypeProcess, PID: 4242}
+	byPort := &service.Service{ID: "port-3000", Name: "node", Type: service.ServiceTypeProcess, PID: 4242, Port: 3000}
+	other := &service.Service{ID: "99", Name: "vite", Type: service.ServiceTypeProcess, PID: 99}
+
+	d.Check(now, []*service.Service{byPID, byPort, other})
+	alerts := d.Check(now.Add(time.Second), []*service.Service{other})
+
+	if len(alerts) != 1 {
+		t.Fatalf("expected one alert per dead PID, got %+v", alerts)
+	}
+	if alerts[0].Kind != KindDisappeared {
+		t.Errorf("alert kind = %s, want %s", alerts[0].Kind, KindDisappeared)
+	}
+}
+
+func TestDetectorExpectedKillCoversPortRow(t *testing.T) {
+	d := NewDetector(3, time.Minute)
+	now := time.Now()
+	byPID := &service.Service{ID: "4242", Name: "next dev", Type: service.ServiceTypeProcess, PID: 4242}
+	byPort := &service.Service{ID: "port-3000", Name: "node", Type: service.ServiceTypeProcess, PID: 4242, Port: 3000}
+
+	d.Check(now, []*service.Service{byPID, byPort})
+	d.Expect(byPID.ID)
+
+	if alerts := d.Check(now.Add(time.Second), nil); len(alerts) != 0 {
+		t.Errorf("killing a process listed twice produced alerts: %+v", alerts)
+	}
+}
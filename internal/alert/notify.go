@@ -0,0 +1,33 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// runs a notify-send compatible command as `<command...> <title> <body>`.
+// The command may include its own arguments, e.g. "notify-send -u critical".
+func Notify(command string, a Alert) error {
+	parts := strings.Fields(command)
+	if len(parts) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	title := "devhud: " + a.ServiceName
+	body := a.Message
+	if len(a.LastLogs) > 0 {
+		body += "\n" + a.LastLogs[len(a.LastLogs)-1]
+	}
+
+	args := append(parts[1:], title, body)
+	if out, err := exec.CommandContext(ctx, parts[0], args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("notify: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Config is the user configuration read from config.json in the devhud config directory.
+type Config struct {
+	Notify      NotifyConfig  `json:"notify"`
+	Connections []Connection  `json:"connections"`
+	Exec        []ExecCommand `json:"exec"`
+}
+
+// NotifyConfig controls desktop notifications for crash alerts.
+type NotifyConfig struct {
+	// Command is a notify-send compatible command, run as `<command> <title> <body>`.
+	Command string `json:"command"`
+}
+
+// Connection is a named database connection, for databases that are not
+// discovered from a container, such as a native server or a port-forward.
+type Connection struct {
+	Name string `json:"name"`
+	// Type is a database type as detected for containers, e.g. "postgres" or "mysql".
+	Type     string `json:"type"`
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	// PasswordCommand is run with sh -c instead of storing Password; its output
+	// without the trailing newline is the password.
+	PasswordCommand string `json:"password_command"`
+	Database        string `json:"database"`
+	// SSLMode is a libpq sslmode such as "disable" or "require".
+	SSLMode string `json:"sslmode"`
+}
+
+// Connection returns the connection with the given name.
+func (c *Config) Connection(name string) (Connection, bool) {
+	for _, conn := range c.Connections {
+		if conn.Name == name {
+			return conn, true
+		}
+	}
+	return Connection{}, false
+}
+
+// ResolvePassword returns Password, or the output of PasswordCommand if set.
+func (c Connection) ResolvePassword(ctx context.Context) (string, error) {
+	if c.PasswordCommand == "" {
+		return c.Password, nil
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.PasswordCommand)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("password command for %s: %w: %s", c.Name, err, msg)
+		}
+		return "", fmt.Errorf("password command for %s: %w", c.Name, err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// ExecCommand is a command offered in the action menu of containers whose
+// image matches, such as psql for postgres.
+type ExecCommand struct {
+	// Image matches the image's repository, ignoring the tag. Without a slash
+	// it matches the last path element, so "postgres" matches "postgres:16" and
+	// "docker.io/library/postgres". Globs such as "bitnami/*" are allowed.
+	Image string `json:"image"`
+	// Name labels the command in the menu; it defaults to Command.
+	Name string `json:"name"`
+	// Command is run with the container's shell, so it can use the
+	// container's environment, e.g. psql -U "$POSTGRES_USER".
+	Command    string `json:"command"`
+	User       string `json:"user"`
+	WorkingDir string `json:"working_dir"`
+}
+
+// Label returns the command's name in the action menu.
+func (e ExecCommand) Label() string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return e.Command
+}
+
+// Matches reports whether the command is offered for containers of image.
+func (e ExecCommand) Matches(image string) bool {
+	if e.Image == "" || e.Command == "" {
+		return false
+	}
+	repo := image
+	if i := strings.IndexByte(repo, '@'); i >= 0 {
+		repo = repo[:i]
+	}
+	if i := strings.LastIndexByte(repo, ':'); i > strings.LastIndexByte(repo, '/') {
+		repo = repo[:i]
+	}
+
+	if !strings.Contains(e.Image, "/") {
+		ok, _ := path.Match(e.Image, path.Base(repo))
+		return ok
+	}
+	for _, candidate := range []string{repo, strings.TrimPrefix(repo, "docker.io/")} {
+		if ok, _ := path.Match(e.Image, candidate); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ExecCommands returns the commands offered for containers of image.
+func (c *Config) ExecCommands(image string) []ExecCommand {
+	var commands []ExecCommand
+	for _, e := range c.Exec {
+		if e.Matches(image) {
+			commands = append(commands, e)
+		}
+	}
+	return commands
+}
+
+// Dir returns the devhud config directory, honouring DEVHUD_CONFIG_DIR.
+func Dir() (string, error) {
+	if dir := os.Getenv("DEVHUD_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("config dir: %w", err)
+	}
+	return filepath.Join(base, "devhud"), nil
+}
+
+// DataDir returns the directory for state devhud writes, such as query history.
+// It honours DEVHUD_DATA_DIR, then XDG_DATA_HOME, and defaults to ~/.local/share/devhud.
+func DataDir() (string, error) {
+	if dir := os.Getenv("DEVHUD_DATA_DIR"); dir != "" {
+		return dir, nil
+	}
+	if base := os.Getenv("XDG_DATA_HOME"); base != "" {
+		return filepath.Join(base, "devhud"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("data dir: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "devhud"), nil
+}
+
+// Load reads config.json from the config directory. A missing file yields defaults.
+func Load() (*Config, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(filepath.Join(dir, "config.json"))
+}
+
+// LoadFile reads a config from path. A missing file yields defaults.
+func LoadFile(path string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"notify": {"command": "notify-send -u critical"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if cfg.Notify.Command != "notify-send -u critical" {
+		t.Errorf("Notify.Command = %q", cfg.Notify.Command)
+	}
+}
+
+func TestLoadFileConnections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"connections": [{"name": "local", "type": "postgres", "host": "127.0.0.1", "user": "me", "password_command": "echo secret", "sslmode": "require"}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	conn, ok := cfg.Connection("local")
+	if !ok {
+		t.Fatal("Connection(local) not found")
+	}
+	if conn.Host != "127.0.0.1" || conn.User != "me" || conn.SSLMode != "require" {
+		t.Errorf("Connection(local) = %+v", conn)
+	}
+	if _, ok := cfg.Connection("missing"); ok {
+		t.Error("Connection(missing) found")
+	}
+}
+
+func TestExecCommandMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		image   string
+		want    bool
+	}{
+		{"postgres", "postgres", true},
+		{"postgres", "postgres:16-alpine", true},
+		{"postgres", "docker.io/library/postgres:16", true},
+		{"postgres", "postgres@sha256:0123", true},
+		{"postgres", "postgresql", false},
+		{"redis*", "redis-stack-server:latest", true},
+		{"bitnami/*", "bitnami/postgresql:16", true},
+		{"bitnami/*", "docker.io/bitnami/redis", true},
+		{"bitnami/*", "postgres", false},
+		{"app", "localhost:5000/team/app:dev", true},
+		{"app", "localhost:5000/app", true},
+	}
+	for _, tt := range tests {
+		e := ExecCommand{Image: tt.pattern, Command: "sh"}
+		if got := e.Matches(tt.image); got != tt.want {
+			t.Errorf("ExecCommand{Image: %q}.Matches(%q) = %v, want %v", tt.pattern, tt.image, got, tt.want)
+		}
+	}
+
+	cfg := &Config{Exec: []ExecCommand{
+		{Image: "postgres", Name: "psql", Command: "psql -U postgres"},
+		{Image: "redis", Command: "redis-cli"},
+		{Image: "postgres"},
+	}}
+	commands := cfg.ExecCommands("postgres:16")
+	if len(commands) != 1 || commands[0].Label() != "psql" {
+		t.Errorf("ExecCommands(postgres:16) = %+v, want psql only", commands)
+	}
+	if got := cfg.ExecCommands("redis:7")[0].Label(); got != "redis-cli" {
+		t.Errorf("Label() = %q, want the command", got)
+	}
+}
+
+func TestResolvePassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		conn    Connection
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", conn: Connection{Password: "pw"}, want: "pw"},
+		{name: "command", conn: Connection{Password: "ignored", PasswordCommand: "printf 'pw\\n'"}, want: "pw"},
+		{name: "keeps inner spaces", conn: Connection{PasswordCommand: "echo ' a b'"}, want: " a b"},
+		{name: "failing command", conn: Connection{PasswordCommand: "echo nope >&2; exit 3"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.conn.ResolvePassword(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolvePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolvePassword() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFileMissing(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if cfg.Notify.Command != "" {
+		t.Errorf("expected default config, got %+v", cfg)
+	}
+}
+
+func TestLoadFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{not json`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestDirOverride(t *testing.T) {
+	t.Setenv("DEVHUD_CONFIG_DIR", "/tmp/devhud-test")
+	dir, err := Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != "/tmp/devhud-test" {
+		t.Errorf("Dir() = %q, want override", dir)
+	}
+}
+
+func TestDataDir(t *testing.T) {
+	t.Setenv("DEVHUD_DATA_DIR", "")
+	t.Setenv("XDG_DATA_HOME", "/xdg")
+	if dir, err := DataDir(); err != nil || dir != "/xdg/devhud" {
+		t.Errorf("DataDir() = %q, %v, want /xdg/devhud", dir, err)
+	}
+
+	t.Setenv("DEVHUD_DATA_DIR", "/custom")
+	if dir, err := DataDir(); err != nil || dir != "/custom" {
+		t.Errorf("DataDir() = %q, %v, want /custom", dir, err)
+	}
+}
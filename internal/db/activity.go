@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Session is a connection to the database server and what it is doing.
+type Session struct {
+	ID       int64
+	User     string
+	Database string
+	Client   string
+	// State is "active", "idle in transaction" and so on for Postgres, and the
+	// command, such as "Query" or "Sleep", for MySQL.
+	State string
+	// Duration is how long the session has been running its query, or in its
+	// state when it is not running one.
+	Duration time.Duration
+	// Transaction is the age of the session's open transaction, or 0 without one.
+	Transaction time.Duration
+	// Wait names what the session is waiting for, if anything.
+	Wait  string
+	Query string
+	// BlockedBy holds the sessions holding the locks this one waits for, and
+	// Lock describes the lock, e.g. "AccessExclusiveLock on users".
+	BlockedBy []int64
+	Lock      string
+	// Blocking counts the sessions waiting for this one's locks.
+	Blocking int
+	// Own is set for the session the listing itself ran on.
+	Own bool
+}
+
+// Idle reports whether the session is connected but doing nothing. Sessions
+// idle in a transaction or holding locks others wait for are not idle.
+func (s Session) Idle() bool {
+	return (s.State == "idle" || s.State == "Sleep") && s.Transaction == 0 && s.Blocking == 0
+}
+
+// ActivityMonitor is implemented by SQL drivers that can list the sessions on
+// the server and stop their queries.
+type ActivityMonitor interface {
+	Sessions(ctx context.Context, db *sql.DB) ([]Session, error)
+	// CancelQuery stops the query a session is running and keeps the session.
+	CancelQuery(ctx context.Context, db *sql.DB, id int64) error
+	// Terminate closes a session, rolling back its transaction.
+	Terminate(ctx context.Context, db *sql.DB, id int64) error
+}
+
+// CanMonitor reports whether the client's sessions can be listed.
+func (c *Client) CanMonitor() bool {
+	_, ok := c.driver.(ActivityMonitor)
+	return ok
+}
+
+// Sessions lists the sessions on the server. Sessions blocking others come
+// first, then the ones waiting for them, then the longest running.
+func (c *Client) Sessions(ctx context.Context) ([]Session, error) {
+	monitor, ok := c.driver.(ActivityMonitor)
+	if !ok {
+		return nil, fmt.Errorf("session monitoring is not supported for %s", c.dbType)
+	}
+	sessions, err := monitor.Sessions(ctx, c.db)
+	if err != nil {
+		return nil, err
+	}
+	orderSessions(sessions)
+	return sessions, nil
+}
+
+// CancelQuery stops the query a session is running.
+func (c *Client) CancelQuery(ctx context.Context, id int64) error {
+	monitor, ok := c.driver.(ActivityMonitor)
+	if !ok {
+		return fmt.Errorf("session monitoring is not supported for %s", c.dbType)
+	}
+	return monitor.CancelQuery(ctx, c.db, id)
+}
+
+// TerminateSession closes a session.
+func (c *Client) TerminateSession(ctx context.Context, id int64) error {
+	monitor, ok := c.driver.(ActivityMonitor)
+	if !ok {
+		return fmt.Errorf("session monitoring is not supported for %s", c.dbType)
+	}
+	return monitor.Terminate(ctx, c.db, id)
+}
+
+// counts the sessions each one blocks and sorts blockers, then blocked
+// sessions, then the rest, each by duration.
+func orderSessions(sessions []Session) {
+	blocking := make(map[int64]int)
+	for _, s := range sessions {
+		for _, id := range s.BlockedBy {
+			blocking[id]++
+		}
+	}
+	rank := func(s Session) int {
+		switch {
+		case s.Blocking > 0:
+			return 2
+		case len(s.BlockedBy) > 0:
+			return 1
+		}
+		return 0
+	}
+	for i := range sessions {
+		sessions[i].Blocking = blocking[sessions[i].ID]
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if ri, rj := rank(sessions[i]), rank(sessions[j]); ri != rj {
+			return ri > rj
+		}
+		return sessions[i].Duration > sessions[j].Duration
+	})
+}
+
+// parses a comma-separated list of session IDs, skipping anything else.
+func parseIDs(list string) []int64 {
+	var ids []int64
+	for _, part := range strings.Split(list, ",") {
+		if id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func fromSeconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Millisecond)
+}
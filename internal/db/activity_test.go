@@ -0,0 +1,58 @@
+package db
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOrderSessions(t *testing.T) {
+	sessions := []Session{
+		{ID: 1, State: "active", Duration: time.Second},
+		{ID: 2, State: "active", Duration: 5 * time.Second, BlockedBy: []int64{4}},
+		{ID: 3, State: "active", Duration: 10 * time.Second},
+		{ID: 4, State: "idle in transaction", Duration: 2 * time.Second, Transaction: time.Minute},
+		{ID: 5, State: "active", Duration: 6 * time.Second, BlockedBy: []int64{4, 2}},
+	}
+	orderSessions(sessions)
+
+	var order []int64
+	for _, s := range sessions {
+		order = append(order, s.ID)
+	}
+	// 4 and 2 block others, 5 only waits
+	if want := []int64{2, 4, 5, 3, 1}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if sessions[1].Blocking != 2 || sessions[0].Blocking != 1 {
+		t.Errorf("Blocking = %d, %d, want 2, 1", sessions[1].Blocking, sessions[0].Blocking)
+	}
+}
+
+func TestSessionIdle(t *testing.T) {
+	tests := []struct {
+		session Session
+		want    bool
+	}{
+		{Session{State: "idle"}, true},
+		{Session{State: "Sleep"}, true},
+		{Session{State: "active"}, false},
+		{Session{State: "idle in transaction", Transaction: time.Second}, false},
+		{Session{State: "Sleep", Transaction: time.Second}, false},
+		{Session{State: "idle", Blocking: 1}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.session.Idle(); got != tt.want {
+			t.Errorf("%+v.Idle() = %v, want %v", tt.session, got, tt.want)
+		}
+	}
+}
+
+func TestParseIDs(t *testing.T) {
+	if got := parseIDs("12, 34,x,"); !slices.Equal(got, []int64{12, 34}) {
+		t.Errorf("parseIDs() = %v", got)
+	}
+	if got := parseIDs(""); got != nil {
+		t.Errorf("parseIDs(\"\") = %v, want nil", got)
+	}
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// Browser is a database whose tables (or collections) can be listed and paged through.
+type Browser interface {
+	ListTables(ctx context.Context) ([]TableInfo, error)
+	GetTableColumns(ctx context.Context, tableName string) ([]ColumnInfo, error)
+	GetTableData(ctx context.Context, tableName string, limit, offset int) (RowData, error)
+	Close() error
+}
+
+// DocumentStore is a Browser whose rows are JSON documents that can be filtered
+// with a query. Rows hold the document id under "_id" and pretty JSON under "document".
+type DocumentStore interface {
+	Browser
+	FindDocuments(ctx context.Context, collection, filter string, limit, offset int) (RowData, error)
+}
+
+// Open connects to a database of the given type and returns a browser for it.
+func Open(ctx context.Context, config *ConnectionConfig, dbType string) (Browser, error) {
+	driver, err := Lookup(dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch d := driver.(type) {
+	case SQLDriver:
+		client, err := NewClient(ctx, config, dbType)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case Opener:
+		return d.Open(ctx, config)
+	default:
+		return nil, fmt.Errorf("%s databases cannot be browsed as tables", dbType)
+	}
+}
@@ -4,36 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-
-	_ "github.com/go-sql-driver/mysql"
-	_ "github.com/lib/pq"
 )
 
 // Client wraps a database connection with type information.
 type Client struct {
 	db     *sql.DB
 	dbType string
+	driver SQLDriver
 	config *ConnectionConfig
 }
 
 // NewClient creates a new database client and opens a connection.
 func NewClient(ctx context.Context, config *ConnectionConfig, dbType string) (*Client, error) {
-	dsn := BuildConnectionString(config, dbType)
-	if dsn == "" {
-		return nil, fmt.Errorf("unsupported database type: %s", dbType)
-	}
-
-	var driverName string
-	switch dbType {
-	case "postgres":
-		driverName = "postgres"
-	case "mysql":
-		driverName = "mysql"
-	default:
-		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	driver, err := lookupSQL(dbType)
+	if err != nil {
+		return nil, err
 	}
 
-	db, err := sql.Open(driverName, dsn)
+	db, err := sql.Open(driver.SQLDriverName(), driver.DSN(config))
 	if err != nil {
 		return nil, fmt.Errorf("open connection: %w", err)
 	}
@@ -41,6 +29,7 @@ func NewClient(ctx context.Context, config *ConnectionConfig, dbType string) (*C
 	client := &Client{
 		db:     db,
 		dbType: dbType,
+		driver: driver,
 		config: config,
 	}
 
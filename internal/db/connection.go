@@ -1,13 +1,22 @@
 package db
 
 import (
+	"archive/tar"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"path"
 	"strings"
 
 	"github.com/docker/docker/client"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/env"
 )
 
+// secretFileLimit caps how much of a *_FILE secret is read.
+const secretFileLimit = 64 << 10
+
 // ConnectionConfig holds database connection parameters.
 type ConnectionConfig struct {
 	Host     string
@@ -15,10 +24,23 @@ type ConnectionConfig struct {
 	User     string
 	Password string
 	Database string
+	// Schema selects a schema within Database on servers that have them. Empty
+	// means the server's default.
+	Schema string
+	// SSLMode is a libpq sslmode. Empty means "disable", as containers on
+	// localhost rarely have TLS set up.
+	SSLMode string
 }
 
 // DiscoverConfig inspects a Docker container to extract database connection parameters from env vars.
+// Variables set through a *_FILE secret, as the official images allow, are read
+// from the file inside the container.
 func DiscoverConfig(ctx context.Context, dockerClient *client.Client, containerID, dbType string) (*ConnectionConfig, error) {
+	driver, err := Lookup(dbType)
+	if err != nil {
+		return nil, err
+	}
+
 	inspect, err := dockerClient.ContainerInspect(ctx, containerID)
 	if err != nil {
 		return nil, fmt.Errorf("inspect container: %w", err)
@@ -26,34 +48,20 @@ func DiscoverConfig(ctx context.Context, dockerClient *client.Client, containerI
 
 	config := &ConnectionConfig{
 		Host: "localhost",
-		Port: getDefaultPort(dbType),
+		Port: driver.DefaultPort(),
 	}
-
-	envMap := make(map[string]string)
-	for _, env := range inspect.Config.Env {
-		parts := strings.SplitN(env, "=", 2)
-		if len(parts) == 2 {
-			envMap[parts[0]] = parts[1]
+	vars := env.Parse(inspect.Config.Env)
+	for key, path := range fileSecrets(vars, driver.EnvKeys()) {
+		if value, err := readContainerFile(ctx, dockerClient, containerID, path); err == nil {
+			vars[key] = strings.TrimRight(value, "\r\n")
 		}
 	}
-
-	switch dbType {
-	case "postgres":
-		config.User = "postgres"
-		config.Password = getEnv(envMap, "POSTGRES_PASSWORD", "")
-		config.Database = getEnv(envMap, "POSTGRES_DB", "postgres")
-	case "mysql":
-		config.User = getEnv(envMap, "MYSQL_USER", "root")
-		config.Password = getEnv(envMap, "MYSQL_PASSWORD", getEnv(envMap, "MYSQL_ROOT_PASSWORD", ""))
-		config.Database = getEnv(envMap, "MYSQL_DATABASE", "mysql")
-	default:
-		return nil, fmt.Errorf("unsupported database type: %s", dbType)
-	}
+	driver.Configure(config, vars, inspect.Args)
 
 	if inspect.NetworkSettings != nil && len(inspect.NetworkSettings.Ports) > 0 {
 		for portBinding := range inspect.NetworkSettings.Ports {
 			portStr := portBinding.Port()
-			if portStr == getDefaultPort(dbType) {
+			if portStr == driver.DefaultPort() {
 				bindings := inspect.NetworkSettings.Ports[portBinding]
 				if len(bindings) > 0 && bindings[0].HostPort != "" {
 					config.Port = bindings[0].HostPort
@@ -66,28 +74,91 @@ func DiscoverConfig(ctx context.Context, dockerClient *client.Client, containerI
 	return config, nil
 }
 
+// SavedConfig builds the settings for a saved connection, running its
+// password command. Host and port default to the database's port on localhost.
+func SavedConfig(ctx context.Context, conn config.Connection) (*ConnectionConfig, error) {
+	driver, err := Lookup(conn.Type)
+	if err != nil {
+		return nil, fmt.Errorf("connection %s: %w", conn.Name, err)
+	}
+	password, err := conn.ResolvePassword(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config := &ConnectionConfig{
+		Host:     conn.Host,
+		Port:     conn.Port,
+		User:     conn.User,
+		Password: password,
+		Database: conn.Database,
+		SSLMode:  conn.SSLMode,
+	}
+	if config.Host == "" {
+		config.Host = "localhost"
+	}
+	if config.Port == "" {
+		config.Port = driver.DefaultPort()
+	}
+	return config, nil
+}
+
+// returns the path of each <KEY>_FILE variable by KEY, for the given keys when
+// they are not set directly.
+func fileSecrets(vars map[string]string, keys []string) map[string]string {
+	files := make(map[string]string)
+	for _, key := range keys {
+		value := vars[key+"_FILE"]
+		if !path.IsAbs(value) || vars[key] != "" {
+			continue
+		}
+		files[key] = value
+	}
+	return files
+}
+
+// reads a small file from a container's filesystem.
+func readContainerFile(ctx context.Context, dockerClient *client.Client, containerID, path string) (string, error) {
+	rc, _, err := dockerClient.CopyFromContainer(ctx, containerID, path)
+	if err != nil {
+		return "", fmt.Errorf("copy %s: %w", path, err)
+	}
+	defer rc.Close()
+	return readTarFile(rc)
+}
+
+// returns the contents of the first regular file in a tar stream.
+func readTarFile(r io.Reader) (string, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return "", errors.New("no file in archive")
+		}
+		if err != nil {
+			return "", fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Size > secretFileLimit {
+			return "", fmt.Errorf("%s is larger than %d bytes", hdr.Name, secretFileLimit)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return "", fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+		return string(data), nil
+	}
+}
+
 // BuildConnectionString formats a DSN string for database/sql drivers.
+// It returns "" for database types that are not reached through database/sql.
 func BuildConnectionString(config *ConnectionConfig, dbType string) string {
-	switch dbType {
-	case "postgres":
-		return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-			config.User,
-			config.Password,
-			config.Host,
-			config.Port,
-			config.Database,
-		)
-	case "mysql":
-		return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
-			config.User,
-			config.Password,
-			config.Host,
-			config.Port,
-			config.Database,
-		)
-	default:
+	driver, err := lookupSQL(dbType)
+	if err != nil {
 		return ""
 	}
+	return driver.DSN(config)
 }
 
 func getEnv(envMap map[string]string, key, defaultValue string) string {
@@ -98,12 +169,9 @@ func getEnv(envMap map[string]string, key, defaultValue string) string {
 }
 
 func getDefaultPort(dbType string) string {
-	switch dbType {
-	case "postgres":
-		return "5432"
-	case "mysql":
-		return "3306"
-	default:
+	driver, err := Lookup(dbType)
+	if err != nil {
 		return ""
 	}
+	return driver.DefaultPort()
 }
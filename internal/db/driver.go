@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// Driver knows how to find and connect to one kind of database running in a container.
+type Driver interface {
+	// DefaultPort is the port the database listens on inside its container.
+	DefaultPort() string
+	// Configure fills in credentials from the container's environment and command line.
+	Configure(config *ConnectionConfig, env map[string]string, args []string)
+	// EnvKeys lists the variables Configure reads. The image may set each of
+	// them through a <KEY>_FILE secret instead.
+	EnvKeys() []string
+}
+
+// SQLDriver is a Driver for databases reached through database/sql. Drivers
+// implementing it are browsed with Client.
+type SQLDriver interface {
+	Driver
+	// SQLDriverName is the name the database/sql driver is registered under.
+	SQLDriverName() string
+	DSN(config *ConnectionConfig) string
+	ListTables(ctx context.Context, db *sql.DB) ([]TableInfo, error)
+	Columns(ctx context.Context, db *sql.DB, tableName string) ([]ColumnInfo, error)
+	// PrimaryKey returns the table's primary key columns in key order.
+	PrimaryKey(ctx context.Context, db *sql.DB, tableName string) ([]string, error)
+	Quote(name string) string
+	// Placeholder returns the bind parameter marker for the nth (1-based) argument.
+	Placeholder(n int) string
+	// AsText casts an expression to text so LIKE works on any column type.
+	AsText(expr string) string
+}
+
+// Opener is implemented by non-SQL drivers that provide their own Browser.
+type Opener interface {
+	Open(ctx context.Context, config *ConnectionConfig) (Browser, error)
+}
+
+var drivers = make(map[string]Driver)
+
+// Register makes a driver available under a database type name, as returned by DetectType.
+func Register(dbType string, driver Driver) {
+	drivers[dbType] = driver
+}
+
+// Lookup returns the driver registered for a database type.
+func Lookup(dbType string) (Driver, error) {
+	driver, ok := drivers[dbType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+	return driver, nil
+}
+
+// Types returns the registered database types in sorted order.
+func Types() []string {
+	types := make([]string, 0, len(drivers))
+	for t := range drivers {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// lookupSQL returns the driver for a database type if it is reached through database/sql.
+func lookupSQL(dbType string) (SQLDriver, error) {
+	driver, err := Lookup(dbType)
+	if err != nil {
+		return nil, err
+	}
+	sqlDriver, ok := driver.(SQLDriver)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a SQL database", dbType)
+	}
+	return sqlDriver, nil
+}
+
+// runs a query returning (table name, kind, column count, estimated rows) rows.
+// An estimate below zero means the catalog has none.
+func queryTables(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]TableInfo, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []TableInfo
+	for rows.Next() {
+		var table TableInfo
+		if err := rows.Scan(&table.Name, &table.Kind, &table.ColumnCount, &table.RowCount); err != nil {
+			return nil, fmt.Errorf("scan table: %w", err)
+		}
+		if table.RowCount < 0 {
+			table.RowCount = -1
+		} else {
+			table.Approximate = true
+		}
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tables: %w", err)
+	}
+
+	return tables, nil
+}
+
+// runs a query returning (column name, data type) rows.
+func queryColumns(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]ColumnInfo, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var col ColumnInfo
+		if err := rows.Scan(&col.Name, &col.Type); err != nil {
+			return nil, fmt.Errorf("scan column: %w", err)
+		}
+		columns = append(columns, col)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate columns: %w", err)
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypes(t *testing.T) {
+	want := []string{"mariadb", "mongodb", "mysql", "postgres", "redis", "sqlite"}
+	if got := Types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Types() = %v, want %v", got, want)
+	}
+	if _, err := Lookup("cassandra"); err == nil {
+		t.Error("expected error for unregistered type")
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType string
+		env    map[string]string
+		args   []string
+		want   ConnectionConfig
+	}{
+		{
+			name:   "postgres defaults",
+			dbType: "postgres",
+			env:    map[string]string{"POSTGRES_PASSWORD": "pw"},
+			want:   ConnectionConfig{User: "postgres", Password: "pw", Database: "postgres"},
+		},
+		{
+			name:   "postgres user names the default database",
+			dbType: "postgres",
+			env:    map[string]string{"POSTGRES_USER": "app", "POSTGRES_PASSWORD": "pw"},
+			want:   ConnectionConfig{User: "app", Password: "pw", Database: "app"},
+		},
+		{
+			name:   "postgres user and database",
+			dbType: "postgres",
+			env:    map[string]string{"POSTGRES_USER": "app", "POSTGRES_DB": "shop"},
+			want:   ConnectionConfig{User: "app", Database: "shop"},
+		},
+		{
+			name:   "mysql root password fallback",
+			dbType: "mysql",
+			env:    map[string]string{"MYSQL_ROOT_PASSWORD": "rootpw", "MYSQL_DATABASE": "app"},
+			want:   ConnectionConfig{User: "root", Password: "rootpw", Database: "app"},
+		},
+		{
+			name:   "mariadb env",
+			dbType: "mariadb",
+			env: map[string]string{
+				"MARIADB_USER":     "shop",
+				"MARIADB_PASSWORD": "shoppw",
+				"MARIADB_DATABASE": "store",
+			},
+			want: ConnectionConfig{User: "shop", Password: "shoppw", Database: "store"},
+		},
+		{
+			name:   "mariadb legacy mysql env",
+			dbType: "mariadb",
+			env:    map[string]string{"MYSQL_ROOT_PASSWORD": "legacy", "MARIADB_DATABASE": "store"},
+			want:   ConnectionConfig{User: "root", Password: "legacy", Database: "store"},
+		},
+		{
+			name:   "redis requirepass",
+			dbType: "redis",
+			args:   []string{"--requirepass", "cache"},
+			want:   ConnectionConfig{Password: "cache", Database: "0"},
+		},
+		{
+			name:   "mongodb root user",
+			dbType: "mongodb",
+			env:    map[string]string{"MONGO_INITDB_ROOT_USERNAME": "root", "MONGO_INITDB_ROOT_PASSWORD": "example"},
+			want:   ConnectionConfig{User: "root", Password: "example"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := Lookup(tt.dbType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got ConnectionConfig
+			driver.Configure(&got, tt.env, tt.args)
+			if got != tt.want {
+				t.Errorf("Configure() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceString(t *testing.T) {
+	tests := []struct {
+		ns   Namespace
+		want string
+	}{
+		{Namespace{Database: "app"}, "app"},
+		{Namespace{Database: "app", Schema: "tenant_1"}, "app / tenant_1"},
+	}
+	for _, tt := range tests {
+		if got := tt.ns.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
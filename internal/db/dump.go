@@ -0,0 +1,102 @@
+package db
+
+import "fmt"
+
+// ContainerCommand is a command run inside a database container, with the
+// environment it needs, such as the password.
+type ContainerCommand struct {
+	Cmd []string
+	Env []string
+}
+
+// Dumper is implemented by SQL drivers whose server images ship tools to dump
+// a database as SQL and load it back.
+type Dumper interface {
+	// DumpCommand writes the database to stdout.
+	DumpCommand(config *ConnectionConfig) ContainerCommand
+	// RestoreCommand reads a dump from stdin and replaces the dumped objects.
+	RestoreCommand(config *ConnectionConfig) ContainerCommand
+}
+
+// CanDump reports whether snapshots can be taken of a database type.
+func CanDump(dbType string) bool {
+	driver, err := Lookup(dbType)
+	if err != nil {
+		return false
+	}
+	_, ok := driver.(Dumper)
+	return ok
+}
+
+// DumpCommands returns the commands that dump config's database inside its
+// container and restore it from that dump.
+func DumpCommands(dbType string, config *ConnectionConfig) (dump, restore ContainerCommand, err error) {
+	driver, err := Lookup(dbType)
+	if err != nil {
+		return dump, restore, err
+	}
+	dumper, ok := driver.(Dumper)
+	if !ok {
+		return dump, restore, fmt.Errorf("snapshots are not supported for %s", dbType)
+	}
+	return dumper.DumpCommand(config), dumper.RestoreCommand(config), nil
+}
+
+// The dump has no DROP statements; the restore clears the database first.
+// Ownership is left to the restoring user.
+func (postgresDriver) DumpCommand(config *ConnectionConfig) ContainerCommand {
+	return ContainerCommand{
+		Cmd: []string{"pg_dump", "--username", config.User, "--dbname", config.Database, "--no-owner"},
+		Env: []string{"PGPASSWORD=" + config.Password},
+	}
+}
+
+// drops every user schema with whatever depends on it, so tables, views and
+// foreign keys created after the snapshot go too, and leaves an empty public
+// schema for the dump to load into.
+const postgresResetSchemas = `DO $$
+DECLARE s name;
+BEGIN
+  FOR s IN SELECT nspname FROM pg_namespace
+    WHERE nspname NOT LIKE 'pg\_%' AND nspname <> 'information_schema'
+  LOOP
+    EXECUTE format('DROP SCHEMA %I CASCADE', s);
+  END LOOP;
+END $$;
+CREATE SCHEMA public;`
+
+// The reset and the dump from stdin run in one transaction that stops at the
+// first error, so a failed restore leaves the database as it was.
+func (postgresDriver) RestoreCommand(config *ConnectionConfig) ContainerCommand {
+	return ContainerCommand{
+		Cmd: []string{"psql", "--username", config.User, "--dbname", config.Database,
+			"--quiet", "--single-transaction", "--set", "ON_ERROR_STOP=1",
+			"--command", postgresResetSchemas, "--file", "-"},
+		Env: []string{"PGPASSWORD=" + config.Password},
+	}
+}
+
+// MariaDB 11 images ship only the mariadb-* names of the client tools, so the
+// first of the two that exists is run.
+func mysqlTool(name, fallback string, args ...string) []string {
+	script := fmt.Sprintf(`exec "$(command -v %s || command -v %s)" "$@"`, name, fallback)
+	return append([]string{"sh", "-c", script, "sh"}, args...)
+}
+
+// The dump includes DROP DATABASE and CREATE DATABASE, so a restore also
+// removes tables created after the snapshot.
+func (mysqlDriver) DumpCommand(config *ConnectionConfig) ContainerCommand {
+	return ContainerCommand{
+		Cmd: mysqlTool("mariadb-dump", "mysqldump",
+			"--user="+config.User, "--single-transaction", "--routines", "--triggers",
+			"--add-drop-database", "--databases", config.Database),
+		Env: []string{"MYSQL_PWD=" + config.Password},
+	}
+}
+
+func (mysqlDriver) RestoreCommand(config *ConnectionConfig) ContainerCommand {
+	return ContainerCommand{
+		Cmd: mysqlTool("mariadb", "mysql", "--user="+config.User),
+		Env: []string{"MYSQL_PWD=" + config.Password},
+	}
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDumpCommands(t *testing.T) {
+	config := &ConnectionConfig{User: "app", Password: "secret", Database: "shop"}
+
+	tests := []struct {
+		dbType      string
+		dump        string
+		restore     string
+		env         string
+		unsupported bool
+	}{
+		{
+			dbType:  "postgres",
+			dump:    "pg_dump --username app --dbname shop --no-owner",
+			restore: "psql --username app --dbname shop --quiet --single-transaction --set ON_ERROR_STOP=1 --command " + postgresResetSchemas + " --file -",
+			env:     "PGPASSWORD=secret",
+		},
+		{
+			dbType:  "mysql",
+			dump:    `sh -c exec "$(command -v mariadb-dump || command -v mysqldump)" "$@" sh --user=app --single-transaction --routines --triggers --add-drop-database --databases shop`,
+			restore: `sh -c exec "$(command -v mariadb || command -v mysql)" "$@" sh --user=app`,
+			env:     "MYSQL_PWD=secret",
+		},
+		{dbType: "sqlite", unsupported: true},
+		{dbType: "mongodb", unsupported: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			dump, restore, err := DumpCommands(tt.dbType, config)
+			if tt.unsupported {
+				if err == nil {
+					t.Error("DumpCommands() error = nil, want unsupported")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DumpCommands() error: %v", err)
+			}
+			if got := strings.Join(dump.Cmd, " "); got != tt.dump {
+				t.Errorf("dump = %q, want %q", got, tt.dump)
+			}
+			if got := strings.Join(restore.Cmd, " "); got != tt.restore {
+				t.Errorf("restore = %q, want %q", got, tt.restore)
+			}
+			if len(dump.Env) != 1 || dump.Env[0] != tt.env || len(restore.Env) != 1 || restore.Env[0] != tt.env {
+				t.Errorf("env = %v / %v, want %q", dump.Env, restore.Env, tt.env)
+			}
+		})
+	}
+}
+
+func TestPostgresRestoreResetsSchemas(t *testing.T) {
+	_, restore, err := DumpCommands("postgres", &ConnectionConfig{User: "app", Database: "shop"})
+	if err != nil {
+		t.Fatalf("DumpCommands() error: %v", err)
+	}
+	cmd := restore.Cmd
+	i := slices.Index(cmd, "--command")
+	if i < 0 || i+1 >= len(cmd) {
+		t.Fatalf("restore = %q, want a --command that clears the database", cmd)
+	}
+	if j := slices.Index(cmd, "--file"); j < i || cmd[j+1] != "-" {
+		t.Errorf("restore = %q, want the dump read from stdin after the reset", cmd)
+	}
+	if !slices.Contains(cmd, "--single-transaction") {
+		t.Errorf("restore = %q, want the reset and the dump in one transaction", cmd)
+	}
+	script := cmd[i+1]
+	for _, want := range []string{"DROP SCHEMA %I CASCADE", "CREATE SCHEMA public"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("reset script missing %q:\n%s", want, script)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Change kinds.
+const (
+	ChangeUpdate = "UPDATE"
+	ChangeInsert = "INSERT"
+	ChangeDelete = "DELETE"
+)
+
+// ErrNoPrimaryKey is returned for edits to a table without a primary key, whose
+// rows cannot be addressed one at a time.
+var ErrNoPrimaryKey = errors.New("table has no primary key")
+
+// ColumnValue is a value for one column; a nil Value is NULL.
+type ColumnValue struct {
+	Column string
+	Value  interface{}
+}
+
+// RowChange is an edit to one row of a table. Key holds the primary key of the
+// row to update or delete. Values holds the new values for an update, or the
+// columns set by an insert; the others take their defaults.
+type RowChange struct {
+	Kind   string
+	Key    []ColumnValue
+	Values []ColumnValue
+}
+
+// Statement is a parameterized statement. Preview is the same statement with
+// the values inlined, for showing before it runs.
+type Statement struct {
+	SQL     string
+	Args    []interface{}
+	Preview string
+}
+
+// ChangeStatement builds the statement for a change to a table.
+func (c *Client) ChangeStatement(tableName string, change RowChange) (Statement, error) {
+	return buildChange(c.driver, tableName, change)
+}
+
+// ApplyChanges runs statements in one transaction. Each statement must change
+// exactly one row, otherwise the transaction is rolled back and nothing changes.
+func (c *Client) ApplyChanges(ctx context.Context, statements []Statement) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, stmt := range statements {
+		result, err := tx.ExecContext(ctx, stmt.SQL, stmt.Args...)
+		if err != nil {
+			return fmt.Errorf("statement %d: %w", i+1, err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("statement %d: rows affected: %w", i+1, err)
+		}
+		if n != 1 {
+			return fmt.Errorf("statement %d changed %d rows, want 1; rolled back", i+1, n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// builds the statement for a change twice over: once with placeholders and
+// once with literals for the preview.
+func buildChange(driver SQLDriver, tableName string, change RowChange) (Statement, error) {
+	switch change.Kind {
+	case ChangeUpdate, ChangeInsert, ChangeDelete:
+	default:
+		return Statement{}, fmt.Errorf("unknown change kind %q", change.Kind)
+	}
+	if change.Kind != ChangeInsert && len(change.Key) == 0 {
+		return Statement{}, ErrNoPrimaryKey
+	}
+	if change.Kind == ChangeUpdate && len(change.Values) == 0 {
+		return Statement{}, fmt.Errorf("update sets no columns")
+	}
+
+	var stmt Statement
+	_, mysql := driver.(mysqlDriver)
+	style := literalStyleFor(driver)
+	bind := func(v interface{}) string {
+		stmt.Args = append(stmt.Args, v)
+		return driver.Placeholder(len(stmt.Args))
+	}
+	literal := func(v interface{}) string {
+		return sqlLiteral(v, style)
+	}
+
+	build := func(param func(interface{}) string) string {
+		table := driver.Quote(tableName)
+		switch change.Kind {
+		case ChangeUpdate:
+			return fmt.Sprintf("UPDATE %s SET %s WHERE %s", table,
+				strings.Join(assignments(driver, change.Values, param), ", "),
+				strings.Join(assignments(driver, change.Key, param), " AND "))
+		case ChangeDelete:
+			return fmt.Sprintf("DELETE FROM %s WHERE %s", table,
+				strings.Join(assignments(driver, change.Key, param), " AND "))
+		}
+		if len(change.Values) == 0 {
+			if mysql {
+				return fmt.Sprintf("INSERT INTO %s () VALUES ()", table)
+			}
+			return fmt.Sprintf("INSERT INTO %s DEFAULT VALUES", table)
+		}
+		columns := make([]string, len(change.Values))
+		values := make([]string, len(change.Values))
+		for i, cv := range change.Values {
+			columns[i] = driver.Quote(cv.Column)
+			values[i] = param(cv.Value)
+		}
+		return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table,
+			strings.Join(columns, ", "), strings.Join(values, ", "))
+	}
+
+	stmt.SQL = build(bind)
+	stmt.Preview = build(literal) + ";"
+	return stmt, nil
+}
+
+// formats "column" = value pairs.
+func assignments(driver SQLDriver, values []ColumnValue, param func(interface{}) string) []string {
+	out := make([]string, len(values))
+	for i, cv := range values {
+		out[i] = driver.Quote(cv.Column) + " = " + param(cv.Value)
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuildChange(t *testing.T) {
+	key := []ColumnValue{{Column: "id", Value: int64(7)}}
+
+	tests := []struct {
+		name        string
+		dbType      string
+		change      RowChange
+		wantSQL     string
+		wantArgs    []interface{}
+		wantPreview string
+		wantErr     error
+	}{
+		{
+			name:        "update",
+			dbType:      "postgres",
+			change:      RowChange{Kind: ChangeUpdate, Key: key, Values: []ColumnValue{{Column: "email", Value: "o'neil@x.io"}, {Column: "note", Value: nil}}},
+			wantSQL:     `UPDATE "users" SET "email" = $1, "note" = $2 WHERE "id" = $3`,
+			wantArgs:    []interface{}{"o'neil@x.io", nil, int64(7)},
+			wantPreview: `UPDATE "users" SET "email" = 'o''neil@x.io', "note" = NULL WHERE "id" = 7;`,
+		},
+		{
+			name:        "insert",
+			dbType:      "mysql",
+			change:      RowChange{Kind: ChangeInsert, Values: []ColumnValue{{Column: "email", Value: `a\b`}}},
+			wantSQL:     "INSERT INTO `users` (`email`) VALUES (?)",
+			wantArgs:    []interface{}{`a\b`},
+			wantPreview: "INSERT INTO `users` (`email`) VALUES ('a\\\\b');",
+		},
+		{
+			name:        "insert defaults",
+			dbType:      "sqlite",
+			change:      RowChange{Kind: ChangeInsert},
+			wantSQL:     `INSERT INTO "users" DEFAULT VALUES`,
+			wantPreview: `INSERT INTO "users" DEFAULT VALUES;`,
+		},
+		{
+			name:        "insert defaults on mysql",
+			dbType:      "mariadb",
+			change:      RowChange{Kind: ChangeInsert},
+			wantSQL:     "INSERT INTO `users` () VALUES ()",
+			wantPreview: "INSERT INTO `users` () VALUES ();",
+		},
+		{
+			name:        "delete by composite key",
+			dbType:      "postgres",
+			change:      RowChange{Kind: ChangeDelete, Key: []ColumnValue{{Column: "org", Value: "acme"}, {Column: "id", Value: int64(7)}}},
+			wantSQL:     `DELETE FROM "users" WHERE "org" = $1 AND "id" = $2`,
+			wantArgs:    []interface{}{"acme", int64(7)},
+			wantPreview: `DELETE FROM "users" WHERE "org" = 'acme' AND "id" = 7;`,
+		},
+		{
+			name:    "delete without a key",
+			dbType:  "postgres",
+			change:  RowChange{Kind: ChangeDelete},
+			wantErr: ErrNoPrimaryKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := lookupSQL(tt.dbType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := buildChange(driver, "users", tt.change)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("buildChange() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.SQL != tt.wantSQL {
+				t.Errorf("buildChange() sql = %s, want %s", got.SQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(got.Args, tt.wantArgs) {
+				t.Errorf("buildChange() args = %#v, want %#v", got.Args, tt.wantArgs)
+			}
+			if got.Preview != tt.wantPreview {
+				t.Errorf("buildChange() preview = %s, want %s", got.Preview, tt.wantPreview)
+			}
+		})
+	}
+}
+
+func TestApplyChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.sqlite3")
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = raw.Exec(`
+		CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT, price INTEGER DEFAULT 1);
+		INSERT INTO items (name, price) VALUES ('apple', 3), ('banana', 2);
+	`)
+	raw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: path}, "sqlite")
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	defer client.Close()
+
+	statements := func(changes ...RowChange) []Statement {
+		var out []Statement
+		for _, c := range changes {
+			stmt, err := client.ChangeStatement("items", c)
+			if err != nil {
+				t.Fatalf("ChangeStatement() error: %v", err)
+			}
+			out = append(out, stmt)
+		}
+		return out
+	}
+	names := func() []string {
+		rows, err := client.QueryTable(ctx, "items", TableQuery{Keys: []string{"id"}})
+		if err != nil {
+			t.Fatalf("QueryTable() error: %v", err)
+		}
+		var out []string
+		for _, row := range rows {
+			name, _ := row["name"].(string)
+			out = append(out, name)
+		}
+		return out
+	}
+
+	err = client.ApplyChanges(ctx, statements(
+		RowChange{Kind: ChangeUpdate, Key: []ColumnValue{{Column: "id", Value: int64(1)}}, Values: []ColumnValue{{Column: "name", Value: "apricot"}}},
+		RowChange{Kind: ChangeDelete, Key: []ColumnValue{{Column: "id", Value: int64(2)}}},
+		RowChange{Kind: ChangeInsert, Values: []ColumnValue{{Column: "name", Value: "cherry"}}},
+	))
+	if err != nil {
+		t.Fatalf("ApplyChanges() error: %v", err)
+	}
+	if got, want := names(), []string{"apricot", "cherry"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after ApplyChanges() names = %v, want %v", got, want)
+	}
+
+	// the second statement matches no row, so the first is rolled back too
+	err = client.ApplyChanges(ctx, statements(
+		RowChange{Kind: ChangeDelete, Key: []ColumnValue{{Column: "id", Value: int64(1)}}},
+		RowChange{Kind: ChangeDelete, Key: []ColumnValue{{Column: "id", Value: int64(99)}}},
+	))
+	if err == nil {
+		t.Fatal("ApplyChanges() error = nil for a statement that changed no rows")
+	}
+	if got, want := names(), []string{"apricot", "cherry"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after rollback names = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,332 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Export formats.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatSQL    = "sql"
+)
+
+// ExportFormats lists the export formats in menu order.
+var ExportFormats = []string{FormatCSV, FormatNDJSON, FormatSQL}
+
+// queryExportTable names the table in INSERT statements exported from a query result.
+const queryExportTable = "query_result"
+
+// rowWriter writes rows in one export format.
+type rowWriter interface {
+	WriteRow(values []interface{}) error
+	Flush() error
+}
+
+// ExportFile runs write on a temporary file next to path and renames it over
+// path only if write succeeds, so a failed or cancelled export never leaves a
+// truncated file behind. It returns the number of rows written.
+func ExportFile(path string, write func(w io.Writer) (int, error)) (int, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("create file: %w", err)
+	}
+	rows, err := write(f)
+	if err == nil {
+		err = f.Chmod(0o644)
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close file: %w", closeErr)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return 0, err
+	}
+	return rows, nil
+}
+
+// ExportTable streams a table's rows matching q's filters and sort to out.
+// q.Limit of 0 exports every row. It returns the number of rows written.
+func (c *Client) ExportTable(ctx context.Context, tableName string, q TableQuery, format string, out io.Writer) (int, error) {
+	query, args, err := buildSelect(c.driver, tableName, q)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("query table data: %w", err)
+	}
+	defer rows.Close()
+	return c.exportRows(rows, tableName, format, out)
+}
+
+// ExportQuery streams the result of a statement that returns rows to out. It
+// returns the number of rows written.
+func (c *Client) ExportQuery(ctx context.Context, statement, format string, out io.Writer) (int, error) {
+	return c.exportQuery(ctx, c.db, statement, format, out)
+}
+
+func (c *Client) exportQuery(ctx context.Context, q queryer, statement, format string, out io.Writer) (int, error) {
+	if !returnsRows(statement) {
+		return 0, fmt.Errorf("statement returns no rows")
+	}
+	rows, err := q.QueryContext(ctx, statement)
+	if err != nil {
+		return 0, fmt.Errorf("run query: %w", err)
+	}
+	defer rows.Close()
+	return c.exportRows(rows, queryExportTable, format, out)
+}
+
+// ExportRows writes rows that are already loaded, such as the page on screen.
+// An empty tableName names INSERTs after a query result.
+func (c *Client) ExportRows(columns []ColumnInfo, data RowData, tableName, format string, out io.Writer) (int, error) {
+	if tableName == "" {
+		tableName = queryExportTable
+	}
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	w, err := c.newRowWriter(out, format, tableName, names)
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range data {
+		values := make([]interface{}, len(names))
+		for i, name := range names {
+			values[i] = row[name]
+		}
+		if err := w.WriteRow(values); err != nil {
+			return 0, fmt.Errorf("write row: %w", err)
+		}
+	}
+	return len(data), w.Flush()
+}
+
+// writes rows one at a time as they are read, so memory use stays flat.
+func (c *Client) exportRows(rows *sql.Rows, tableName, format string, out io.Writer) (int, error) {
+	names, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("get columns: %w", err)
+	}
+	w, err := c.newRowWriter(out, format, tableName, names)
+	if err != nil {
+		return 0, err
+	}
+
+	values := make([]interface{}, len(names))
+	ptrs := make([]interface{}, len(names))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	count := 0
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return count, fmt.Errorf("scan row: %w", err)
+		}
+		if err := w.WriteRow(values); err != nil {
+			return count, fmt.Errorf("write row: %w", err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("iterate rows: %w", err)
+	}
+	return count, w.Flush()
+}
+
+func (c *Client) newRowWriter(out io.Writer, format, tableName string, columns []string) (rowWriter, error) {
+	switch format {
+	case FormatCSV:
+		w := csv.NewWriter(out)
+		if err := w.Write(columns); err != nil {
+			return nil, fmt.Errorf("write header: %w", err)
+		}
+		return &csvWriter{w: w, record: make([]string, len(columns))}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{w: bufio.NewWriter(out), columns: columns}, nil
+	case FormatSQL:
+		quoted := make([]string, len(columns))
+		for i, name := range columns {
+			quoted[i] = c.driver.Quote(name)
+		}
+		prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES (", c.driver.Quote(tableName), strings.Join(quoted, ", "))
+		return &insertWriter{w: bufio.NewWriter(out), prefix: prefix, style: literalStyleFor(c.driver)}, nil
+	}
+	return nil, fmt.Errorf("unknown export format %q (want %s)", format, strings.Join(ExportFormats, ", "))
+}
+
+// csvWriter leaves NULL cells empty.
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func (c *csvWriter) WriteRow(values []interface{}) error {
+	for i, v := range values {
+		c.record[i] = exportText(v)
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonWriter writes one JSON object per row with keys in column order.
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func (n *ndjsonWriter) WriteRow(values []interface{}) error {
+	n.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			n.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(n.columns[i])
+		n.w.Write(key)
+		n.w.WriteByte(':')
+		if b, ok := binaryValue(v); ok {
+			v = hexText(b)
+		} else if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		val, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		n.w.Write(val)
+	}
+	_, err := n.w.WriteString("}\n")
+	return err
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return n.w.Flush()
+}
+
+// insertWriter writes one INSERT statement per row.
+type insertWriter struct {
+	w      *bufio.Writer
+	prefix string
+	style  literalStyle
+}
+
+// literalStyle is how a dialect writes literals. MySQL treats backslashes in
+// string literals as escapes, so they are doubled there. PostgreSQL reads
+// binary as a '\x..' bytea literal; the others take X'..' blob literals.
+type literalStyle struct {
+	backslashes bool
+	byteaHex    bool
+}
+
+func literalStyleFor(driver SQLDriver) literalStyle {
+	_, mysql := driver.(mysqlDriver)
+	_, postgres := driver.(postgresDriver)
+	return literalStyle{backslashes: mysql, byteaHex: postgres}
+}
+
+func (s *insertWriter) WriteRow(values []interface{}) error {
+	s.w.WriteString(s.prefix)
+	for i, v := range values {
+		if i > 0 {
+			s.w.WriteString(", ")
+		}
+		s.w.WriteString(sqlLiteral(v, s.style))
+	}
+	_, err := s.w.WriteString(");\n")
+	return err
+}
+
+func (s *insertWriter) Flush() error {
+	return s.w.Flush()
+}
+
+// formats a value as a SQL literal.
+func sqlLiteral(v interface{}, style literalStyle) string {
+	if b, ok := binaryValue(v); ok {
+		if style.byteaHex {
+			return "'" + hexText(b) + "'"
+		}
+		return "X'" + hex.EncodeToString(b) + "'"
+	}
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	s := exportText(v)
+	if style.backslashes {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// formats a value as plain text; NULL becomes "" and binary becomes hex.
+func exportText(v interface{}) string {
+	if b, ok := binaryValue(v); ok {
+		return hexText(b)
+	}
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		if v.Location() == time.UTC {
+			return v.Format("2006-01-02 15:04:05.999999999")
+		}
+		return v.Format("2006-01-02 15:04:05.999999999-07:00")
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// returns the bytes of a value that is not valid UTF-8 text, such as a BLOB or
+// bytea column. Loaded pages hold such values as strings.
+func binaryValue(v interface{}) ([]byte, bool) {
+	switch v := v.(type) {
+	case []byte:
+		return v, !utf8.Valid(v)
+	case string:
+		return []byte(v), !utf8.ValidString(v)
+	}
+	return nil, false
+}
+
+// formats binary like PostgreSQL's bytea output, e.g. \xdeadbeef.
+func hexText(b []byte) string {
+	return `\x` + hex.EncodeToString(b)
+}
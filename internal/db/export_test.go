@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.sqlite3")
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = raw.Exec(`
+		CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT, score REAL);
+		INSERT INTO notes (body, score) VALUES ('it''s, "quoted"', 1.5), (NULL, NULL), ('plain', 2);
+	`)
+	raw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: path}, "sqlite")
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			want:   "id,body,score\n1,\"it's, \"\"quoted\"\"\",1.5\n2,,\n3,plain,2\n",
+		},
+		{
+			format: FormatNDJSON,
+			want: `{"id":1,"body":"it's, \"quoted\"","score":1.5}` + "\n" +
+				`{"id":2,"body":null,"score":null}` + "\n" +
+				`{"id":3,"body":"plain","score":2}` + "\n",
+		},
+		{
+			format: FormatSQL,
+			want: `INSERT INTO "notes" ("id", "body", "score") VALUES (1, 'it''s, "quoted"', 1.5);` + "\n" +
+				`INSERT INTO "notes" ("id", "body", "score") VALUES (2, NULL, NULL);` + "\n" +
+				`INSERT INTO "notes" ("id", "body", "score") VALUES (3, 'plain', 2);` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b strings.Builder
+			n, err := client.ExportTable(ctx, "notes", TableQuery{Keys: []string{"id"}}, tt.format, &b)
+			if err != nil {
+				t.Fatalf("ExportTable() error: %v", err)
+			}
+			if n != 3 {
+				t.Errorf("ExportTable() wrote %d rows, want 3", n)
+			}
+			if b.String() != tt.want {
+				t.Errorf("ExportTable() =\n%s\nwant\n%s", b.String(), tt.want)
+			}
+		})
+	}
+
+	var b strings.Builder
+	n, err := client.ExportQuery(ctx, "SELECT body FROM notes WHERE score > 1.6", FormatSQL, &b)
+	if err != nil || n != 1 {
+		t.Fatalf("ExportQuery() = %d, %v, want 1 row", n, err)
+	}
+	if want := `INSERT INTO "query_result" ("body") VALUES ('plain');` + "\n"; b.String() != want {
+		t.Errorf("ExportQuery() = %q, want %q", b.String(), want)
+	}
+
+	if _, err := client.ExportQuery(ctx, "DELETE FROM notes", FormatCSV, &b); err == nil {
+		t.Error("ExportQuery() ran a statement that returns no rows")
+	}
+
+	b.Reset()
+	page := RowData{{"id": int64(9), "body": "x"}}
+	if _, err := client.ExportRows([]ColumnInfo{{Name: "id"}, {Name: "body"}}, page, "notes", FormatCSV, &b); err != nil {
+		t.Fatalf("ExportRows() error: %v", err)
+	}
+	if want := "id,body\n9,x\n"; b.String() != want {
+		t.Errorf("ExportRows() = %q, want %q", b.String(), want)
+	}
+
+	if _, err := client.ExportRows(nil, nil, "notes", "xml", &b); err == nil {
+		t.Error("ExportRows() accepted an unknown format")
+	}
+}
+
+func TestSQLLiteral(t *testing.T) {
+	tests := []struct {
+		name  string
+		val   interface{}
+		style literalStyle
+		want  string
+	}{
+		{name: "null", val: nil, want: "NULL"},
+		{name: "bool", val: true, want: "TRUE"},
+		{name: "int", val: int64(-3), want: "-3"},
+		{name: "float", val: 0.25, want: "0.25"},
+		{name: "quote", val: "o'neil", want: "'o''neil'"},
+		{name: "backslash kept", val: `a\b`, want: `'a\b'`},
+		{name: "backslash doubled for mysql", val: `a\b`, style: literalStyle{backslashes: true}, want: `'a\\b'`},
+		{name: "bytes", val: []byte("raw"), want: "'raw'"},
+		{name: "binary blob", val: []byte{0xff, 0x00, 0xde}, want: "X'ff00de'"},
+		{name: "binary bytea", val: []byte{0xff, 0x00, 0xde}, style: literalStyle{byteaHex: true}, want: `'\xff00de'`},
+		{name: "binary loaded as string", val: "\xff\xfe", want: "X'fffe'"},
+		{name: "utc time", val: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), want: "'2024-01-02 03:04:05'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sqlLiteral(tt.val, tt.style); got != tt.want {
+				t.Errorf("sqlLiteral() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportBinary(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: filepath.Join(t.TempDir(), "bin.db")}, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	columns := []ColumnInfo{{Name: "data"}}
+	page := RowData{{"data": "\x00\xff"}, {"data": []byte("text")}}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: FormatCSV, want: "data\n\\x00ff\ntext\n"},
+		{format: FormatNDJSON, want: `{"data":"\\x00ff"}` + "\n" + `{"data":"text"}` + "\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if _, err := client.ExportRows(columns, page, "blobs", tt.format, &b); err != nil {
+			t.Fatalf("ExportRows(%s) error: %v", tt.format, err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("ExportRows(%s) = %q, want %q", tt.format, b.String(), tt.want)
+		}
+	}
+}
+
+func TestExportFileKeepsFileOnFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.csv")
+	if err := os.WriteFile(path, []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ExportFile(path, func(w io.Writer) (int, error) {
+		io.WriteString(w, "partial")
+		return 0, errors.New("connection lost")
+	})
+	if err == nil {
+		t.Fatal("expected the export to fail")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "original" {
+		t.Errorf("file after failed export = %q, want original", data)
+	}
+
+	rows, err := ExportFile(path, func(w io.Writer) (int, error) {
+		io.WriteString(w, "id\n1\n")
+		return 1, nil
+	})
+	if err != nil || rows != 1 {
+		t.Fatalf("ExportFile() = %d, %v, want 1 row", rows, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "id\n1\n" {
+		t.Errorf("file after export = %q", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the export", len(entries))
+	}
+}
@@ -0,0 +1,309 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Filter operators accepted in a Filter.
+const (
+	OpEqual     = "="
+	OpNotEqual  = "!="
+	OpLess      = "<"
+	OpLessEq    = "<="
+	OpGreater   = ">"
+	OpGreaterEq = ">="
+	OpLike      = "LIKE"
+	OpNotLike   = "NOT LIKE"
+	OpIsNull    = "IS NULL"
+	OpNotNull   = "IS NOT NULL"
+	OpBetween   = "BETWEEN"
+)
+
+// Filter is a condition on one column. Value is unused for the NULL checks,
+// and To is the upper bound for OpBetween.
+type Filter struct {
+	Column string
+	Op     string
+	Value  string
+	To     string
+}
+
+// String formats the filter the way ParseCondition reads it.
+func (f Filter) String() string {
+	switch f.Op {
+	case OpIsNull:
+		return f.Column + " is null"
+	case OpNotNull:
+		return f.Column + " is not null"
+	case OpBetween:
+		return fmt.Sprintf("%s %s..%s", f.Column, f.Value, f.To)
+	case OpLike, OpNotLike:
+		return fmt.Sprintf("%s %s %s", f.Column, strings.ToLower(f.Op), f.Value)
+	}
+	return fmt.Sprintf("%s %s %s", f.Column, f.Op, f.Value)
+}
+
+// TableQuery selects a page of rows from a table.
+type TableQuery struct {
+	Filters []Filter
+	OrderBy string
+	Desc    bool
+	// Limit of 0 selects every row.
+	Limit  int
+	Offset int
+	// Keys are the table's primary key columns. They order rows that would
+	// otherwise tie and allow keyset pagination.
+	Keys []string
+	// After holds the key values of the last row on the previous page. When set
+	// and the rows are ordered by the keys, the page starts after it instead of
+	// at Offset, which stays fast on large tables.
+	After []interface{}
+}
+
+// Keyset reports whether the query can page by primary key instead of OFFSET.
+func (q TableQuery) Keyset() bool {
+	if len(q.Keys) == 0 {
+		return false
+	}
+	return q.OrderBy == "" || (len(q.Keys) == 1 && q.OrderBy == q.Keys[0])
+}
+
+// ParseCondition reads a filter on column from a short expression:
+//
+//	= 5, != 5, > 5, >= 5, < 5, <= 5   comparisons
+//	like %ada%, not like %ada%        patterns (a bare value containing % also works)
+//	null, not null                    NULL checks
+//	10..20                            inclusive range
+//	ada                               equality
+func ParseCondition(column, expr string) (Filter, error) {
+	expr = strings.TrimSpace(expr)
+	f := Filter{Column: column}
+	lower := strings.ToLower(expr)
+
+	switch {
+	case expr == "":
+		return f, fmt.Errorf("empty filter")
+	case lower == "null" || lower == "is null":
+		f.Op = OpIsNull
+		return f, nil
+	case lower == "not null" || lower == "is not null":
+		f.Op = OpNotNull
+		return f, nil
+	case strings.HasPrefix(lower, "not like "):
+		f.Op, f.Value = OpNotLike, unquote(expr[len("not like "):])
+		return f, nil
+	case strings.HasPrefix(lower, "like "):
+		f.Op, f.Value = OpLike, unquote(expr[len("like "):])
+		return f, nil
+	}
+
+	for _, op := range []string{">=", "<=", "!=", "<>", "=", ">", "<"} {
+		if rest, ok := strings.CutPrefix(expr, op); ok {
+			if op == "<>" {
+				op = OpNotEqual
+			}
+			f.Op, f.Value = op, unquote(rest)
+			return f, nil
+		}
+	}
+
+	if from, to, ok := strings.Cut(expr, ".."); ok {
+		f.Op, f.Value, f.To = OpBetween, unquote(from), unquote(to)
+		if f.Value == "" || f.To == "" {
+			return f, fmt.Errorf("range needs both bounds: %q", expr)
+		}
+		return f, nil
+	}
+
+	f.Op, f.Value = OpEqual, unquote(expr)
+	if strings.Contains(f.Value, "%") {
+		f.Op = OpLike
+	}
+	return f, nil
+}
+
+// trims spaces and one pair of surrounding single quotes.
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// QueryTable returns one page of a table's rows with filters and sorting applied
+// on the server.
+func (c *Client) QueryTable(ctx context.Context, tableName string, q TableQuery) (RowData, error) {
+	query, args, err := buildSelect(c.driver, tableName, q)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query table data: %w", err)
+	}
+	defer rows.Close()
+
+	_, data, err := scanRows(rows, 0)
+	return data, err
+}
+
+// CountRows returns how many rows of a table match the filters.
+func (c *Client) CountRows(ctx context.Context, tableName string, filters []Filter) (int, error) {
+	where, args, err := buildWhere(c.driver, filters)
+	if err != nil {
+		return 0, err
+	}
+	query := "SELECT COUNT(*) FROM " + c.driver.Quote(tableName) + where
+
+	var count int
+	if err := c.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count rows: %w", err)
+	}
+	return count, nil
+}
+
+// PrimaryKey returns a table's primary key columns in key order, or none.
+func (c *Client) PrimaryKey(ctx context.Context, tableName string) ([]string, error) {
+	return c.driver.PrimaryKey(ctx, c.db, tableName)
+}
+
+// builds a SELECT for one page. Identifiers are quoted and values bound.
+func buildSelect(driver SQLDriver, tableName string, q TableQuery) (string, []interface{}, error) {
+	var after []interface{}
+	if q.Keyset() && len(q.After) > 0 {
+		if len(q.After) != len(q.Keys) {
+			return "", nil, fmt.Errorf("keyset has %d values for %d key columns", len(q.After), len(q.Keys))
+		}
+		after = q.After
+	}
+
+	where, args, err := buildWhere(driver, q.Filters)
+	if err != nil {
+		return "", nil, err
+	}
+	if after != nil {
+		cmp := ">"
+		if q.Desc {
+			cmp = "<"
+		}
+		cond := fmt.Sprintf("%s %s %s", tuple(quoteAll(driver, q.Keys)), cmp,
+			tuple(placeholders(driver, len(args)+1, len(after))))
+		if where == "" {
+			where = " WHERE " + cond
+		} else {
+			where += " AND " + cond
+		}
+		args = append(args, after...)
+	}
+
+	direction := ""
+	if q.Desc {
+		direction = " DESC"
+	}
+	var order []string
+	if q.OrderBy != "" {
+		order = append(order, driver.Quote(q.OrderBy)+direction)
+	}
+	for _, key := range q.Keys {
+		if key != q.OrderBy {
+			order = append(order, driver.Quote(key)+direction)
+		}
+	}
+
+	query := "SELECT * FROM " + driver.Quote(tableName) + where
+	if len(order) > 0 {
+		query += " ORDER BY " + strings.Join(order, ", ")
+	}
+	if q.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", q.Limit)
+	}
+	if after == nil && q.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", q.Offset)
+	}
+	return query, args, nil
+}
+
+// builds a WHERE clause from filters with one bound parameter per value.
+func buildWhere(driver SQLDriver, filters []Filter) (string, []interface{}, error) {
+	var conds []string
+	var args []interface{}
+	// returns the placeholder for the most recently appended argument
+	next := func() string {
+		return driver.Placeholder(len(args))
+	}
+	for _, f := range filters {
+		col := driver.Quote(f.Column)
+		switch f.Op {
+		case OpEqual, OpNotEqual, OpLess, OpLessEq, OpGreater, OpGreaterEq:
+			args = append(args, f.Value)
+			conds = append(conds, fmt.Sprintf("%s %s %s", col, f.Op, next()))
+		case OpLike, OpNotLike:
+			args = append(args, f.Value)
+			conds = append(conds, fmt.Sprintf("%s %s %s", driver.AsText(col), f.Op, next()))
+		case OpIsNull, OpNotNull:
+			conds = append(conds, col+" "+f.Op)
+		case OpBetween:
+			args = append(args, f.Value)
+			from := next()
+			args = append(args, f.To)
+			conds = append(conds, fmt.Sprintf("%s BETWEEN %s AND %s", col, from, next()))
+		default:
+			return "", nil, fmt.Errorf("unsupported filter operator: %q", f.Op)
+		}
+	}
+	if len(conds) == 0 {
+		return "", args, nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args, nil
+}
+
+func quoteAll(driver SQLDriver, names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = driver.Quote(name)
+	}
+	return quoted
+}
+
+func placeholders(driver SQLDriver, first, n int) []string {
+	ps := make([]string, n)
+	for i := range ps {
+		ps[i] = driver.Placeholder(first + i)
+	}
+	return ps
+}
+
+// joins expressions as a row value, e.g. ("a", "b"), or returns a single one bare.
+func tuple(exprs []string) string {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	return "(" + strings.Join(exprs, ", ") + ")"
+}
+
+// runs a query returning primary key column names in key order.
+func queryKeys(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query primary key: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan primary key: %w", err)
+		}
+		keys = append(keys, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate primary key: %w", err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,210 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    Filter
+		wantErr bool
+	}{
+		{expr: "ada", want: Filter{Column: "name", Op: OpEqual, Value: "ada"}},
+		{expr: "'ada lovelace'", want: Filter{Column: "name", Op: OpEqual, Value: "ada lovelace"}},
+		{expr: "= 5", want: Filter{Column: "name", Op: OpEqual, Value: "5"}},
+		{expr: ">= 5", want: Filter{Column: "name", Op: OpGreaterEq, Value: "5"}},
+		{expr: "<5", want: Filter{Column: "name", Op: OpLess, Value: "5"}},
+		{expr: "<> 5", want: Filter{Column: "name", Op: OpNotEqual, Value: "5"}},
+		{expr: "LIKE %ada%", want: Filter{Column: "name", Op: OpLike, Value: "%ada%"}},
+		{expr: "not like a%", want: Filter{Column: "name", Op: OpNotLike, Value: "a%"}},
+		{expr: "%ada", want: Filter{Column: "name", Op: OpLike, Value: "%ada"}},
+		{expr: "NULL", want: Filter{Column: "name", Op: OpIsNull}},
+		{expr: "is not null", want: Filter{Column: "name", Op: OpNotNull}},
+		{expr: "10..20", want: Filter{Column: "name", Op: OpBetween, Value: "10", To: "20"}},
+		{expr: "2024-01-01..", wantErr: true},
+		{expr: "  ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseCondition("name", tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseCondition() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		dbType   string
+		q        TableQuery
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:    "plain page",
+			dbType:  "postgres",
+			q:       TableQuery{Limit: 100, Offset: 200},
+			wantSQL: `SELECT * FROM "users" LIMIT 100 OFFSET 200`,
+		},
+		{
+			name:   "filters and sort",
+			dbType: "postgres",
+			q: TableQuery{
+				Filters: []Filter{
+					{Column: "name", Op: OpLike, Value: "a%"},
+					{Column: "age", Op: OpBetween, Value: "18", To: "65"},
+					{Column: "deleted_at", Op: OpIsNull},
+				},
+				OrderBy: "age",
+				Desc:    true,
+				Keys:    []string{"id"},
+				Limit:   10,
+				Offset:  20,
+				After:   []interface{}{int64(7)},
+			},
+			wantSQL:  `SELECT * FROM "users" WHERE "name"::text LIKE $1 AND "age" BETWEEN $2 AND $3 AND "deleted_at" IS NULL ORDER BY "age" DESC, "id" DESC LIMIT 10 OFFSET 20`,
+			wantArgs: []interface{}{"a%", "18", "65"},
+		},
+		{
+			name:   "keyset after filter",
+			dbType: "postgres",
+			q: TableQuery{
+				Filters: []Filter{{Column: "name", Op: OpEqual, Value: "ada"}},
+				Keys:    []string{"id"},
+				Limit:   10,
+				Offset:  20,
+				After:   []interface{}{int64(7)},
+			},
+			wantSQL:  `SELECT * FROM "users" WHERE "name" = $1 AND "id" > $2 ORDER BY "id" LIMIT 10`,
+			wantArgs: []interface{}{"ada", int64(7)},
+		},
+		{
+			name:   "composite keyset descending",
+			dbType: "mysql",
+			q: TableQuery{
+				Keys:  []string{"org", "id"},
+				Desc:  true,
+				Limit: 10,
+				After: []interface{}{"acme", int64(7)},
+			},
+			wantSQL:  "SELECT * FROM `users` WHERE (`org`, `id`) < (?, ?) ORDER BY `org` DESC, `id` DESC LIMIT 10",
+			wantArgs: []interface{}{"acme", int64(7)},
+		},
+		{
+			name:    "quotes identifiers",
+			dbType:  "mysql",
+			q:       TableQuery{Filters: []Filter{{Column: "we`ird", Op: OpNotNull}}, Limit: 5},
+			wantSQL: "SELECT * FROM `users` WHERE `we``ird` IS NOT NULL LIMIT 5",
+		},
+		{
+			name:    "keyset arity mismatch",
+			dbType:  "postgres",
+			q:       TableQuery{Keys: []string{"org", "id"}, Limit: 10, After: []interface{}{1}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown operator",
+			dbType:  "postgres",
+			q:       TableQuery{Filters: []Filter{{Column: "id", Op: "; DROP"}}, Limit: 10},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := lookupSQL(tt.dbType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotSQL, gotArgs, err := buildSelect(driver, "users", tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildSelect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotSQL != tt.wantSQL {
+				t.Errorf("buildSelect() sql =\n%s\nwant\n%s", gotSQL, tt.wantSQL)
+			}
+			if len(gotArgs) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+					t.Errorf("buildSelect() args = %v, want %v", gotArgs, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryTableKeyset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.sqlite3")
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = raw.Exec(`
+		CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT, price INTEGER);
+		INSERT INTO items (name, price) VALUES
+			('apple', 3), ('banana', 1), ('cherry', 5), ('date', NULL), ('elderberry', 8);
+	`)
+	raw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: path}, "sqlite")
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	defer client.Close()
+
+	keys, err := client.PrimaryKey(ctx, "items")
+	if err != nil || !reflect.DeepEqual(keys, []string{"id"}) {
+		t.Fatalf("PrimaryKey() = %v, %v, want [id]", keys, err)
+	}
+
+	q := TableQuery{Keys: keys, Limit: 2}
+	var names []string
+	for page := 0; page < 3; page++ {
+		rows, err := client.QueryTable(ctx, "items", q)
+		if err != nil {
+			t.Fatalf("QueryTable() page %d error: %v", page, err)
+		}
+		for _, row := range rows {
+			names = append(names, row["name"].(string))
+		}
+		if len(rows) > 0 {
+			q.After = []interface{}{rows[len(rows)-1]["id"]}
+		}
+	}
+	want := []string{"apple", "banana", "cherry", "date", "elderberry"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("keyset pages = %v, want %v", names, want)
+	}
+
+	filters := []Filter{{Column: "price", Op: OpGreaterEq, Value: "3"}, {Column: "name", Op: OpNotLike, Value: "e%"}}
+	rows, err := client.QueryTable(ctx, "items", TableQuery{Filters: filters, OrderBy: "price", Desc: true, Keys: keys, Limit: 10})
+	if err != nil {
+		t.Fatalf("QueryTable() filtered error: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["name"] != "cherry" || rows[1]["name"] != "apple" {
+		t.Errorf("filtered rows = %v, want cherry then apple", rows)
+	}
+
+	count, err := client.CountRows(ctx, "items", []Filter{{Column: "price", Op: OpIsNull}})
+	if err != nil || count != 1 {
+		t.Errorf("CountRows() = %d, %v, want 1", count, err)
+	}
+}
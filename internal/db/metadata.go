@@ -2,92 +2,77 @@ package db
 
 import (
 	"context"
-	"fmt"
+	"sync"
 )
 
-// TableInfo holds metadata about a database table.
+// Table kinds reported in TableInfo.Kind.
+const (
+	KindTable            = "table"
+	KindView             = "view"
+	KindMaterializedView = "materialized view"
+)
+
+// TableInfo holds metadata about a database table. RowCount is -1 when unknown.
 type TableInfo struct {
-	Name        string
-	RowCount    int
+	Name     string
+	Kind     string
+	RowCount int
+	// Approximate is set when RowCount is a catalog estimate rather than a count.
+	Approximate bool
 	ColumnCount int
 }
 
-// ListTables returns all tables in the database with metadata.
-func (c *Client) ListTables(ctx context.Context) ([]TableInfo, error) {
-	var query string
-	switch c.dbType {
-	case "postgres":
-		query = `
-			SELECT
-				t.table_name,
-				COALESCE((
-					SELECT COUNT(*)
-					FROM information_schema.columns
-					WHERE table_schema = t.table_schema
-					AND table_name = t.table_name
-				), 0) as column_count
-			FROM information_schema.tables t
-			WHERE t.table_schema = 'public'
-			ORDER BY t.table_name
-		`
-	case "mysql":
-		query = `
-			SELECT
-				t.TABLE_NAME,
-				COALESCE((
-					SELECT COUNT(*)
-					FROM information_schema.COLUMNS c
-					WHERE c.TABLE_SCHEMA = t.TABLE_SCHEMA
-					AND c.TABLE_NAME = t.TABLE_NAME
-				), 0) as column_count
-			FROM information_schema.TABLES t
-			WHERE t.TABLE_SCHEMA = DATABASE()
-			ORDER BY t.TABLE_NAME
-		`
-	default:
-		return nil, fmt.Errorf("unsupported database type: %s", c.dbType)
-	}
-
-	rows, err := c.db.QueryContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("query tables: %w", err)
-	}
-	defer rows.Close()
+// TableCount is the exact row count of one table, or the error counting it.
+type TableCount struct {
+	Table string
+	Count int
+	Err   error
+}
 
-	var tables []TableInfo
-	for rows.Next() {
-		var table TableInfo
-		if err := rows.Scan(&table.Name, &table.ColumnCount); err != nil {
-			return nil, fmt.Errorf("scan table: %w", err)
-		}
+// ListTables returns all tables in the database with metadata. Row counts are
+// catalog estimates, which cost nothing to read; use CountTables for exact ones.
+func (c *Client) ListTables(ctx context.Context) ([]TableInfo, error) {
+	return c.driver.ListTables(ctx, c.db)
+}
 
-		rowCount, err := c.getTableRowCount(ctx, table.Name)
-		if err != nil {
-			table.RowCount = 0
-		} else {
-			table.RowCount = rowCount
-		}
+// CountTables counts the rows of each table, running at most workers counts at
+// once. Results arrive on the returned channel, which is closed when every table
+// is counted or ctx is cancelled.
+func (c *Client) CountTables(ctx context.Context, tables []string, workers int) <-chan TableCount {
+	jobs := make(chan string)
+	results := make(chan TableCount)
 
-		tables = append(tables, table)
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for table := range jobs {
+				count, err := c.CountRows(ctx, table, nil)
+				select {
+				case results <- TableCount{Table: table, Count: count, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate tables: %w", err)
-	}
+	go func() {
+		defer close(jobs)
+		for _, table := range tables {
+			select {
+			case jobs <- table:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
-	return tables, nil
-}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 
-func (c *Client) getTableRowCount(ctx context.Context, tableName string) (int, error) {
-	quoted, err := quoteIdentifier(tableName, c.dbType)
-	if err != nil {
-		return 0, err
-	}
-	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", quoted)
-	var count int
-	err = c.db.QueryRowContext(ctx, query).Scan(&count)
-	if err != nil {
-		return 0, err
-	}
-	return count, nil
+	return results
 }
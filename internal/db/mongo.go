@@ -0,0 +1,223 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func init() {
+	Register("mongodb", mongoDriver{})
+}
+
+type mongoDriver struct{}
+
+func (mongoDriver) DefaultPort() string { return "27017" }
+
+func (mongoDriver) Configure(config *ConnectionConfig, env map[string]string, args []string) {
+	config.User = getEnv(env, "MONGO_INITDB_ROOT_USERNAME", "")
+	config.Password = getEnv(env, "MONGO_INITDB_ROOT_PASSWORD", "")
+}
+
+func (mongoDriver) EnvKeys() []string {
+	return []string{"MONGO_INITDB_ROOT_USERNAME", "MONGO_INITDB_ROOT_PASSWORD"}
+}
+
+func (mongoDriver) Open(ctx context.Context, config *ConnectionConfig) (Browser, error) {
+	client, err := NewMongoClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// mongoSystemDatabases are hidden from the collection list.
+var mongoSystemDatabases = map[string]bool{
+	"admin":  true,
+	"config": true,
+	"local":  true,
+}
+
+// MongoClient browses MongoDB collections as tables of JSON documents.
+// Collections are named "database.collection".
+type MongoClient struct {
+	client   *mongo.Client
+	database string
+}
+
+// NewMongoClient connects to MongoDB and verifies the connection. When the config
+// names a database only its collections are listed, otherwise all user databases are.
+func NewMongoClient(ctx context.Context, config *ConnectionConfig) (*MongoClient, error) {
+	client, err := mongo.Connect(options.Client().ApplyURI(mongoURI(config)))
+	if err != nil {
+		return nil, fmt.Errorf("open connection: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	return &MongoClient{client: client, database: config.Database}, nil
+}
+
+// Close disconnects from MongoDB.
+func (c *MongoClient) Close() error {
+	return c.client.Disconnect(context.Background())
+}
+
+// ListTables returns every collection with its estimated document count.
+func (c *MongoClient) ListTables(ctx context.Context) ([]TableInfo, error) {
+	databases := []string{c.database}
+	if c.database == "" {
+		names, err := c.client.ListDatabaseNames(ctx, bson.D{})
+		if err != nil {
+			return nil, fmt.Errorf("list databases: %w", err)
+		}
+		databases = databases[:0]
+		for _, name := range names {
+			if !mongoSystemDatabases[name] {
+				databases = append(databases, name)
+			}
+		}
+	}
+	sort.Strings(databases)
+
+	var tables []TableInfo
+	for _, dbName := range databases {
+		database := c.client.Database(dbName)
+		names, err := database.ListCollectionNames(ctx, bson.D{})
+		if err != nil {
+			return nil, fmt.Errorf("list collections in %s: %w", dbName, err)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			table := TableInfo{Name: dbName + "." + name}
+			if count, err := database.Collection(name).EstimatedDocumentCount(ctx); err == nil {
+				table.RowCount = int(count)
+			}
+			tables = append(tables, table)
+		}
+	}
+	return tables, nil
+}
+
+// GetTableColumns returns the fixed columns of a document row.
+func (c *MongoClient) GetTableColumns(ctx context.Context, tableName string) ([]ColumnInfo, error) {
+	return []ColumnInfo{
+		{Name: "_id", Type: "objectId"},
+		{Name: "document", Type: "json"},
+	}, nil
+}
+
+// GetTableData returns a page of documents in _id order.
+func (c *MongoClient) GetTableData(ctx context.Context, tableName string, limit, offset int) (RowData, error) {
+	return c.FindDocuments(ctx, tableName, "", limit, offset)
+}
+
+// FindDocuments returns a page of documents matching filter, an extended JSON
+// query such as {"status": "active"}. An empty filter matches everything.
+func (c *MongoClient) FindDocuments(ctx context.Context, collection, filter string, limit, offset int) (RowData, error) {
+	dbName, collName, err := splitNamespace(collection)
+	if err != nil {
+		return nil, err
+	}
+	query, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := c.client.Database(dbName).Collection(collName).Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("find documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var data RowData
+	for cursor.Next(ctx) {
+		var doc bson.D
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("decode document: %w", err)
+		}
+		row, err := documentRow(doc)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("iterate documents: %w", err)
+	}
+	return data, nil
+}
+
+// converts a document into a row holding its id and pretty-printed relaxed extended JSON.
+func documentRow(doc bson.D) (map[string]interface{}, error) {
+	pretty, err := bson.MarshalExtJSONIndent(doc, false, false, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("format document: %w", err)
+	}
+
+	row := map[string]interface{}{"document": string(pretty)}
+	for _, elem := range doc {
+		if elem.Key != "_id" {
+			continue
+		}
+		if oid, ok := elem.Value.(bson.ObjectID); ok {
+			row["_id"] = oid.Hex()
+		} else {
+			row["_id"] = fmt.Sprint(elem.Value)
+		}
+	}
+	return row, nil
+}
+
+// parses a filter written in relaxed extended JSON.
+func parseFilter(filter string) (bson.D, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return bson.D{}, nil
+	}
+	var query bson.D
+	if err := bson.UnmarshalExtJSON([]byte(filter), false, &query); err != nil {
+		return nil, fmt.Errorf("parse filter: %w", err)
+	}
+	return query, nil
+}
+
+// splits "database.collection". Database names cannot contain dots, collection names can.
+func splitNamespace(ns string) (string, string, error) {
+	dbName, collName, ok := strings.Cut(ns, ".")
+	if !ok || dbName == "" || collName == "" {
+		return "", "", fmt.Errorf("invalid collection name: %q", ns)
+	}
+	return dbName, collName, nil
+}
+
+// builds a mongodb:// URI. Root users created from MONGO_INITDB_ROOT_* live in
+// the admin database, and a direct connection avoids replica set hostnames that
+// only resolve inside the Docker network.
+func mongoURI(config *ConnectionConfig) string {
+	u := url.URL{
+		Scheme: "mongodb",
+		Host:   net.JoinHostPort(config.Host, config.Port),
+		Path:   "/",
+	}
+	query := url.Values{"directConnection": {"true"}}
+	if config.User != "" {
+		u.User = url.UserPassword(config.User, config.Password)
+		query.Set("authSource", "admin")
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,414 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func init() {
+	Register("mysql", mysqlDriver{envPrefixes: []string{"MYSQL"}})
+	// The MariaDB image reads MARIADB_* and still honours the older MYSQL_* names.
+	Register("mariadb", mysqlDriver{envPrefixes: []string{"MARIADB", "MYSQL"}})
+}
+
+// mysqlDriver serves MySQL and MariaDB, which share a wire protocol and
+// information_schema layout but name their image env vars differently.
+type mysqlDriver struct {
+	envPrefixes []string
+}
+
+func (mysqlDriver) DefaultPort() string   { return "3306" }
+func (mysqlDriver) SQLDriverName() string { return "mysql" }
+
+func (d mysqlDriver) Configure(config *ConnectionConfig, env map[string]string, args []string) {
+	config.User = d.getEnv(env, "USER", "root")
+	config.Password = d.getEnv(env, "PASSWORD", d.getEnv(env, "ROOT_PASSWORD", ""))
+	config.Database = d.getEnv(env, "DATABASE", "mysql")
+}
+
+func (d mysqlDriver) EnvKeys() []string {
+	var keys []string
+	for _, prefix := range d.envPrefixes {
+		for _, suffix := range []string{"USER", "PASSWORD", "ROOT_PASSWORD", "DATABASE"} {
+			keys = append(keys, prefix+"_"+suffix)
+		}
+	}
+	return keys
+}
+
+// returns the first non-empty <prefix>_<suffix> variable.
+func (d mysqlDriver) getEnv(env map[string]string, suffix, defaultValue string) string {
+	for _, prefix := range d.envPrefixes {
+		if val := getEnv(env, prefix+"_"+suffix, ""); val != "" {
+			return val
+		}
+	}
+	return defaultValue
+}
+
+// DSN reports rows matched rather than rows changed as affected, so an update
+// that leaves a row as it was still counts it.
+func (mysqlDriver) DSN(config *ConnectionConfig) string {
+	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?clientFoundRows=true",
+		config.User,
+		config.Password,
+		net.JoinHostPort(config.Host, config.Port),
+		config.Database,
+	)
+	if tls := mysqlTLS(config.SSLMode); tls != "" {
+		dsn += "&tls=" + tls
+	}
+	return dsn
+}
+
+// maps a libpq sslmode to the go-sql-driver/mysql tls parameter. require
+// encrypts without checking the certificate, as it does for Postgres.
+func mysqlTLS(sslMode string) string {
+	switch sslMode {
+	case "allow", "prefer":
+		return "preferred"
+	case "require":
+		return "skip-verify"
+	case "verify-ca", "verify-full":
+		return "true"
+	}
+	return ""
+}
+
+func (mysqlDriver) ListTables(ctx context.Context, db *sql.DB) ([]TableInfo, error) {
+	return queryTables(ctx, db, `
+		SELECT
+			t.TABLE_NAME,
+			CASE WHEN t.TABLE_TYPE LIKE '%VIEW' THEN 'view' ELSE 'table' END,
+			COALESCE((
+				SELECT COUNT(*)
+				FROM information_schema.COLUMNS c
+				WHERE c.TABLE_SCHEMA = t.TABLE_SCHEMA
+				AND c.TABLE_NAME = t.TABLE_NAME
+			), 0) as column_count,
+			-- InnoDB estimates TABLE_ROWS from sampled pages; views have none
+			COALESCE(t.TABLE_ROWS, -1)
+		FROM information_schema.TABLES t
+		WHERE t.TABLE_SCHEMA = DATABASE()
+		ORDER BY t.TABLE_NAME
+	`)
+}
+
+func (mysqlDriver) Columns(ctx context.Context, db *sql.DB, tableName string) ([]ColumnInfo, error) {
+	return queryColumns(ctx, db, `
+		SELECT COLUMN_NAME, DATA_TYPE
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE()
+		AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION
+	`, tableName)
+}
+
+func (mysqlDriver) Quote(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func (mysqlDriver) PrimaryKey(ctx context.Context, db *sql.DB, tableName string) ([]string, error) {
+	return queryKeys(ctx, db, `
+		SELECT COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = DATABASE()
+		AND TABLE_NAME = ?
+		AND CONSTRAINT_NAME = 'PRIMARY'
+		ORDER BY ORDINAL_POSITION
+	`, tableName)
+}
+
+func (mysqlDriver) Placeholder(n int) string  { return "?" }
+func (mysqlDriver) AsText(expr string) string { return "CAST(" + expr + " AS CHAR)" }
+
+// Schema reads the table from information_schema and takes the DDL from SHOW
+// CREATE TABLE. Functional index parts show as "(expression)", since MariaDB and
+// MySQL 5.7 have no STATISTICS.EXPRESSION column.
+func (d mysqlDriver) Schema(ctx context.Context, db *sql.DB, tableName string) (*TableSchema, error) {
+	schema := &TableSchema{Table: tableName}
+
+	var name string
+	err := db.QueryRowContext(ctx, "SHOW CREATE TABLE "+d.Quote(tableName)).Scan(&name, &schema.DDL)
+	if err != nil {
+		return nil, fmt.Errorf("show create table: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT COLUMN_NAME, COLUMN_TYPE, EXTRA, IS_NULLABLE = 'YES', COLUMN_DEFAULT
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE()
+		AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("query columns: %w", err)
+	}
+	err = scanEach(rows, func() error {
+		var col ColumnDetail
+		var extra string
+		var def sql.NullString
+		if err := rows.Scan(&col.Name, &col.Type, &extra, &col.Nullable, &def); err != nil {
+			return err
+		}
+		if extra != "" {
+			col.Type += " " + extra
+		}
+		col.Default = def.String
+		schema.Columns = append(schema.Columns, col)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan columns: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT INDEX_NAME, NON_UNIQUE = 0, COALESCE(COLUMN_NAME, '(expression)')
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE()
+		AND TABLE_NAME = ?
+		ORDER BY INDEX_NAME = 'PRIMARY' DESC, INDEX_NAME, SEQ_IN_INDEX
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("query indexes: %w", err)
+	}
+	err = scanEach(rows, func() error {
+		var idx IndexInfo
+		var column string
+		if err := rows.Scan(&idx.Name, &idx.Unique, &column); err != nil {
+			return err
+		}
+		if n := len(schema.Indexes); n > 0 && schema.Indexes[n-1].Name == idx.Name {
+			schema.Indexes[n-1].Columns = append(schema.Indexes[n-1].Columns, column)
+			return nil
+		}
+		idx.Primary = idx.Name == "PRIMARY"
+		idx.Columns = []string{column}
+		schema.Indexes = append(schema.Indexes, idx)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan indexes: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT tc.CONSTRAINT_NAME, tc.CONSTRAINT_TYPE, k.COLUMN_NAME,
+			COALESCE(k.REFERENCED_TABLE_NAME, ''), COALESCE(k.REFERENCED_COLUMN_NAME, ''),
+			COALESCE(r.UPDATE_RULE, ''), COALESCE(r.DELETE_RULE, '')
+		FROM information_schema.TABLE_CONSTRAINTS tc
+		JOIN information_schema.KEY_COLUMN_USAGE k
+			ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+			AND k.TABLE_NAME = tc.TABLE_NAME
+			AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		LEFT JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+			ON r.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+			AND r.TABLE_NAME = tc.TABLE_NAME
+			AND r.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = DATABASE()
+		AND tc.TABLE_NAME = ?
+		ORDER BY FIELD(tc.CONSTRAINT_TYPE, 'PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY'),
+			tc.CONSTRAINT_NAME, k.ORDINAL_POSITION
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("query constraints: %w", err)
+	}
+	rules := make(map[string][2]string)
+	err = scanEach(rows, func() error {
+		var c Constraint
+		var column, refColumn, onUpdate, onDelete string
+		if err := rows.Scan(&c.Name, &c.Type, &column, &c.RefTable, &refColumn, &onUpdate, &onDelete); err != nil {
+			return err
+		}
+		if n := len(schema.Constraints); n > 0 && schema.Constraints[n-1].Name == c.Name {
+			last := &schema.Constraints[n-1]
+			last.Columns = append(last.Columns, column)
+			if refColumn != "" {
+				last.RefColumns = append(last.RefColumns, refColumn)
+			}
+			return nil
+		}
+		c.Columns = []string{column}
+		if refColumn != "" {
+			c.RefColumns = []string{refColumn}
+		}
+		rules[c.Name] = [2]string{onUpdate, onDelete}
+		schema.Constraints = append(schema.Constraints, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan constraints: %w", err)
+	}
+
+	for i, c := range schema.Constraints {
+		quoted := strings.Join(mapStrings(c.Columns, d.Quote), ", ")
+		switch c.Type {
+		case ConstraintForeignKey:
+			rule := rules[c.Name]
+			schema.Constraints[i].Definition = foreignKeyDefinition(d.Quote, c, rule[0], rule[1])
+		default:
+			schema.Constraints[i].Definition = fmt.Sprintf("%s (%s)", c.Type, quoted)
+		}
+	}
+	return schema, nil
+}
+
+// mysqlSystemSchemas are hidden from the namespace list.
+var mysqlSystemSchemas = map[string]bool{
+	"information_schema": true,
+	"performance_schema": true,
+	"mysql":              true,
+	"sys":                true,
+}
+
+// Namespaces lists the databases on the server. MySQL schemas are databases.
+func (mysqlDriver) Namespaces(ctx context.Context, db *sql.DB, config *ConnectionConfig) ([]Namespace, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT SCHEMA_NAME, SCHEMA_NAME = DATABASE()
+		FROM information_schema.SCHEMATA
+		ORDER BY SCHEMA_NAME
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query databases: %w", err)
+	}
+	var namespaces []Namespace
+	err = scanEach(rows, func() error {
+		var ns Namespace
+		if err := rows.Scan(&ns.Database, &ns.Current); err != nil {
+			return err
+		}
+		if !mysqlSystemSchemas[ns.Database] || ns.Current {
+			namespaces = append(namespaces, ns)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan databases: %w", err)
+	}
+	return namespaces, nil
+}
+
+// mysqlLockWaitQueries each return (waiting session, blocking session, lock)
+// rows. The sys views exist on MySQL 5.7 and later; MariaDB still has
+// INNODB_LOCK_WAITS but no sys schema unless installed. The first query that
+// runs is used for row locks; metadata locks, which stall DDL, come from sys only.
+// The locked table is NULL for some waits, so the lock falls back to its mode.
+var mysqlLockWaitQueries = [][]string{
+	{`
+		SELECT waiting_pid, COALESCE(blocking_pid, 0),
+			CONCAT(COALESCE(waiting_lock_mode, ''), COALESCE(CONCAT(' on ', locked_table), ''))
+		FROM sys.innodb_lock_waits
+	`, `
+		SELECT r.trx_mysql_thread_id, b.trx_mysql_thread_id,
+			CONCAT(COALESCE(l.lock_mode, ''), COALESCE(CONCAT(' on ', l.lock_table), ''))
+		FROM information_schema.INNODB_LOCK_WAITS w
+		JOIN information_schema.INNODB_TRX r ON r.trx_id = w.requesting_trx_id
+		JOIN information_schema.INNODB_TRX b ON b.trx_id = w.blocking_trx_id
+		JOIN information_schema.INNODB_LOCKS l ON l.lock_id = w.requested_lock_id
+	`},
+	{`
+		SELECT waiting_pid, COALESCE(blocking_pid, 0),
+			CONCAT(COALESCE(waiting_lock_type, ''), ' metadata lock',
+				COALESCE(CONCAT(' on ', object_schema, '.', object_name), ''))
+		FROM sys.schema_table_lock_waits
+	`},
+}
+
+// Sessions reads the process list. Transaction ages and lock waits need the
+// PROCESS privilege and are left out without it.
+func (mysqlDriver) Sessions(ctx context.Context, db *sql.DB) ([]Session, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT ID, COALESCE(USER, ''), COALESCE(DB, ''), COALESCE(HOST, ''), COALESCE(COMMAND, ''),
+			COALESCE(TIME, 0), COALESCE(STATE, ''), COALESCE(INFO, ''), ID = CONNECTION_ID()
+		FROM information_schema.PROCESSLIST
+		WHERE COMMAND NOT IN ('Daemon', 'Binlog Dump')
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query sessions: %w", err)
+	}
+	var sessions []Session
+	index := make(map[int64]int)
+	err = scanEach(rows, func() error {
+		var s Session
+		var seconds int64
+		if err := rows.Scan(&s.ID, &s.User, &s.Database, &s.Client, &s.State, &seconds,
+			&s.Wait, &s.Query, &s.Own); err != nil {
+			return err
+		}
+		s.Duration = time.Duration(seconds) * time.Second
+		index[s.ID] = len(sessions)
+		sessions = append(sessions, s)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan sessions: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT trx_mysql_thread_id, TIMESTAMPDIFF(SECOND, trx_started, NOW())
+		FROM information_schema.INNODB_TRX
+	`)
+	if err == nil {
+		err = scanEach(rows, func() error {
+			var id, seconds int64
+			if err := rows.Scan(&id, &seconds); err != nil {
+				return err
+			}
+			if i, ok := index[id]; ok {
+				// a transaction younger than a second still counts as open
+				sessions[i].Transaction = max(time.Duration(seconds)*time.Second, time.Millisecond)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scan transactions: %w", err)
+		}
+	}
+
+	for _, queries := range mysqlLockWaitQueries {
+		for _, query := range queries {
+			rows, err := db.QueryContext(ctx, query)
+			if err != nil {
+				continue
+			}
+			err = scanEach(rows, func() error {
+				var waiting, blocking int64
+				var lock string
+				if err := rows.Scan(&waiting, &blocking, &lock); err != nil {
+					return err
+				}
+				if i, ok := index[waiting]; ok {
+					if blocking != 0 {
+						sessions[i].BlockedBy = append(sessions[i].BlockedBy, blocking)
+					}
+					sessions[i].Lock = lock
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("scan lock waits: %w", err)
+			}
+			break
+		}
+	}
+	return sessions, nil
+}
+
+func (mysqlDriver) CancelQuery(ctx context.Context, db *sql.DB, id int64) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("KILL QUERY %d", id)); err != nil {
+		return fmt.Errorf("kill query: %w", err)
+	}
+	return nil
+}
+
+func (mysqlDriver) Terminate(ctx context.Context, db *sql.DB, id int64) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("KILL CONNECTION %d", id)); err != nil {
+		return fmt.Errorf("kill connection: %w", err)
+	}
+	return nil
+}
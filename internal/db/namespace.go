@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Namespace is a database, or a schema within one, that tables can be listed from.
+type Namespace struct {
+	Database string
+	// Schema is empty for databases without schemas, and for databases on the
+	// server other than the connected one.
+	Schema  string
+	Current bool
+}
+
+// String formats the namespace as "database" or "database / schema".
+func (n Namespace) String() string {
+	if n.Schema == "" {
+		return n.Database
+	}
+	return n.Database + " / " + n.Schema
+}
+
+// Navigator is implemented by SQL drivers for servers holding several
+// databases or schemas.
+type Navigator interface {
+	Namespaces(ctx context.Context, db *sql.DB, config *ConnectionConfig) ([]Namespace, error)
+}
+
+// Namespaces lists the databases and schemas reachable on the server.
+func (c *Client) Namespaces(ctx context.Context) ([]Namespace, error) {
+	navigator, ok := c.driver.(Navigator)
+	if !ok {
+		return nil, fmt.Errorf("%s databases have a single namespace", c.dbType)
+	}
+	return navigator.Namespaces(ctx, c.db, c.config)
+}
+
+// Config returns the connection settings the client was opened with.
+func (c *Client) Config() ConnectionConfig {
+	return *c.config
+}
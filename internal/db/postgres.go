@@ -0,0 +1,378 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+func init() {
+	Register("postgres", postgresDriver{})
+}
+
+type postgresDriver struct{}
+
+func (postgresDriver) DefaultPort() string   { return "5432" }
+func (postgresDriver) SQLDriverName() string { return "postgres" }
+
+func (postgresDriver) Configure(config *ConnectionConfig, env map[string]string, args []string) {
+	config.User = getEnv(env, "POSTGRES_USER", "postgres")
+	config.Password = getEnv(env, "POSTGRES_PASSWORD", "")
+	// the image names the default database after the user
+	config.Database = getEnv(env, "POSTGRES_DB", config.User)
+}
+
+func (postgresDriver) EnvKeys() []string {
+	return []string{"POSTGRES_USER", "POSTGRES_PASSWORD", "POSTGRES_DB"}
+}
+
+// DSN selects a schema through search_path, so unqualified names and
+// current_schema() in the catalog queries below refer to it.
+func (d postgresDriver) DSN(config *ConnectionConfig) string {
+	sslMode := config.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.User, config.Password),
+		Host:     net.JoinHostPort(config.Host, config.Port),
+		Path:     "/" + config.Database,
+		RawQuery: "sslmode=" + url.QueryEscape(sslMode),
+	}
+	if config.Schema != "" {
+		dsn.RawQuery += "&search_path=" + url.QueryEscape(d.Quote(config.Schema))
+	}
+	return dsn.String()
+}
+
+func (postgresDriver) ListTables(ctx context.Context, db *sql.DB) ([]TableInfo, error) {
+	return queryTables(ctx, db, `
+		SELECT
+			c.relname,
+			CASE c.relkind
+				WHEN 'v' THEN 'view'
+				WHEN 'm' THEN 'materialized view'
+				ELSE 'table'
+			END,
+			(
+				SELECT COUNT(*)
+				FROM pg_attribute a
+				WHERE a.attrelid = c.oid
+				AND a.attnum > 0
+				AND NOT a.attisdropped
+			) as column_count,
+			-- reltuples is -1 until the table is first vacuumed or analyzed
+			CASE WHEN c.relkind IN ('r', 'p', 'm') THEN c.reltuples::bigint ELSE -1 END
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema()
+		AND c.relkind IN ('r', 'p', 'f', 'v', 'm')
+		ORDER BY c.relname
+	`)
+}
+
+// Columns reads pg_attribute, since information_schema leaves out materialized views.
+func (d postgresDriver) Columns(ctx context.Context, db *sql.DB, tableName string) ([]ColumnInfo, error) {
+	return queryColumns(ctx, db, `
+		SELECT attname, format_type(atttypid, atttypmod)
+		FROM pg_attribute
+		WHERE attrelid = $1::regclass
+		AND attnum > 0
+		AND NOT attisdropped
+		ORDER BY attnum
+	`, d.Quote(tableName))
+}
+
+func (postgresDriver) Quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (postgresDriver) PrimaryKey(ctx context.Context, db *sql.DB, tableName string) ([]string, error) {
+	return queryKeys(ctx, db, `
+		SELECT k.column_name
+		FROM information_schema.table_constraints c
+		JOIN information_schema.key_column_usage k
+			ON k.constraint_schema = c.constraint_schema
+			AND k.constraint_name = c.constraint_name
+		WHERE c.constraint_type = 'PRIMARY KEY'
+		AND c.table_schema = current_schema()
+		AND c.table_name = $1
+		ORDER BY k.ordinal_position
+	`, tableName)
+}
+
+func (postgresDriver) Placeholder(n int) string  { return fmt.Sprintf("$%d", n) }
+func (postgresDriver) AsText(expr string) string { return expr + "::text" }
+
+// postgresConstraintTypes maps pg_constraint.contype to constraint types.
+var postgresConstraintTypes = map[string]string{
+	"p": ConstraintPrimaryKey,
+	"u": ConstraintUnique,
+	"f": ConstraintForeignKey,
+	"c": ConstraintCheck,
+}
+
+// Schema reads the table from pg_catalog. Postgres has no SHOW CREATE TABLE, so
+// the DDL is assembled from the column, constraint and index definitions.
+func (d postgresDriver) Schema(ctx context.Context, db *sql.DB, tableName string) (*TableSchema, error) {
+	table := d.Quote(tableName)
+	schema := &TableSchema{Table: tableName}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull,
+			COALESCE(pg_get_expr(d.adbin, d.adrelid), '')
+		FROM pg_attribute a
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = $1::regclass
+		AND a.attnum > 0
+		AND NOT a.attisdropped
+		ORDER BY a.attnum
+	`, table)
+	if err != nil {
+		return nil, fmt.Errorf("query columns: %w", err)
+	}
+	err = scanEach(rows, func() error {
+		var col ColumnDetail
+		if err := rows.Scan(&col.Name, &col.Type, &col.Nullable, &col.Default); err != nil {
+			return err
+		}
+		schema.Columns = append(schema.Columns, col)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan columns: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT c.conname, c.contype::text, pg_get_constraintdef(c.oid),
+			array_to_string(ARRAY(
+				SELECT a.attname
+				FROM unnest(c.conkey) WITH ORDINALITY AS k(num, ord)
+				JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.num
+				ORDER BY k.ord
+			), chr(31)),
+			COALESCE(r.relname, ''),
+			array_to_string(ARRAY(
+				SELECT a.attname
+				FROM unnest(c.confkey) WITH ORDINALITY AS k(num, ord)
+				JOIN pg_attribute a ON a.attrelid = c.confrelid AND a.attnum = k.num
+				ORDER BY k.ord
+			), chr(31))
+		FROM pg_constraint c
+		LEFT JOIN pg_class r ON r.oid = c.confrelid
+		WHERE c.conrelid = $1::regclass
+		AND c.contype IN ('p', 'u', 'f', 'c')
+		ORDER BY array_position(ARRAY['p', 'u', 'f', 'c'], c.contype::text), c.conname
+	`, table)
+	if err != nil {
+		return nil, fmt.Errorf("query constraints: %w", err)
+	}
+	err = scanEach(rows, func() error {
+		var c Constraint
+		var contype, columns, refColumns string
+		if err := rows.Scan(&c.Name, &contype, &c.Definition, &columns, &c.RefTable, &refColumns); err != nil {
+			return err
+		}
+		c.Type = postgresConstraintTypes[contype]
+		c.Columns = splitList(columns)
+		c.RefColumns = splitList(refColumns)
+		schema.Constraints = append(schema.Constraints, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan constraints: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT i.relname, ix.indisunique, ix.indisprimary, pg_get_indexdef(ix.indexrelid),
+			array_to_string(ARRAY(
+				SELECT pg_get_indexdef(ix.indexrelid, k, true)
+				FROM generate_series(1, ix.indnkeyatts) AS k
+				ORDER BY k
+			), chr(31))
+		FROM pg_index ix
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		WHERE ix.indrelid = $1::regclass
+		ORDER BY i.relname
+	`, table)
+	if err != nil {
+		return nil, fmt.Errorf("query indexes: %w", err)
+	}
+	indexDefs := make(map[string]string)
+	err = scanEach(rows, func() error {
+		var idx IndexInfo
+		var def, columns string
+		if err := rows.Scan(&idx.Name, &idx.Unique, &idx.Primary, &def, &columns); err != nil {
+			return err
+		}
+		idx.Columns = splitList(columns)
+		indexDefs[idx.Name] = def
+		schema.Indexes = append(schema.Indexes, idx)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan indexes: %w", err)
+	}
+
+	schema.DDL = postgresDDL(d.Quote, table, schema, indexDefs)
+	return schema, nil
+}
+
+// assembles CREATE TABLE and CREATE INDEX statements. Indexes that back a
+// constraint are created by it and left out.
+func postgresDDL(quote func(string) string, table string, schema *TableSchema, indexDefs map[string]string) string {
+	var defs []string
+	for _, col := range schema.Columns {
+		def := "    " + quote(col.Name) + " " + col.Type
+		if !col.Nullable {
+			def += " NOT NULL"
+		}
+		if col.Default != "" {
+			def += " DEFAULT " + col.Default
+		}
+		defs = append(defs, def)
+	}
+	constraintNames := make(map[string]bool)
+	for _, c := range schema.Constraints {
+		constraintNames[c.Name] = true
+		defs = append(defs, "    CONSTRAINT "+quote(c.Name)+" "+c.Definition)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n%s\n);", table, strings.Join(defs, ",\n"))
+	for _, idx := range schema.Indexes {
+		if !constraintNames[idx.Name] {
+			fmt.Fprintf(&b, "\n\n%s;", indexDefs[idx.Name])
+		}
+	}
+	return b.String()
+}
+
+// Namespaces lists the schemas of the connected database and the other
+// databases on the server, whose schemas are listed once connected to them.
+func (postgresDriver) Namespaces(ctx context.Context, db *sql.DB, config *ConnectionConfig) ([]Namespace, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT nspname, nspname = current_schema()
+		FROM pg_namespace
+		WHERE nspname NOT IN ('information_schema', 'pg_catalog', 'pg_toast')
+		AND nspname NOT LIKE 'pg_temp_%'
+		AND nspname NOT LIKE 'pg_toast_temp_%'
+		ORDER BY nspname
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query schemas: %w", err)
+	}
+	var namespaces []Namespace
+	err = scanEach(rows, func() error {
+		ns := Namespace{Database: config.Database}
+		if err := rows.Scan(&ns.Schema, &ns.Current); err != nil {
+			return err
+		}
+		namespaces = append(namespaces, ns)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan schemas: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT datname
+		FROM pg_database
+		WHERE datallowconn
+		AND NOT datistemplate
+		AND datname <> current_database()
+		ORDER BY datname
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query databases: %w", err)
+	}
+	err = scanEach(rows, func() error {
+		var ns Namespace
+		if err := rows.Scan(&ns.Database); err != nil {
+			return err
+		}
+		namespaces = append(namespaces, ns)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan databases: %w", err)
+	}
+	return namespaces, nil
+}
+
+// Sessions reads pg_stat_activity for client backends. pg_blocking_pids finds
+// the sessions holding the locks a session waits for.
+func (postgresDriver) Sessions(ctx context.Context, db *sql.DB) ([]Session, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT
+			a.pid,
+			COALESCE(a.usename, ''),
+			COALESCE(a.datname, ''),
+			COALESCE(host(a.client_addr), CASE WHEN a.client_port = -1 THEN 'local' ELSE '' END),
+			COALESCE(a.state, ''),
+			COALESCE(EXTRACT(EPOCH FROM now() - CASE WHEN a.state = 'active' THEN a.query_start ELSE a.state_change END), 0)::float8,
+			COALESCE(EXTRACT(EPOCH FROM now() - a.xact_start), 0)::float8,
+			COALESCE(a.wait_event_type || ': ' || a.wait_event, ''),
+			COALESCE(a.query, ''),
+			array_to_string(pg_blocking_pids(a.pid), ','),
+			COALESCE((
+				SELECT l.mode || ' on ' || COALESCE(l.relation::regclass::text, l.locktype)
+				FROM pg_locks l
+				WHERE l.pid = a.pid
+				AND NOT l.granted
+				LIMIT 1
+			), ''),
+			a.pid = pg_backend_pid()
+		FROM pg_stat_activity a
+		WHERE a.backend_type = 'client backend'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query sessions: %w", err)
+	}
+	var sessions []Session
+	err = scanEach(rows, func() error {
+		var s Session
+		var duration, transaction float64
+		var blockedBy string
+		if err := rows.Scan(&s.ID, &s.User, &s.Database, &s.Client, &s.State, &duration, &transaction,
+			&s.Wait, &s.Query, &blockedBy, &s.Lock, &s.Own); err != nil {
+			return err
+		}
+		s.Duration = fromSeconds(duration)
+		s.Transaction = fromSeconds(transaction)
+		s.BlockedBy = parseIDs(blockedBy)
+		sessions = append(sessions, s)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (postgresDriver) CancelQuery(ctx context.Context, db *sql.DB, id int64) error {
+	return signalBackend(ctx, db, "pg_cancel_backend", id)
+}
+
+func (postgresDriver) Terminate(ctx context.Context, db *sql.DB, id int64) error {
+	return signalBackend(ctx, db, "pg_terminate_backend", id)
+}
+
+// calls pg_cancel_backend or pg_terminate_backend, which return false rather
+// than failing when there is no such session.
+func signalBackend(ctx context.Context, db *sql.DB, function string, id int64) error {
+	var ok bool
+	if err := db.QueryRowContext(ctx, "SELECT "+function+"($1)", id).Scan(&ok); err != nil {
+		return fmt.Errorf("%s: %w", function, err)
+	}
+	if !ok {
+		return fmt.Errorf("session %d is gone", id)
+	}
+	return nil
+}
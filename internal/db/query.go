@@ -0,0 +1,286 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// QueryRowLimit caps how many rows an ad-hoc query loads.
+const QueryRowLimit = 1000
+
+// QueryResult is the outcome of an ad-hoc statement. Statements that return rows
+// fill Columns and Rows; others report RowsAffected.
+type QueryResult struct {
+	Columns      []ColumnInfo
+	Rows         RowData
+	HasRows      bool
+	Truncated    bool
+	RowsAffected int64
+	Duration     time.Duration
+}
+
+// readKeywords start statements that never modify data.
+var readKeywords = map[string]bool{
+	"SELECT":   true,
+	"SHOW":     true,
+	"EXPLAIN":  true,
+	"DESCRIBE": true,
+	"DESC":     true,
+	"PRAGMA":   true,
+	"VALUES":   true,
+	"TABLE":    true,
+	"WITH":     true,
+}
+
+// writeKeywords modify data or schema wherever they appear, e.g. in a WITH clause.
+var writeKeywords = map[string]bool{
+	"INSERT":   true,
+	"UPDATE":   true,
+	"DELETE":   true,
+	"MERGE":    true,
+	"CREATE":   true,
+	"ALTER":    true,
+	"DROP":     true,
+	"TRUNCATE": true,
+	"GRANT":    true,
+	"REVOKE":   true,
+}
+
+// queryer is a connection pool or a single connection.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Query runs an ad-hoc statement on any pooled connection. Statements that
+// return rows load at most QueryRowLimit of them.
+func (c *Client) Query(ctx context.Context, statement string) (*QueryResult, error) {
+	return runStatement(ctx, c.db, statement)
+}
+
+// QuerySession runs ad-hoc statements on one connection, so a transaction begun
+// by one statement is still open for the statements after it.
+type QuerySession struct {
+	client *Client
+	mu     sync.Mutex
+	conn   *sql.Conn
+}
+
+// starts a session. It takes a connection from the pool on its first statement.
+func (c *Client) NewQuerySession() *QuerySession {
+	return &QuerySession{client: c}
+}
+
+// Query runs a statement on the session's connection, like Client.Query.
+func (s *QuerySession) Query(ctx context.Context, statement string) (*QueryResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.connect(ctx); err != nil {
+		return nil, err
+	}
+	return runStatement(ctx, s.conn, statement)
+}
+
+// ExportQuery streams a statement's result to out like Client.ExportQuery,
+// but on the session's connection, so it sees the session's uncommitted
+// changes and settings.
+func (s *QuerySession) ExportQuery(ctx context.Context, statement, format string, out io.Writer) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.connect(ctx); err != nil {
+		return 0, err
+	}
+	return s.client.exportQuery(ctx, s.conn, statement, format, out)
+}
+
+// takes the session's connection from the pool if it has none. s.mu must be held.
+func (s *QuerySession) connect(ctx context.Context) error {
+	if s.conn != nil {
+		return nil
+	}
+	conn, err := s.client.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+	s.conn = conn
+	return nil
+}
+
+// Close rolls back a transaction the session left open and returns the
+// connection to the pool. It waits for a running statement to finish.
+func (s *QuerySession) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// fails harmlessly when no transaction is open
+	_, _ = s.conn.ExecContext(ctx, "ROLLBACK")
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func runStatement(ctx context.Context, q queryer, statement string) (*QueryResult, error) {
+	start := time.Now()
+
+	if !returnsRows(statement) {
+		res, err := q.ExecContext(ctx, statement)
+		if err != nil {
+			return nil, fmt.Errorf("exec statement: %w", err)
+		}
+		affected, _ := res.RowsAffected()
+		return &QueryResult{RowsAffected: affected, Duration: time.Since(start)}, nil
+	}
+
+	rows, err := q.QueryContext(ctx, statement)
+	if err != nil {
+		return nil, fmt.Errorf("run query: %w", err)
+	}
+	defer rows.Close()
+
+	columns, data, err := scanRows(rows, QueryRowLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryResult{
+		Columns:   columns,
+		Rows:      data,
+		HasRows:   true,
+		Truncated: len(data) == QueryRowLimit && rows.Next(),
+		Duration:  time.Since(start),
+	}, nil
+}
+
+// IsWrite reports whether a statement may modify data or schema. Unknown
+// statements count as writes.
+func IsWrite(statement string) bool {
+	words := keywords(statement)
+	if len(words) == 0 {
+		return false
+	}
+	if !readKeywords[words[0]] {
+		return true
+	}
+	for _, w := range words[1:] {
+		if writeKeywords[w] {
+			return true
+		}
+	}
+	return false
+}
+
+// reports whether a statement produces a result set, including DML with RETURNING.
+func returnsRows(statement string) bool {
+	words := keywords(statement)
+	if len(words) == 0 {
+		return false
+	}
+	if readKeywords[words[0]] {
+		return true
+	}
+	for _, w := range words {
+		if w == "RETURNING" {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the upper-cased bare words of a statement, skipping comments, string
+// literals and quoted identifiers so their contents are never taken as keywords.
+func keywords(statement string) []string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, strings.ToUpper(word.String()))
+			word.Reset()
+		}
+	}
+
+	s := statement
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch == '-' && i+1 < len(s) && s[i+1] == '-':
+			flush()
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case ch == '/' && i+1 < len(s) && s[i+1] == '*':
+			flush()
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return words
+			}
+			i += end + 3
+		case ch == '\'' || ch == '"' || ch == '`':
+			flush()
+			end := strings.IndexByte(s[i+1:], ch)
+			if end < 0 {
+				return words
+			}
+			i += end + 1
+		case ch == '_' || unicode.IsLetter(rune(ch)):
+			word.WriteByte(ch)
+		case unicode.IsDigit(rune(ch)) && word.Len() > 0:
+			word.WriteByte(ch)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return words
+}
+
+// scans up to limit rows (0 for no limit), converting []byte values to strings.
+// Column types come from the driver's reported database type names.
+func scanRows(rows *sql.Rows, limit int) ([]ColumnInfo, RowData, error) {
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("get columns: %w", err)
+	}
+	columns := make([]ColumnInfo, len(types))
+	for i, t := range types {
+		columns[i] = ColumnInfo{Name: t.Name(), Type: strings.ToLower(t.DatabaseTypeName())}
+	}
+
+	var data RowData
+	for (limit == 0 || len(data) < limit) && rows.Next() {
+		values := make([]interface{}, len(columns))
+		valuePtrs := make([]interface{}, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, nil, fmt.Errorf("scan row: %w", err)
+		}
+
+		row := make(map[string]interface{})
+		for i, col := range columns {
+			val := values[i]
+			if b, ok := val.([]byte); ok {
+				row[col.Name] = string(b)
+			} else {
+				row[col.Name] = val
+			}
+		}
+		data = append(data, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("iterate rows: %w", err)
+	}
+
+	return columns, data, nil
+}
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsWrite(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{"SELECT * FROM users", false},
+		{"  select 1", false},
+		{"-- latest first\nSELECT * FROM users ORDER BY id DESC", false},
+		{"/* note */ SHOW TABLES", false},
+		{"EXPLAIN SELECT 1", false},
+		{"SELECT 'DELETE FROM users' AS text", false},
+		{`SELECT "update" FROM audit`, false},
+		{"WITH recent AS (SELECT * FROM posts) SELECT * FROM recent", false},
+		{"WITH gone AS (DELETE FROM posts RETURNING *) SELECT count(*) FROM gone", true},
+		{"UPDATE users SET name = 'x'", true},
+		{"insert into users (name) values ('y')", true},
+		{"DROP TABLE users", true},
+		{"VACUUM", true},
+		{"", false},
+		{"-- only a comment", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			if got := IsWrite(tt.statement); got != tt.want {
+				t.Errorf("IsWrite(%q) = %v, want %v", tt.statement, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReturnsRows(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{"SELECT 1", true},
+		{"PRAGMA table_info(users)", true},
+		{"INSERT INTO users (name) VALUES ('a') RETURNING id", true},
+		{"DELETE FROM users", false},
+		{"CREATE TABLE t (returning_id INT)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			if got := returnsRows(tt.statement); got != tt.want {
+				t.Errorf("returnsRows(%q) = %v, want %v", tt.statement, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientQuery(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: filepath.Join(t.TempDir(), "q.db")}, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	res, err := client.Query(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)")
+	if err != nil {
+		t.Fatalf("Query(CREATE) error: %v", err)
+	}
+	if res.HasRows {
+		t.Error("CREATE should not return rows")
+	}
+
+	res, err = client.Query(ctx, "INSERT INTO items (name) VALUES ('a'), ('b'), ('c')")
+	if err != nil {
+		t.Fatalf("Query(INSERT) error: %v", err)
+	}
+	if res.RowsAffected != 3 {
+		t.Errorf("RowsAffected = %d, want 3", res.RowsAffected)
+	}
+
+	res, err = client.Query(ctx, "SELECT id, name FROM items WHERE name <> 'b' ORDER BY id")
+	if err != nil {
+		t.Fatalf("Query(SELECT) error: %v", err)
+	}
+	if !res.HasRows || len(res.Rows) != 2 || res.Truncated {
+		t.Fatalf("Query(SELECT) = %+v, want 2 rows", res)
+	}
+	if res.Columns[0].Name != "id" || res.Columns[0].Type != "integer" {
+		t.Errorf("Columns[0] = %+v, want id integer", res.Columns[0])
+	}
+	if res.Rows[1]["name"] != "c" {
+		t.Errorf("second row = %v, want name c", res.Rows[1])
+	}
+
+	if _, err := client.Query(ctx, "SELECT * FROM missing"); err == nil {
+		t.Error("expected error for missing table")
+	}
+}
+
+func TestQuerySessionKeepsTransaction(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: filepath.Join(t.TempDir(), "tx.db")}, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if _, err := client.Query(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+
+	count := func() any {
+		res, err := client.Query(ctx, "SELECT COUNT(*) AS n FROM items")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Rows[0]["n"]
+	}
+
+	session := client.NewQuerySession()
+	for _, statement := range []string{"BEGIN", "INSERT INTO items DEFAULT VALUES", "ROLLBACK"} {
+		if _, err := session.Query(ctx, statement); err != nil {
+			t.Fatalf("Query(%q) error: %v", statement, err)
+		}
+	}
+	if n := count(); n != int64(0) {
+		t.Errorf("rows after ROLLBACK = %v, want 0", n)
+	}
+
+	// a transaction left open is rolled back when the session closes
+	for _, statement := range []string{"BEGIN", "INSERT INTO items DEFAULT VALUES"} {
+		if _, err := session.Query(ctx, statement); err != nil {
+			t.Fatalf("Query(%q) error: %v", statement, err)
+		}
+	}
+	// an export on the session sees its uncommitted rows
+	var b strings.Builder
+	if n, err := session.ExportQuery(ctx, "SELECT id FROM items", FormatCSV, &b); err != nil || n != 1 {
+		t.Errorf("ExportQuery() = %d, %v, want the uncommitted row", n, err)
+	}
+	if err := session.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if n := count(); n != int64(0) {
+		t.Errorf("rows after Close = %v, want 0", n)
+	}
+}
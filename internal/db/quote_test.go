@@ -36,10 +36,28 @@ func TestQuoteIdentifier(t *testing.T) {
 			dbType: "mysql",
 			want:   "`my``table`",
 		},
+		{
+			name:   "mariadb with backtick",
+			input:  "my`table",
+			dbType: "mariadb",
+			want:   "`my``table`",
+		},
+		{
+			name:    "non-sql db type",
+			input:   "users",
+			dbType:  "redis",
+			wantErr: true,
+		},
+		{
+			name:   "sqlite with double quote",
+			input:  `my"table`,
+			dbType: "sqlite",
+			want:   `"my""table"`,
+		},
 		{
 			name:    "unsupported db type",
 			input:   "users",
-			dbType:  "sqlite",
+			dbType:  "cassandra",
 			wantErr: true,
 		},
 		{
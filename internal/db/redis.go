@@ -0,0 +1,279 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func init() {
+	Register("redis", redisDriver{})
+}
+
+// redisDriver locates redis containers; keys are browsed with RedisClient.
+type redisDriver struct{}
+
+func (redisDriver) DefaultPort() string { return "6379" }
+
+func (redisDriver) Configure(config *ConnectionConfig, env map[string]string, args []string) {
+	config.User = getEnv(env, "REDIS_USERNAME", "")
+	config.Password = getEnv(env, "REDIS_PASSWORD", parseRequirePass(args))
+	config.Database = "0"
+}
+
+func (redisDriver) EnvKeys() []string { return []string{"REDIS_USERNAME", "REDIS_PASSWORD"} }
+
+// redisValueLimit caps how many elements of a collection are loaded for display.
+const redisValueLimit = 500
+
+// RedisClient wraps a Redis connection for key browsing.
+type RedisClient struct {
+	rdb *redis.Client
+}
+
+// KeyInfo holds metadata about a Redis key. TTL is -1 for keys without expiry,
+// and Memory is -1 when the server does not support MEMORY USAGE.
+type KeyInfo struct {
+	Name   string
+	Type   string
+	TTL    time.Duration
+	Memory int64
+}
+
+// RedisValue is a key's contents laid out as a table. Length is the full size of
+// the value, which may exceed len(Rows) for large collections.
+type RedisValue struct {
+	Type    string
+	Length  int64
+	Columns []string
+	Rows    [][]string
+}
+
+// NewRedisClient connects to Redis using the discovered host, port and password.
+func NewRedisClient(ctx context.Context, config *ConnectionConfig) (*RedisClient, error) {
+	opts := &redis.Options{
+		Addr:     net.JoinHostPort(config.Host, config.Port),
+		Username: config.User,
+		Password: config.Password,
+	}
+	if n, err := strconv.Atoi(config.Database); err == nil {
+		opts.DB = n
+	}
+
+	client := &RedisClient{rdb: redis.NewClient(opts)}
+	if err := client.rdb.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("ping redis: %w", err)
+	}
+	return client, nil
+}
+
+// Close closes the Redis connection.
+func (c *RedisClient) Close() error {
+	return c.rdb.Close()
+}
+
+// ScanKeys walks the keyspace with SCAN and returns up to limit keys matching
+// pattern, sorted by name, along with their type, TTL and memory usage.
+func (c *RedisClient) ScanKeys(ctx context.Context, pattern string, limit int) ([]KeyInfo, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var names []string
+	var cursor uint64
+	for {
+		batch, next, err := c.rdb.Scan(ctx, cursor, pattern, 200).Result()
+		if err != nil {
+			return nil, fmt.Errorf("scan keys: %w", err)
+		}
+		names = append(names, batch...)
+		cursor = next
+		if cursor == 0 || len(names) >= limit {
+			break
+		}
+	}
+	if len(names) > limit {
+		names = names[:limit]
+	}
+	sort.Strings(names)
+
+	pipe := c.rdb.Pipeline()
+	types := make([]*redis.StatusCmd, len(names))
+	ttls := make([]*redis.DurationCmd, len(names))
+	mems := make([]*redis.IntCmd, len(names))
+	for i, name := range names {
+		types[i] = pipe.Type(ctx, name)
+		ttls[i] = pipe.PTTL(ctx, name)
+		mems[i] = pipe.MemoryUsage(ctx, name)
+	}
+	// MEMORY USAGE is not available everywhere, so errors are checked per command
+	_, _ = pipe.Exec(ctx)
+
+	keys := make([]KeyInfo, 0, len(names))
+	for i, name := range names {
+		key := KeyInfo{Name: name, TTL: -1, Memory: -1}
+		t, err := types[i].Result()
+		if err != nil {
+			return nil, fmt.Errorf("key type: %w", err)
+		}
+		key.Type = t
+		if key.Type == "none" {
+			// expired or deleted between SCAN and TYPE
+			continue
+		}
+		if ttl, err := ttls[i].Result(); err == nil && ttl > 0 {
+			key.TTL = ttl
+		}
+		if mem, err := mems[i].Result(); err == nil {
+			key.Memory = mem
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// GetValue loads a key's contents according to its type.
+func (c *RedisClient) GetValue(ctx context.Context, key string) (*RedisValue, error) {
+	keyType, err := c.rdb.Type(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("key type: %w", err)
+	}
+
+	value := &RedisValue{Type: keyType}
+	switch keyType {
+	case "string":
+		s, err := c.rdb.Get(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("get string: %w", err)
+		}
+		value.Length = int64(len(s))
+		value.Columns = []string{"value"}
+		value.Rows = [][]string{{s}}
+
+	case "hash":
+		value.Length, err = c.rdb.HLen(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("hash length: %w", err)
+		}
+		fields, _, err := c.rdb.HScan(ctx, key, 0, "*", redisValueLimit).Result()
+		if err != nil {
+			return nil, fmt.Errorf("scan hash: %w", err)
+		}
+		value.Columns = []string{"field", "value"}
+		for i := 0; i+1 < len(fields); i += 2 {
+			value.Rows = append(value.Rows, []string{fields[i], fields[i+1]})
+		}
+		sort.Slice(value.Rows, func(i, j int) bool { return value.Rows[i][0] < value.Rows[j][0] })
+
+	case "list":
+		value.Length, err = c.rdb.LLen(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("list length: %w", err)
+		}
+		items, err := c.rdb.LRange(ctx, key, 0, redisValueLimit-1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("list range: %w", err)
+		}
+		value.Columns = []string{"index", "value"}
+		for i, item := range items {
+			value.Rows = append(value.Rows, []string{strconv.Itoa(i), item})
+		}
+
+	case "set":
+		value.Length, err = c.rdb.SCard(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("set size: %w", err)
+		}
+		members, _, err := c.rdb.SScan(ctx, key, 0, "*", redisValueLimit).Result()
+		if err != nil {
+			return nil, fmt.Errorf("scan set: %w", err)
+		}
+		sort.Strings(members)
+		value.Columns = []string{"member"}
+		for _, m := range members {
+			value.Rows = append(value.Rows, []string{m})
+		}
+
+	case "zset":
+		value.Length, err = c.rdb.ZCard(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("sorted set size: %w", err)
+		}
+		members, err := c.rdb.ZRangeWithScores(ctx, key, 0, redisValueLimit-1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("sorted set range: %w", err)
+		}
+		value.Columns = []string{"score", "member"}
+		for _, z := range members {
+			value.Rows = append(value.Rows, []string{
+				strconv.FormatFloat(z.Score, 'f', -1, 64),
+				fmt.Sprint(z.Member),
+			})
+		}
+
+	case "stream":
+		value.Length, err = c.rdb.XLen(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("stream length: %w", err)
+		}
+		entries, err := c.rdb.XRangeN(ctx, key, "-", "+", redisValueLimit).Result()
+		if err != nil {
+			return nil, fmt.Errorf("stream range: %w", err)
+		}
+		value.Columns = []string{"id", "fields"}
+		for _, e := range entries {
+			value.Rows = append(value.Rows, []string{e.ID, formatStreamFields(e.Values)})
+		}
+
+	case "none":
+		return nil, errors.New("key does not exist")
+
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", keyType)
+	}
+
+	return value, nil
+}
+
+// formats stream entry fields as "k=v k=v" in key order.
+func formatStreamFields(values map[string]interface{}) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, values[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseRequirePass extracts the password from a redis-server command line,
+// e.g. ["redis-server", "--requirepass", "secret"].
+func parseRequirePass(args []string) string {
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "--requirepass=") {
+			return strings.TrimPrefix(arg, "--requirepass=")
+		}
+		if arg == "--requirepass" && i+1 < len(args) {
+			return args[i+1]
+		}
+		// the official image's docs use a shell form: sh -c "redis-server --requirepass x"
+		if strings.Contains(arg, "--requirepass") && strings.ContainsAny(arg, " \t") {
+			if pass := parseRequirePass(strings.Fields(arg)); pass != "" {
+				return pass
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *RedisClient) {
+	t.Helper()
+	srv := miniredis.RunT(t)
+	srv.RequireAuth("secret")
+
+	client, err := NewRedisClient(context.Background(), &ConnectionConfig{
+		Host:     srv.Host(),
+		Port:     srv.Port(),
+		Password: "secret",
+		Database: "0",
+	})
+	if err != nil {
+		t.Fatalf("NewRedisClient() error: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return srv, client
+}
+
+func TestNewRedisClientWrongPassword(t *testing.T) {
+	srv := miniredis.RunT(t)
+	srv.RequireAuth("secret")
+
+	_, err := NewRedisClient(context.Background(), &ConnectionConfig{
+		Host:     srv.Host(),
+		Port:     srv.Port(),
+		Password: "wrong",
+	})
+	if err == nil {
+		t.Error("expected error for wrong password")
+	}
+}
+
+func TestScanKeys(t *testing.T) {
+	srv, client := newTestRedis(t)
+	ctx := context.Background()
+
+	srv.Set("session:2", "b")
+	srv.Set("session:1", "a")
+	srv.SetTTL("session:1", time.Hour)
+	srv.HSet("user:1", "name", "ada")
+	srv.Lpush("queue", "job")
+
+	keys, err := client.ScanKeys(ctx, "", 100)
+	if err != nil {
+		t.Fatalf("ScanKeys() error: %v", err)
+	}
+	var names []string
+	for _, k := range keys {
+		names = append(names, k.Name)
+	}
+	if want := []string{"queue", "session:1", "session:2", "user:1"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ScanKeys() names = %v, want %v", names, want)
+	}
+
+	keys, err = client.ScanKeys(ctx, "session:*", 100)
+	if err != nil {
+		t.Fatalf("ScanKeys() error: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("ScanKeys(session:*) returned %d keys, want 2", len(keys))
+	}
+	if keys[0].Type != "string" || keys[0].TTL != time.Hour {
+		t.Errorf("session:1 = %+v, want string with 1h TTL", keys[0])
+	}
+	if keys[1].TTL != -1 {
+		t.Errorf("session:2 TTL = %v, want -1", keys[1].TTL)
+	}
+
+	keys, err = client.ScanKeys(ctx, "*", 1)
+	if err != nil {
+		t.Fatalf("ScanKeys() error: %v", err)
+	}
+	if len(keys) != 1 {
+		t.Errorf("ScanKeys(limit 1) returned %d keys", len(keys))
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	srv, client := newTestRedis(t)
+	ctx := context.Background()
+
+	srv.Set("str", "hello")
+	srv.HSet("hash", "b", "2")
+	srv.HSet("hash", "a", "1")
+	srv.Push("list", "x", "y")
+	srv.SAdd("set", "m2", "m1")
+	srv.ZAdd("zset", 2.5, "high")
+	srv.ZAdd("zset", 1, "low")
+	if _, err := srv.XAdd("stream", "1-1", []string{"event", "signup", "user", "1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key     string
+		typ     string
+		columns []string
+		rows    [][]string
+	}{
+		{"str", "string", []string{"value"}, [][]string{{"hello"}}},
+		{"hash", "hash", []string{"field", "value"}, [][]string{{"a", "1"}, {"b", "2"}}},
+		{"list", "list", []string{"index", "value"}, [][]string{{"0", "x"}, {"1", "y"}}},
+		{"set", "set", []string{"member"}, [][]string{{"m1"}, {"m2"}}},
+		{"zset", "zset", []string{"score", "member"}, [][]string{{"1", "low"}, {"2.5", "high"}}},
+		{"stream", "stream", []string{"id", "fields"}, [][]string{{"1-1", "event=signup user=1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			got, err := client.GetValue(ctx, tt.key)
+			if err != nil {
+				t.Fatalf("GetValue() error: %v", err)
+			}
+			if got.Type != tt.typ {
+				t.Errorf("Type = %q, want %q", got.Type, tt.typ)
+			}
+			if !reflect.DeepEqual(got.Columns, tt.columns) {
+				t.Errorf("Columns = %v, want %v", got.Columns, tt.columns)
+			}
+			if !reflect.DeepEqual(got.Rows, tt.rows) {
+				t.Errorf("Rows = %v, want %v", got.Rows, tt.rows)
+			}
+		})
+	}
+
+	if _, err := client.GetValue(ctx, "missing"); err == nil {
+		t.Error("expected error for missing key")
+	}
+}
+
+func TestParseRequirePass(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"separate arg", []string{"--requirepass", "s3cret"}, "s3cret"},
+		{"equals form", []string{"--appendonly", "yes", "--requirepass=s3cret"}, "s3cret"},
+		{"shell form", []string{"-c", "redis-server --requirepass s3cret --save 60 1"}, "s3cret"},
+		{"none", []string{"redis-server"}, ""},
+		{"missing value", []string{"--requirepass"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRequirePass(tt.args); got != tt.want {
+				t.Errorf("parseRequirePass(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Constraint types reported in TableSchema.Constraints.
+const (
+	ConstraintPrimaryKey = "PRIMARY KEY"
+	ConstraintUnique     = "UNIQUE"
+	ConstraintForeignKey = "FOREIGN KEY"
+	ConstraintCheck      = "CHECK"
+)
+
+// ColumnDetail describes a column as declared in the table definition.
+type ColumnDetail struct {
+	Name     string
+	Type     string
+	Nullable bool
+	// Default is the default expression, empty when the column has none.
+	Default    string
+	PrimaryKey bool
+}
+
+// IndexInfo describes an index on a table.
+type IndexInfo struct {
+	Name    string
+	Columns []string
+	Unique  bool
+	Primary bool
+}
+
+// Constraint describes a table constraint. RefTable and RefColumns are set for
+// foreign keys only.
+type Constraint struct {
+	Name       string
+	Type       string
+	Columns    []string
+	Definition string
+	RefTable   string
+	RefColumns []string
+}
+
+// TableSchema is the full definition of a table as the server reports it.
+type TableSchema struct {
+	Table       string
+	Columns     []ColumnDetail
+	Indexes     []IndexInfo
+	Constraints []Constraint
+	DDL         string
+}
+
+// PrimaryKey returns the primary key columns in key order.
+func (s *TableSchema) PrimaryKey() []string {
+	for _, c := range s.Constraints {
+		if c.Type == ConstraintPrimaryKey {
+			return c.Columns
+		}
+	}
+	return nil
+}
+
+// ConstraintsOf returns the constraints of one type, e.g. ConstraintForeignKey.
+func (s *TableSchema) ConstraintsOf(constraintType string) []Constraint {
+	var out []Constraint
+	for _, c := range s.Constraints {
+		if c.Type == constraintType {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// marks the primary key columns from the constraints.
+func (s *TableSchema) markPrimaryKey() {
+	pk := make(map[string]bool)
+	for _, name := range s.PrimaryKey() {
+		pk[name] = true
+	}
+	for i := range s.Columns {
+		s.Columns[i].PrimaryKey = pk[s.Columns[i].Name]
+	}
+}
+
+// SchemaInspector is implemented by SQL drivers that can describe a table's
+// full definition.
+type SchemaInspector interface {
+	Schema(ctx context.Context, db *sql.DB, tableName string) (*TableSchema, error)
+}
+
+// Schema returns a table's columns, indexes, constraints and DDL.
+func (c *Client) Schema(ctx context.Context, tableName string) (*TableSchema, error) {
+	inspector, ok := c.driver.(SchemaInspector)
+	if !ok {
+		return nil, fmt.Errorf("schema inspection is not supported for %s", c.dbType)
+	}
+	schema, err := inspector.Schema(ctx, c.db, tableName)
+	if err != nil {
+		return nil, err
+	}
+	schema.markPrimaryKey()
+	return schema, nil
+}
+
+// formats a foreign key the way CREATE TABLE declares it. Rules are omitted
+// when they are the default.
+func foreignKeyDefinition(quote func(string) string, c Constraint, onUpdate, onDelete string) string {
+	def := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+		strings.Join(mapStrings(c.Columns, quote), ", "),
+		quote(c.RefTable),
+		strings.Join(mapStrings(c.RefColumns, quote), ", "))
+	if onUpdate != "" && onUpdate != "NO ACTION" {
+		def += " ON UPDATE " + onUpdate
+	}
+	if onDelete != "" && onDelete != "NO ACTION" {
+		def += " ON DELETE " + onDelete
+	}
+	return def
+}
+
+func mapStrings(in []string, f func(string) string) []string {
+	out := make([]string, len(in))
+	for i, s := range in {
+		out[i] = f(s)
+	}
+	return out
+}
+
+// splits a list aggregated with schemaListSeparator.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, schemaListSeparator)
+}
+
+// schemaListSeparator joins aggregated names in catalog queries. It cannot
+// appear in an identifier typed by a person.
+const schemaListSeparator = "\x1f"
+
+// calls scan for each row, then closes the rows and reports any iteration error.
+func scanEach(rows *sql.Rows, scan func() error) error {
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSQLiteSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.sqlite3")
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = raw.Exec(`
+		CREATE TABLE orgs (id INTEGER PRIMARY KEY, slug TEXT NOT NULL UNIQUE);
+		CREATE TABLE members (
+			org_id INTEGER NOT NULL REFERENCES orgs (id) ON DELETE CASCADE,
+			user_id INTEGER NOT NULL,
+			role TEXT NOT NULL DEFAULT 'member',
+			joined_at TEXT,
+			PRIMARY KEY (org_id, user_id)
+		);
+		CREATE INDEX members_role ON members (role, joined_at);
+	`)
+	raw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: path}, "sqlite")
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	defer client.Close()
+
+	schema, err := client.Schema(ctx, "members")
+	if err != nil {
+		t.Fatalf("Schema() error: %v", err)
+	}
+
+	wantColumns := []ColumnDetail{
+		{Name: "org_id", Type: "INTEGER", PrimaryKey: true},
+		{Name: "user_id", Type: "INTEGER", PrimaryKey: true},
+		{Name: "role", Type: "TEXT", Default: "'member'"},
+		{Name: "joined_at", Type: "TEXT", Nullable: true},
+	}
+	if !reflect.DeepEqual(schema.Columns, wantColumns) {
+		t.Errorf("Columns = %+v, want %+v", schema.Columns, wantColumns)
+	}
+
+	if got := schema.PrimaryKey(); !reflect.DeepEqual(got, []string{"org_id", "user_id"}) {
+		t.Errorf("PrimaryKey() = %v, want [org_id user_id]", got)
+	}
+
+	fks := schema.ConstraintsOf(ConstraintForeignKey)
+	if len(fks) != 1 {
+		t.Fatalf("foreign keys = %+v, want 1", fks)
+	}
+	if fks[0].RefTable != "orgs" || !reflect.DeepEqual(fks[0].Columns, []string{"org_id"}) ||
+		!reflect.DeepEqual(fks[0].RefColumns, []string{"id"}) {
+		t.Errorf("foreign key = %+v, want org_id -> orgs(id)", fks[0])
+	}
+	if want := `FOREIGN KEY ("org_id") REFERENCES "orgs" ("id") ON DELETE CASCADE`; fks[0].Definition != want {
+		t.Errorf("foreign key definition = %q, want %q", fks[0].Definition, want)
+	}
+
+	if len(schema.Indexes) != 2 || !schema.Indexes[0].Primary || schema.Indexes[1].Name != "members_role" ||
+		!reflect.DeepEqual(schema.Indexes[1].Columns, []string{"role", "joined_at"}) {
+		t.Errorf("Indexes = %+v, want the primary key index then members_role(role, joined_at)", schema.Indexes)
+	}
+
+	if !strings.HasPrefix(schema.DDL, "CREATE TABLE members") || !strings.Contains(schema.DDL, "CREATE INDEX members_role") {
+		t.Errorf("DDL = %q, want the table and index statements", schema.DDL)
+	}
+
+	orgs, err := client.Schema(ctx, "orgs")
+	if err != nil {
+		t.Fatalf("Schema(orgs) error: %v", err)
+	}
+	unique := orgs.ConstraintsOf(ConstraintUnique)
+	if len(unique) != 1 || !reflect.DeepEqual(unique[0].Columns, []string{"slug"}) {
+		t.Errorf("unique constraints = %+v, want slug", unique)
+	}
+
+	if _, err := client.Schema(ctx, "missing"); err == nil {
+		t.Error("Schema() of a missing table succeeded")
+	}
+}
+
+func TestPostgresDDL(t *testing.T) {
+	schema := &TableSchema{
+		Columns: []ColumnDetail{
+			{Name: "id", Type: "integer", Default: "nextval('users_id_seq'::regclass)"},
+			{Name: "email", Type: "character varying(255)"},
+			{Name: "bio", Type: "text", Nullable: true},
+		},
+		Constraints: []Constraint{
+			{Name: "users_pkey", Type: ConstraintPrimaryKey, Definition: "PRIMARY KEY (id)"},
+			{Name: "users_email_key", Type: ConstraintUnique, Definition: "UNIQUE (email)"},
+		},
+		Indexes: []IndexInfo{
+			{Name: "users_bio_idx"},
+			{Name: "users_email_key", Unique: true},
+			{Name: "users_pkey", Unique: true, Primary: true},
+		},
+	}
+	indexDefs := map[string]string{
+		"users_bio_idx":   "CREATE INDEX users_bio_idx ON public.users USING btree (bio)",
+		"users_email_key": "CREATE UNIQUE INDEX users_email_key ON public.users USING btree (email)",
+		"users_pkey":      "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)",
+	}
+
+	got := postgresDDL(postgresDriver{}.Quote, `"public"."users"`, schema, indexDefs)
+	want := `CREATE TABLE "public"."users" (
+    "id" integer NOT NULL DEFAULT nextval('users_id_seq'::regclass),
+    "email" character varying(255) NOT NULL,
+    "bio" text,
+    CONSTRAINT "users_pkey" PRIMARY KEY (id),
+    CONSTRAINT "users_email_key" UNIQUE (email)
+);
+
+CREATE INDEX users_bio_idx ON public.users USING btree (bio);`
+	if got != want {
+		t.Errorf("postgresDDL() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,224 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	Register("sqlite", sqliteDriver{})
+}
+
+// sqliteDriver opens database files directly. ConnectionConfig.Database holds
+// the file path; the other fields are unused.
+type sqliteDriver struct{}
+
+func (sqliteDriver) DefaultPort() string   { return "" }
+func (sqliteDriver) SQLDriverName() string { return "sqlite" }
+
+func (sqliteDriver) Configure(config *ConnectionConfig, env map[string]string, args []string) {}
+
+func (sqliteDriver) EnvKeys() []string { return nil }
+
+// DSN waits on locks held by the app that owns the file instead of failing with SQLITE_BUSY.
+// The path is escaped so '?', '#' and '%' in file names are not read as URI syntax.
+func (sqliteDriver) DSN(config *ConnectionConfig) string {
+	dsn := url.URL{
+		Scheme:   "file",
+		Opaque:   (&url.URL{Path: config.Database}).EscapedPath(),
+		RawQuery: url.Values{"_pragma": {"busy_timeout(5000)"}}.Encode(),
+	}
+	return dsn.String()
+}
+
+func (sqliteDriver) ListTables(ctx context.Context, db *sql.DB) ([]TableInfo, error) {
+	return queryTables(ctx, db, `
+		SELECT
+			m.name,
+			m.type,
+			(SELECT COUNT(*) FROM pragma_table_info(m.name)) as column_count,
+			-- SQLite keeps no row estimates outside of ANALYZE
+			-1
+		FROM sqlite_master m
+		WHERE m.type IN ('table', 'view')
+		AND m.name NOT LIKE 'sqlite_%'
+		ORDER BY m.name
+	`)
+}
+
+func (sqliteDriver) Columns(ctx context.Context, db *sql.DB, tableName string) ([]ColumnInfo, error) {
+	return queryColumns(ctx, db, `
+		SELECT name, type
+		FROM pragma_table_info(?)
+		ORDER BY cid
+	`, tableName)
+}
+
+func (sqliteDriver) Quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (sqliteDriver) PrimaryKey(ctx context.Context, db *sql.DB, tableName string) ([]string, error) {
+	return queryKeys(ctx, db, `
+		SELECT name
+		FROM pragma_table_info(?)
+		WHERE pk > 0
+		ORDER BY pk
+	`, tableName)
+}
+
+func (sqliteDriver) Placeholder(n int) string  { return "?" }
+func (sqliteDriver) AsText(expr string) string { return "CAST(" + expr + " AS TEXT)" }
+
+// Schema reads the table through the pragma table functions. SQLite keeps the
+// original CREATE statements, which make up the DDL.
+func (d sqliteDriver) Schema(ctx context.Context, db *sql.DB, tableName string) (*TableSchema, error) {
+	schema := &TableSchema{Table: tableName}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT name, type, "notnull" = 0, COALESCE(dflt_value, ''), pk
+		FROM pragma_table_info(?)
+		ORDER BY cid
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("query columns: %w", err)
+	}
+	var pk []string
+	pkOrder := make(map[string]int)
+	err = scanEach(rows, func() error {
+		var col ColumnDetail
+		var position int
+		if err := rows.Scan(&col.Name, &col.Type, &col.Nullable, &col.Default, &position); err != nil {
+			return err
+		}
+		if position > 0 {
+			pk = append(pk, col.Name)
+			pkOrder[col.Name] = position
+		}
+		schema.Columns = append(schema.Columns, col)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan columns: %w", err)
+	}
+	if len(schema.Columns) == 0 {
+		return nil, fmt.Errorf("no such table: %s", tableName)
+	}
+	sort.Slice(pk, func(i, j int) bool { return pkOrder[pk[i]] < pkOrder[pk[j]] })
+	if len(pk) > 0 {
+		schema.Constraints = append(schema.Constraints, Constraint{
+			Type:       ConstraintPrimaryKey,
+			Columns:    pk,
+			Definition: fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(mapStrings(pk, d.Quote), ", ")),
+		})
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT il.name, il."unique", il.origin, COALESCE(ii.name, '(expression)')
+		FROM pragma_index_list(?) il
+		JOIN pragma_index_info(il.name) ii
+		ORDER BY il.origin = 'pk' DESC, il.name, ii.seqno
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("query indexes: %w", err)
+	}
+	uniqueNames := make(map[string]bool)
+	err = scanEach(rows, func() error {
+		var idx IndexInfo
+		var origin, column string
+		if err := rows.Scan(&idx.Name, &idx.Unique, &origin, &column); err != nil {
+			return err
+		}
+		if n := len(schema.Indexes); n > 0 && schema.Indexes[n-1].Name == idx.Name {
+			schema.Indexes[n-1].Columns = append(schema.Indexes[n-1].Columns, column)
+			return nil
+		}
+		idx.Primary = origin == "pk"
+		idx.Columns = []string{column}
+		uniqueNames[idx.Name] = origin == "u"
+		schema.Indexes = append(schema.Indexes, idx)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan indexes: %w", err)
+	}
+	// UNIQUE constraints are implemented as automatic indexes.
+	for _, idx := range schema.Indexes {
+		if uniqueNames[idx.Name] {
+			schema.Constraints = append(schema.Constraints, Constraint{
+				Name:       idx.Name,
+				Type:       ConstraintUnique,
+				Columns:    idx.Columns,
+				Definition: fmt.Sprintf("UNIQUE (%s)", strings.Join(mapStrings(idx.Columns, d.Quote), ", ")),
+			})
+		}
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT id, "table", "from", COALESCE("to", ''), on_update, on_delete
+		FROM pragma_foreign_key_list(?)
+		ORDER BY id, seq
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("query foreign keys: %w", err)
+	}
+	lastID := -1
+	var rules [][2]string
+	err = scanEach(rows, func() error {
+		var id int
+		var refTable, from, to, onUpdate, onDelete string
+		if err := rows.Scan(&id, &refTable, &from, &to, &onUpdate, &onDelete); err != nil {
+			return err
+		}
+		if id != lastID {
+			lastID = id
+			schema.Constraints = append(schema.Constraints, Constraint{Type: ConstraintForeignKey, RefTable: refTable})
+			rules = append(rules, [2]string{onUpdate, onDelete})
+		}
+		last := &schema.Constraints[len(schema.Constraints)-1]
+		last.Columns = append(last.Columns, from)
+		last.RefColumns = append(last.RefColumns, to)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan foreign keys: %w", err)
+	}
+	fk := 0
+	for i, c := range schema.Constraints {
+		if c.Type == ConstraintForeignKey {
+			schema.Constraints[i].Definition = foreignKeyDefinition(d.Quote, c, rules[fk][0], rules[fk][1])
+			fk++
+		}
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT sql
+		FROM sqlite_master
+		WHERE tbl_name = ?
+		AND sql IS NOT NULL
+		ORDER BY type = 'table' DESC, name
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("query ddl: %w", err)
+	}
+	var statements []string
+	err = scanEach(rows, func() error {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			return err
+		}
+		statements = append(statements, stmt+";")
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan ddl: %w", err)
+	}
+	schema.DDL = strings.Join(statements, "\n\n")
+	return schema, nil
+}
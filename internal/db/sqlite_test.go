@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSQLiteClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.sqlite3")
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = raw.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, "e""mail" TEXT);
+		CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, body BLOB);
+		INSERT INTO users (name) VALUES ('ada'), ('grace');
+		CREATE VIEW user_names AS SELECT name FROM users;
+	`)
+	raw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: path}, "sqlite")
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	defer client.Close()
+
+	tables, err := client.ListTables(ctx)
+	if err != nil {
+		t.Fatalf("ListTables() error: %v", err)
+	}
+	if len(tables) != 3 || tables[0].Name != "posts" || tables[1].Name != "user_names" || tables[2].Name != "users" {
+		t.Fatalf("ListTables() = %+v, want posts, user_names and users", tables)
+	}
+	if tables[1].Kind != KindView || tables[2].Kind != KindTable {
+		t.Errorf("kinds = %q, %q, want view and table", tables[1].Kind, tables[2].Kind)
+	}
+	if tables[2].RowCount != -1 || tables[2].Approximate || tables[2].ColumnCount != 3 {
+		t.Errorf("users = %+v, want an unknown row count and 3 columns", tables[2])
+	}
+
+	counts := make(map[string]int)
+	for result := range client.CountTables(ctx, []string{"posts", "users", "missing"}, 2) {
+		if result.Err != nil {
+			counts[result.Table] = -1
+			continue
+		}
+		counts[result.Table] = result.Count
+	}
+	if want := map[string]int{"posts": 0, "users": 2, "missing": -1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("CountTables() = %v, want %v", counts, want)
+	}
+
+	columns, err := client.GetTableColumns(ctx, "users")
+	if err != nil {
+		t.Fatalf("GetTableColumns() error: %v", err)
+	}
+	want := []ColumnInfo{{"id", "INTEGER"}, {"name", "TEXT"}, {`e"mail`, "TEXT"}}
+	if len(columns) != len(want) {
+		t.Fatalf("GetTableColumns() = %+v, want %+v", columns, want)
+	}
+	for i := range want {
+		if columns[i] != want[i] {
+			t.Errorf("column %d = %+v, want %+v", i, columns[i], want[i])
+		}
+	}
+
+	rows, err := client.GetTableData(ctx, "users", 1, 1)
+	if err != nil {
+		t.Fatalf("GetTableData() error: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["name"] != "grace" {
+		t.Errorf("GetTableData() = %v, want the second user", rows)
+	}
+}
+
+func TestCountTablesCancel(t *testing.T) {
+	client, err := NewClient(context.Background(), &ConnectionConfig{Database: filepath.Join(t.TempDir(), "dev.sqlite3")}, "sqlite")
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := client.CountTables(ctx, []string{"a", "b", "c", "d"}, 2)
+	<-results
+	cancel()
+	// the channel still closes once the workers see the cancellation
+	for range results {
+	}
+}
+
+func TestSQLiteDSNEscapesPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a?b#c%d.db")
+	raw, err := sql.Open("sqlite", "file:"+url.PathEscape(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = raw.Exec("CREATE TABLE marker (id INTEGER)")
+	raw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, &ConnectionConfig{Database: path}, "sqlite")
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	defer client.Close()
+
+	tables, err := client.ListTables(ctx)
+	if err != nil {
+		t.Fatalf("ListTables() error: %v", err)
+	}
+	if len(tables) != 1 || tables[0].Name != "marker" {
+		t.Errorf("ListTables() = %+v, want the marker table from %s", tables, path)
+	}
+}
@@ -0,0 +1,202 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Kinds of prune, in the order a cleanup runs them. Containers go first so the
+// images and volumes only they used can be pruned after them.
+const (
+	PruneContainers = "containers"
+	PruneImages     = "images"
+	PruneVolumes    = "volumes"
+	PruneBuildCache = "build cache"
+)
+
+// PruneKinds lists the kinds of prune in the order they run.
+var PruneKinds = []string{PruneContainers, PruneImages, PruneVolumes, PruneBuildCache}
+
+// PruneItem is one object a prune deletes.
+type PruneItem struct {
+	Name string
+	Size int64
+}
+
+// PrunePlan lists what one kind of prune deletes and the space it frees.
+type PrunePlan struct {
+	Kind  string
+	Items []PruneItem
+	Size  int64
+}
+
+// Cleanup is a snapshot of docker's disk usage to plan prunes from.
+type Cleanup struct {
+	usage types.DiskUsage
+}
+
+// reads docker's disk usage for planning a cleanup.
+func (c *Client) LoadCleanup() (*Cleanup, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	usage, err := c.cli.DiskUsage(ctx, types.DiskUsageOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("disk usage: %w", err)
+	}
+	return &Cleanup{usage: usage}, nil
+}
+
+// Plan lists what each kind of prune deletes, in PruneKinds order. When
+// containers are selected, the images and volumes used only by stopped
+// containers are included, since those containers are removed first.
+func (c *Cleanup) Plan(selected map[string]bool) []PrunePlan {
+	pruned := make(map[string]bool)
+	plans := make(map[string]*PrunePlan)
+	for _, kind := range PruneKinds {
+		plans[kind] = &PrunePlan{Kind: kind}
+	}
+	add := func(kind, name string, size int64) {
+		p := plans[kind]
+		p.Items = append(p.Items, PruneItem{Name: name, Size: size})
+		if size > 0 {
+			p.Size += size
+		}
+	}
+
+	// users of images and volumes that a prune leaves in place
+	imageUsers := make(map[string]int)
+	volumeUsers := make(map[string]int)
+	for _, cnt := range c.usage.Containers {
+		if stopped(cnt.State) {
+			add(PruneContainers, containerName(cnt), cnt.SizeRw)
+			if selected[PruneContainers] {
+				pruned[cnt.ID] = true
+				continue
+			}
+		}
+		imageUsers[cnt.ImageID]++
+		for _, m := range cnt.Mounts {
+			if m.Type == "volume" {
+				volumeUsers[m.Name]++
+			}
+		}
+	}
+
+	for _, img := range c.usage.Images {
+		if dangling(img.RepoTags) && imageUsers[img.ID] == 0 {
+			add(PruneImages, shortID(img.ID), img.Size)
+		}
+	}
+
+	for _, v := range c.usage.Volumes {
+		if volumeUsers[v.Name] > 0 {
+			continue
+		}
+		size := int64(-1)
+		if v.UsageData != nil {
+			size = v.UsageData.Size
+		}
+		add(PruneVolumes, v.Name, size)
+	}
+
+	for _, bc := range c.usage.BuildCache {
+		if !prunableCache(bc) {
+			continue
+		}
+		name := bc.Description
+		if name == "" {
+			name = bc.Type + " " + shortID(bc.ID)
+		}
+		add(PruneBuildCache, name, bc.Size)
+	}
+
+	out := make([]PrunePlan, len(PruneKinds))
+	for i, kind := range PruneKinds {
+		p := plans[kind]
+		sort.SliceStable(p.Items, func(a, b int) bool { return p.Items[a].Size > p.Items[b].Size })
+		out[i] = *p
+	}
+	return out
+}
+
+// runs one kind of prune and returns the space reclaimed.
+func (c *Client) Prune(kind string) (uint64, error) {
+	switch kind {
+	case PruneImages:
+		return c.PruneDanglingImages()
+	case PruneVolumes:
+		return c.PruneVolumes()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	switch kind {
+	case PruneContainers:
+		report, err := c.cli.ContainersPrune(ctx, filters.NewArgs())
+		if err != nil {
+			return 0, fmt.Errorf("prune containers: %w", err)
+		}
+		return report.SpaceReclaimed, nil
+	case PruneBuildCache:
+		report, err := c.cli.BuildCachePrune(ctx, build.CachePruneOptions{})
+		if err != nil {
+			return 0, fmt.Errorf("prune build cache: %w", err)
+		}
+		return report.SpaceReclaimed, nil
+	}
+	return 0, fmt.Errorf("unknown prune %q", kind)
+}
+
+// reports whether a container in state is removed by a container prune.
+// Paused and restarting containers are kept.
+func stopped(state container.ContainerState) bool {
+	switch state {
+	case container.StateCreated, container.StateExited, container.StateDead:
+		return true
+	}
+	return false
+}
+
+// reports whether a build cache prune without All removes a record. BuildKit
+// keeps records in use, records shared with other records, and its internal
+// and frontend records.
+func prunableCache(bc *build.CacheRecord) bool {
+	if bc.InUse || bc.Shared {
+		return false
+	}
+	return bc.Type != "internal" && bc.Type != "frontend"
+}
+
+func dangling(tags []string) bool {
+	for _, tag := range tags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+func containerName(cnt *container.Summary) string {
+	if len(cnt.Names) > 0 {
+		return strings.TrimPrefix(cnt.Names[0], "/")
+	}
+	return shortID(cnt.ID)
+}
+
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,64 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+)
+
+func TestCleanupPlan(t *testing.T) {
+	cleanup := &Cleanup{usage: types.DiskUsage{
+		Containers: []*container.Summary{
+			{ID: "c1", Names: []string{"/web"}, State: container.StateRunning, ImageID: "sha256:app",
+				Mounts: []container.MountPoint{{Type: "volume", Name: "web_data"}}},
+			{ID: "c2", Names: []string{"/old-db"}, State: container.StateExited, ImageID: "sha256:olddb", SizeRw: 40,
+				Mounts: []container.MountPoint{{Type: "volume", Name: "old_pgdata"}, {Type: "bind", Name: ""}}},
+			{ID: "c3", Names: []string{"/paused"}, State: container.StatePaused, ImageID: "sha256:app"},
+		},
+		Images: []*image.Summary{
+			{ID: "sha256:app", RepoTags: []string{"app:dev"}, Size: 1000},
+			{ID: "sha256:olddb", RepoTags: []string{"<none>:<none>"}, Size: 300},
+			{ID: "sha256:orphan", Size: 200},
+		},
+		Volumes: []*volume.Volume{
+			{Name: "web_data", UsageData: &volume.UsageData{Size: 10}},
+			{Name: "old_pgdata", UsageData: &volume.UsageData{Size: 500}},
+			{Name: "nfs", UsageData: &volume.UsageData{Size: -1}},
+		},
+		BuildCache: []*build.CacheRecord{
+			{ID: "b1", Description: "RUN npm ci", Size: 700},
+			{ID: "b2", Type: "regular", Size: 50, Shared: true},
+			{ID: "b3", Description: "in use", Size: 90, InUse: true},
+			{ID: "b4", Type: "regular", Size: 30},
+			{ID: "b5", Type: "frontend", Size: 20},
+		},
+	}}
+
+	t.Run("containers kept", func(t *testing.T) {
+		plans := cleanup.Plan(map[string]bool{PruneImages: true})
+		want := []PrunePlan{
+			{Kind: PruneContainers, Items: []PruneItem{{"old-db", 40}}, Size: 40},
+			{Kind: PruneImages, Items: []PruneItem{{"orphan", 200}}, Size: 200},
+			{Kind: PruneVolumes, Items: []PruneItem{{"nfs", -1}}},
+			{Kind: PruneBuildCache, Items: []PruneItem{{"RUN npm ci", 700}, {"regular b4", 30}}, Size: 730},
+		}
+		if !reflect.DeepEqual(plans, want) {
+			t.Errorf("Plan() = %+v, want %+v", plans, want)
+		}
+	})
+
+	t.Run("containers pruned first", func(t *testing.T) {
+		plans := cleanup.Plan(map[string]bool{PruneContainers: true})
+		if got := plans[1].Items; !reflect.DeepEqual(got, []PruneItem{{"olddb", 300}, {"orphan", 200}}) {
+			t.Errorf("images = %+v, want the stopped container's image too", got)
+		}
+		if got := plans[2].Items; !reflect.DeepEqual(got, []PruneItem{{"old_pgdata", 500}, {"nfs", -1}}) {
+			t.Errorf("volumes = %+v, want the stopped container's volume too", got)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+)
+
+// returns the raw KEY=VALUE environment of a container.
+func (c *Client) GetEnv(containerID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect container: %w", err)
+	}
+	if inspect.Config == nil {
+		return nil, nil
+	}
+	return inspect.Config.Env, nil
+}
+
+// replaces a container with an identical one using a new environment.
+// The old container is renamed aside and only removed once the new one is running,
+// so a failed create or start puts the original back. Returns the new container ID,
+// and a warning when the new container runs but the old one could not be removed.
+func (c *Client) RecreateWithEnv(containerID string, envVars []string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", "", fmt.Errorf("inspect container: %w", err)
+	}
+	if inspect.Config == nil {
+		return "", "", fmt.Errorf("container %s has no config", containerID)
+	}
+
+	name := strings.TrimPrefix(inspect.Name, "/")
+	backupName := name + "-devhud-old"
+	wasRunning := inspect.State != nil && inspect.State.Running
+
+	config := *inspect.Config
+	config.Env = envVars
+
+	networking := &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{}}
+	if inspect.NetworkSettings != nil {
+		for netName, endpoint := range inspect.NetworkSettings.Networks {
+			networking.EndpointsConfig[netName] = &network.EndpointSettings{
+				IPAMConfig: endpoint.IPAMConfig,
+				Links:      endpoint.Links,
+				Aliases:    endpoint.Aliases,
+				DriverOpts: endpoint.DriverOpts,
+			}
+		}
+	}
+
+	if err := c.cli.ContainerRename(ctx, inspect.ID, backupName); err != nil {
+		return "", "", fmt.Errorf("rename container: %w", err)
+	}
+
+	// restore gets its own timeout: it runs after a step failed, often because
+	// ctx expired
+	restore := func() {
+		restoreCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = c.cli.ContainerRename(restoreCtx, inspect.ID, name)
+		if wasRunning {
+			_ = c.cli.ContainerStart(restoreCtx, inspect.ID, container.StartOptions{})
+		}
+	}
+
+	if wasRunning {
+		timeout := 10
+		if err := c.cli.ContainerStop(ctx, inspect.ID, container.StopOptions{Timeout: &timeout}); err != nil {
+			restore()
+			return "", "", fmt.Errorf("stop container: %w", err)
+		}
+	}
+
+	created, err := c.cli.ContainerCreate(ctx, &config, inspect.HostConfig, networking, nil, name)
+	if err != nil {
+		restore()
+		return "", "", fmt.Errorf("create container: %w", err)
+	}
+
+	if wasRunning {
+		if err := c.cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+			_ = c.cli.ContainerRemove(ctx, created.ID, container.RemoveOptions{Force: true})
+			restore()
+			return "", "", fmt.Errorf("start container: %w", err)
+		}
+	}
+
+	if err := c.cli.ContainerRemove(ctx, inspect.ID, container.RemoveOptions{}); err != nil {
+		return created.ID, fmt.Sprintf("old container left as %s: %v", backupName, err), nil
+	}
+
+	return created.ID, "", nil
+}
+
+// ProjectInfo describes where a container was launched from and when it last started.
+type ProjectInfo struct {
+	WorkingDir string
+	StartedAt  time.Time
+}
+
+// returns the compose working directory and start time of a container.
+func (c *Client) GetProjectInfo(containerID string) (*ProjectInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect container: %w", err)
+	}
+
+	info := &ProjectInfo{}
+	if inspect.Config != nil {
+		info.WorkingDir = inspect.Config.Labels["com.docker.compose.project.working_dir"]
+	}
+	if inspect.State != nil {
+		if started, err := time.Parse(time.RFC3339Nano, inspect.State.StartedAt); err == nil {
+			info.StartedAt = started
+		}
+	}
+	return info, nil
+}
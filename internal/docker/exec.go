@@ -0,0 +1,186 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ExecOptions describes a command run inside a container.
+type ExecOptions struct {
+	Cmd        []string
+	Env        []string
+	User       string
+	WorkingDir string
+}
+
+// runs a command in a container without a TTY and waits for it to exit. stdin
+// may be nil. Output is streamed to stdout and stderr as it arrives. It returns
+// the command's exit code.
+func (c *Client) Exec(ctx context.Context, containerID string, opts ExecOptions, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	created, err := c.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          opts.Cmd,
+		Env:          opts.Env,
+		User:         opts.User,
+		WorkingDir:   opts.WorkingDir,
+		AttachStdin:  stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return -1, fmt.Errorf("exec create: %w", err)
+	}
+
+	resp, err := c.cli.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return -1, fmt.Errorf("exec attach: %w", err)
+	}
+	defer resp.Close()
+
+	// the hijacked connection ignores ctx, so close it to stop a cancelled command
+	stop := context.AfterFunc(ctx, resp.Close)
+	defer stop()
+
+	inputDone := make(chan error, 1)
+	if stdin != nil {
+		go func() {
+			_, err := io.Copy(resp.Conn, stdin)
+			if closeErr := resp.CloseWrite(); err == nil {
+				err = closeErr
+			}
+			inputDone <- err
+		}()
+	} else {
+		inputDone <- nil
+	}
+
+	if _, err := stdcopy.StdCopy(stdout, stderr, resp.Reader); err != nil {
+		if ctx.Err() != nil {
+			return -1, ctx.Err()
+		}
+		return -1, fmt.Errorf("exec output: %w", err)
+	}
+	// a command that exits without reading all of stdin reports that in its
+	// exit code; otherwise a failed copy means it saw truncated input
+	select {
+	case err := <-inputDone:
+		if err != nil {
+			return -1, fmt.Errorf("exec input: %w", err)
+		}
+	default:
+		resp.Close()
+	}
+
+	return c.execExitCode(ctx, created.ID)
+}
+
+// waits for an exec to finish and returns its exit code. The output closes as
+// the process exits, but its exit code can lag behind.
+func (c *Client) execExitCode(ctx context.Context, execID string) (int, error) {
+	for {
+		inspect, err := c.cli.ContainerExecInspect(ctx, execID)
+		if err != nil {
+			return -1, fmt.Errorf("exec inspect: %w", err)
+		}
+		if !inspect.Running {
+			return inspect.ExitCode, nil
+		}
+		select {
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// shells to look for, best first
+var shellPaths = []string{"/bin/bash", "/usr/bin/bash", "/bin/zsh", "/usr/bin/zsh", "/bin/ash", "/bin/sh", "/usr/bin/sh"}
+
+// returns the path of the best shell in a container: bash, zsh, ash or sh.
+// It looks at the filesystem instead of running a command, so it works in
+// images without /bin/sh.
+func (c *Client) DetectShell(ctx context.Context, containerID string) (string, error) {
+	for _, path := range shellPaths {
+		if _, err := c.cli.ContainerStatPath(ctx, containerID, path); err == nil {
+			return path, nil
+		} else if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+	}
+	return "", fmt.Errorf("no shell found in container (looked for bash, zsh, ash and sh)")
+}
+
+// returns the user and working directory commands run with in a container
+// when an exec does not set them.
+func (c *Client) ExecDefaults(ctx context.Context, containerID string) (ExecOptions, error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return ExecOptions{}, fmt.Errorf("inspect container: %w", err)
+	}
+	var opts ExecOptions
+	if inspect.Config != nil {
+		opts.User = inspect.Config.User
+		opts.WorkingDir = inspect.Config.WorkingDir
+	}
+	return opts, nil
+}
+
+// TTY is an interactive command running in a container on a pseudo-terminal.
+// Writes go to the command's input.
+type TTY struct {
+	c    *Client
+	id   string
+	resp types.HijackedResponse
+}
+
+// starts an interactive command on a pseudo-terminal of the given size.
+func (c *Client) StartTTY(ctx context.Context, containerID string, opts ExecOptions, width, height uint) (*TTY, error) {
+	size := &[2]uint{height, width}
+	created, err := c.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          opts.Cmd,
+		Env:          opts.Env,
+		User:         opts.User,
+		WorkingDir:   opts.WorkingDir,
+		Tty:          true,
+		ConsoleSize:  size,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("exec create: %w", err)
+	}
+
+	resp, err := c.cli.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{Tty: true, ConsoleSize: size})
+	if err != nil {
+		return nil, fmt.Errorf("exec attach: %w", err)
+	}
+	return &TTY{c: c, id: created.ID, resp: resp}, nil
+}
+
+func (t *TTY) Write(p []byte) (int, error) {
+	return t.resp.Conn.Write(p)
+}
+
+// resizes the command's terminal.
+func (t *TTY) Resize(ctx context.Context, width, height uint) error {
+	return t.c.cli.ContainerExecResize(ctx, t.id, container.ResizeOptions{Width: width, Height: height})
+}
+
+// copies the command's output to w until it exits and returns its exit code.
+func (t *TTY) Wait(ctx context.Context, w io.Writer) (int, error) {
+	if _, err := io.Copy(w, t.resp.Reader); err != nil {
+		return -1, fmt.Errorf("exec output: %w", err)
+	}
+	return t.c.execExitCode(ctx, t.id)
+}
+
+// closes the connection to the command.
+func (t *TTY) Close() {
+	t.resp.Close()
+}
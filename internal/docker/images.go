@@ -0,0 +1,160 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+)
+
+// Image is a local image and the containers created from it.
+type Image struct {
+	ID string
+	// Tags holds the image's repo:tag names; a dangling image has none.
+	Tags    []string
+	Size    int64
+	Created time.Time
+	// Containers names the containers, running or not, using the image.
+	Containers []string
+}
+
+// Dangling reports whether the image has no tag, usually because a newer
+// build or pull took its tag.
+func (i Image) Dangling() bool {
+	return len(i.Tags) == 0
+}
+
+// lists local images, newest first.
+func (c *Client) ListImages() ([]Image, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	summaries, err := c.cli.ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("image list: %w", err)
+	}
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("container list: %w", err)
+	}
+
+	users := make(map[string][]string)
+	for _, cnt := range containers {
+		name := cnt.ID
+		if len(cnt.Names) > 0 {
+			name = strings.TrimPrefix(cnt.Names[0], "/")
+		}
+		users[cnt.ImageID] = append(users[cnt.ImageID], name)
+	}
+
+	images := make([]Image, 0, len(summaries))
+	for _, s := range summaries {
+		var tags []string
+		for _, tag := range s.RepoTags {
+			if tag != "<none>:<none>" {
+				tags = append(tags, tag)
+			}
+		}
+		sort.Strings(tags)
+		sort.Strings(users[s.ID])
+		images = append(images, Image{
+			ID:         s.ID,
+			Tags:       tags,
+			Size:       s.Size,
+			Created:    time.Unix(s.Created, 0),
+			Containers: users[s.ID],
+		})
+	}
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].Created.After(images[j].Created)
+	})
+	return images, nil
+}
+
+// pulls an image by reference, such as "postgres:16", and returns docker's
+// final status line, e.g. "Image is up to date for postgres:16".
+func (c *Client) PullImage(ref string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	reader, err := c.cli.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		return "", fmt.Errorf("pull image: %w", err)
+	}
+	defer reader.Close()
+
+	status, err := readPullStatus(reader)
+	if err != nil {
+		return "", fmt.Errorf("pull image: %w", err)
+	}
+	return status, nil
+}
+
+// pullMessage is one line of the JSON progress stream of a pull.
+type pullMessage struct {
+	// ID is set on progress for a single layer.
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	ErrorDetail *struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+// reads a pull's progress stream to the end. It returns the last status that
+// is not about a layer or the digest, or the error the daemon reported
+// mid-stream.
+func readPullStatus(r io.Reader) (string, error) {
+	var status string
+	dec := json.NewDecoder(r)
+	for {
+		var msg pullMessage
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return strings.TrimPrefix(status, "Status: "), nil
+			}
+			return "", fmt.Errorf("read progress: %w", err)
+		}
+		if msg.ErrorDetail != nil && msg.ErrorDetail.Message != "" {
+			return "", errors.New(msg.ErrorDetail.Message)
+		}
+		if msg.Error != "" {
+			return "", errors.New(msg.Error)
+		}
+		if msg.ID == "" && msg.Status != "" && !strings.HasPrefix(msg.Status, "Digest: ") {
+			status = msg.Status
+		}
+	}
+}
+
+// removes an image by tag or ID. Removing one of several tags only untags
+// the image. Images used by a container are refused.
+func (c *Client) RemoveImage(ref string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := c.cli.ImageRemove(ctx, ref, image.RemoveOptions{PruneChildren: true}); err != nil {
+		return fmt.Errorf("remove image: %w", err)
+	}
+	return nil
+}
+
+// removes dangling images no container uses and returns the space reclaimed.
+func (c *Client) PruneDanglingImages() (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	report, err := c.cli.ImagesPrune(ctx, filters.NewArgs(filters.Arg("dangling", "true")))
+	if err != nil {
+		return 0, fmt.Errorf("prune images: %w", err)
+	}
+	return report.SpaceReclaimed, nil
+}
@@ -0,0 +1,63 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadPullStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "downloaded",
+			stream: `{"status":"Pulling from library/redis","id":"7"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"a2abf6c4d29d"}
+{"status":"Downloading","progressDetail":{"current":1024,"total":2048},"id":"a2abf6c4d29d"}
+{"status":"Pull complete","progressDetail":{},"id":"a2abf6c4d29d"}
+{"status":"Digest: sha256:0123"}
+{"status":"Status: Downloaded newer image for redis:7"}
+`,
+			want: "Downloaded newer image for redis:7",
+		},
+		{
+			name: "up to date",
+			stream: `{"status":"Pulling from library/redis","id":"7"}
+{"status":"Digest: sha256:0123"}
+{"status":"Status: Image is up to date for redis:7"}`,
+			want: "Image is up to date for redis:7",
+		},
+		{
+			name: "error mid-stream",
+			stream: `{"status":"Pulling from library/redis","id":"7"}
+{"errorDetail":{"message":"unauthorized: authentication required"},"error":"unauthorized: authentication required"}`,
+			wantErr: "unauthorized: authentication required",
+		},
+		{
+			name:    "truncated",
+			stream:  `{"status":"Pulling`,
+			wantErr: "read progress",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readPullStatus(strings.NewReader(tt.stream))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readPullStatus() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readPullStatus() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readPullStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
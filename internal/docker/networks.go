@@ -0,0 +1,100 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+// Network is a network and the containers connected to it.
+type Network struct {
+	ID      string
+	Name    string
+	Driver  string
+	Scope   string
+	Subnets []string
+	// Endpoints are the connected containers, sorted by name.
+	Endpoints []Endpoint
+	// InspectErr is set when the connected containers could not be read. The
+	// network is then listed from the summary alone.
+	InspectErr error
+}
+
+// Endpoint is a container's connection to a network.
+type Endpoint struct {
+	Container string
+	IPv4      string
+	IPv6      string
+}
+
+// lists networks with their subnets and connected containers, by name.
+func (c *Client) ListNetworks() ([]Network, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	summaries, err := c.cli.NetworkList(ctx, network.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("network list: %w", err)
+	}
+
+	networks := make([]Network, 0, len(summaries))
+	for _, s := range summaries {
+		// the list leaves out connected containers
+		inspect, err := c.cli.NetworkInspect(ctx, s.ID, network.InspectOptions{})
+		if err != nil {
+			// a network removed since the list, or one the daemon fails on,
+			// should not hide the rest
+			n := toNetwork(s)
+			n.InspectErr = fmt.Errorf("inspect network %s: %w", s.Name, err)
+			networks = append(networks, n)
+			continue
+		}
+		networks = append(networks, toNetwork(inspect))
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+	return networks, nil
+}
+
+func toNetwork(inspect network.Inspect) Network {
+	n := Network{
+		ID:     inspect.ID,
+		Name:   inspect.Name,
+		Driver: inspect.Driver,
+		Scope:  inspect.Scope,
+	}
+	for _, cfg := range inspect.IPAM.Config {
+		if cfg.Subnet != "" {
+			n.Subnets = append(n.Subnets, cfg.Subnet)
+		}
+	}
+	for id, ep := range inspect.Containers {
+		name := ep.Name
+		if name == "" {
+			name = id
+		}
+		n.Endpoints = append(n.Endpoints, Endpoint{
+			Container: name,
+			IPv4:      stripPrefixLength(ep.IPv4Address),
+			IPv6:      stripPrefixLength(ep.IPv6Address),
+		})
+	}
+	sort.Slice(n.Endpoints, func(i, j int) bool {
+		return n.Endpoints[i].Container < n.Endpoints[j].Container
+	})
+	return n
+}
+
+// drops the prefix length docker appends to endpoint addresses, as in
+// "172.18.0.2/16".
+func stripPrefixLength(addr string) string {
+	if i := strings.IndexByte(addr, '/'); i >= 0 {
+		return addr[:i]
+	}
+	return addr
+}
@@ -0,0 +1,42 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestToNetwork(t *testing.T) {
+	inspect := network.Inspect{
+		ID:     "abc",
+		Name:   "shop_default",
+		Driver: "bridge",
+		Scope:  "local",
+		IPAM: network.IPAM{Config: []network.IPAMConfig{
+			{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"},
+			{Subnet: "fd00:18::/64"},
+		}},
+		Containers: map[string]network.EndpointResource{
+			"2": {Name: "shop-web-1", IPv4Address: "172.18.0.3/16"},
+			"1": {Name: "shop-db-1", IPv4Address: "172.18.0.2/16", IPv6Address: "fd00:18::2/64"},
+			"3": {IPv4Address: ""},
+		},
+	}
+
+	want := Network{
+		ID:      "abc",
+		Name:    "shop_default",
+		Driver:  "bridge",
+		Scope:   "local",
+		Subnets: []string{"172.18.0.0/16", "fd00:18::/64"},
+		Endpoints: []Endpoint{
+			{Container: "3"},
+			{Container: "shop-db-1", IPv4: "172.18.0.2", IPv6: "fd00:18::2"},
+			{Container: "shop-web-1", IPv4: "172.18.0.3"},
+		},
+	}
+	if got := toNetwork(inspect); !reflect.DeepEqual(got, want) {
+		t.Errorf("toNetwork() = %+v, want %+v", got, want)
+	}
+}
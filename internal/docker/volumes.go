@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/versions"
+)
+
+// Volume is a volume and the containers mounting it.
+type Volume struct {
+	Name       string
+	Driver     string
+	Mountpoint string
+	Created    time.Time
+	// Size is the space the volume's data takes, or -1 when the driver does
+	// not report it.
+	Size int64
+	// Containers names the containers, running or not, mounting the volume.
+	Containers []string
+}
+
+// Unused reports whether no container mounts the volume, so a prune deletes it.
+func (v Volume) Unused() bool {
+	return len(v.Containers) == 0
+}
+
+// lists volumes with their sizes, largest first.
+func (c *Client) ListVolumes() ([]Volume, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	usage, err := c.cli.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		return nil, fmt.Errorf("disk usage: %w", err)
+	}
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("container list: %w", err)
+	}
+
+	users := make(map[string][]string)
+	for _, cnt := range containers {
+		name := cnt.ID
+		if len(cnt.Names) > 0 {
+			name = strings.TrimPrefix(cnt.Names[0], "/")
+		}
+		for _, m := range cnt.Mounts {
+			if m.Type == "volume" {
+				users[m.Name] = append(users[m.Name], name)
+			}
+		}
+	}
+
+	volumes := make([]Volume, 0, len(usage.Volumes))
+	for _, v := range usage.Volumes {
+		size := int64(-1)
+		if v.UsageData != nil {
+			size = v.UsageData.Size
+		}
+		created, _ := time.Parse(time.RFC3339, v.CreatedAt)
+		sort.Strings(users[v.Name])
+		volumes = append(volumes, Volume{
+			Name:       v.Name,
+			Driver:     v.Driver,
+			Mountpoint: v.Mountpoint,
+			Created:    created,
+			Size:       size,
+			Containers: users[v.Name],
+		})
+	}
+	sort.SliceStable(volumes, func(i, j int) bool {
+		if volumes[i].Size != volumes[j].Size {
+			return volumes[i].Size > volumes[j].Size
+		}
+		return volumes[i].Name < volumes[j].Name
+	})
+	return volumes, nil
+}
+
+// removes a volume and its data. Volumes mounted by a container are refused.
+func (c *Client) RemoveVolume(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := c.cli.VolumeRemove(ctx, name, false); err != nil {
+		return fmt.Errorf("remove volume: %w", err)
+	}
+	return nil
+}
+
+// removes every volume no container mounts, named or anonymous, and returns
+// the space reclaimed.
+func (c *Client) PruneVolumes() (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	// API 1.42 made prune skip named volumes unless all=true, and older
+	// daemons reject the filter but prune named volumes anyway
+	c.cli.NegotiateAPIVersion(ctx)
+	args := filters.NewArgs()
+	if !versions.LessThan(c.cli.ClientVersion(), "1.42") {
+		args.Add("all", "true")
+	}
+	report, err := c.cli.VolumesPrune(ctx, args)
+	if err != nil {
+		return 0, fmt.Errorf("prune volumes: %w", err)
+	}
+	return report.SpaceReclaimed, nil
+}
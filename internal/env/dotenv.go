@@ -0,0 +1,216 @@
+package env
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DotenvFiles lists the files read from a project directory, in override order.
+// .env.example documents the expected keys and is never treated as a value source.
+var DotenvFiles = []string{".env", ".env.local"}
+
+const exampleFile = ".env.example"
+
+// ParseDotenv reads KEY=VALUE lines in .env syntax: comments, blank lines,
+// an optional "export" prefix, single and double quoted values, and trailing comments.
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		parsed, err := parseDotenvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		vars[key] = parsed
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read dotenv: %w", err)
+	}
+	return vars, nil
+}
+
+func parseDotenvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch value[0] {
+	case '\'':
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", errors.New("unterminated single quote")
+		}
+		return value[1 : end+1], nil
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			ch := value[i]
+			if ch == '"' {
+				return b.String(), nil
+			}
+			if ch == '\\' && i+1 < len(value) {
+				i++
+				switch value[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(value[i])
+				}
+				continue
+			}
+			b.WriteByte(ch)
+		}
+		return "", errors.New("unterminated double quote")
+	}
+
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// DotenvFile is one parsed .env-style file.
+type DotenvFile struct {
+	Name    string
+	Path    string
+	Vars    map[string]string
+	ModTime time.Time
+}
+
+// Project holds the .env files found in a directory.
+type Project struct {
+	Dir     string
+	Files   []DotenvFile
+	Example *DotenvFile
+}
+
+// LoadProject reads .env, .env.local and .env.example from dir. Missing files are skipped.
+func LoadProject(dir string) (*Project, error) {
+	project := &Project{Dir: dir}
+
+	for _, name := range append(append([]string{}, DotenvFiles...), exampleFile) {
+		file, err := loadDotenvFile(filepath.Join(dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if name == exampleFile {
+			project.Example = file
+		} else {
+			project.Files = append(project.Files, *file)
+		}
+	}
+
+	return project, nil
+}
+
+func loadDotenvFile(path string) (*DotenvFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	vars, err := ParseDotenv(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+
+	return &DotenvFile{
+		Name:    filepath.Base(path),
+		Path:    path,
+		Vars:    vars,
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+// Values merges the project's value files, later files overriding earlier ones.
+func (p *Project) Values() map[string]string {
+	merged := make(map[string]string)
+	for _, file := range p.Files {
+		for k, v := range file.Vars {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// LastModified returns the newest modification time across the value files.
+func (p *Project) LastModified() time.Time {
+	var latest time.Time
+	for _, file := range p.Files {
+		if file.ModTime.After(latest) {
+			latest = file.ModTime
+		}
+	}
+	return latest
+}
+
+// DriftReport compares a project's .env files with a running environment.
+type DriftReport struct {
+	Project *Project
+	// Missing lists keys documented in .env.example but set in no value file.
+	Missing []string
+	// Drift lists keys whose file value differs from, or is absent in, the running environment.
+	Drift []DiffEntry
+	// Stale is set when a value file changed after the service started.
+	Stale bool
+}
+
+// Compare builds a drift report; startedAt may be zero when the start time is unknown.
+func Compare(project *Project, running map[string]string, startedAt time.Time) *DriftReport {
+	report := &DriftReport{Project: project}
+	values := project.Values()
+
+	if project.Example != nil {
+		for key := range project.Example.Vars {
+			if _, ok := values[key]; !ok {
+				report.Missing = append(report.Missing, key)
+			}
+		}
+		sort.Strings(report.Missing)
+	}
+
+	for _, entry := range Diff(values, running) {
+		if entry.Status == DiffChanged || entry.Status == DiffLeftOnly {
+			report.Drift = append(report.Drift, entry)
+		}
+	}
+
+	if !startedAt.IsZero() && len(report.Drift) > 0 && project.LastModified().After(startedAt) {
+		report.Stale = true
+	}
+
+	return report
+}
@@ -0,0 +1,126 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDotenv(t *testing.T) {
+	input := `
+# database settings
+DB_HOST=localhost
+export DB_PORT=5432
+DB_NAME = app # trailing comment
+SINGLE='keep # this \n raw'
+DOUBLE="line1\nline2 \"quoted\""
+HASH=abc#def
+EMPTY=
+`
+	got, err := ParseDotenv(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDotenv() error: %v", err)
+	}
+
+	want := map[string]string{
+		"DB_HOST": "localhost",
+		"DB_PORT": "5432",
+		"DB_NAME": "app",
+		"SINGLE":  "keep # this \\n raw",
+		"DOUBLE":  "line1\nline2 \"quoted\"",
+		"HASH":    "abc#def",
+		"EMPTY":   "",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseDotenv() returned %d keys, want %d: %v", len(got), len(want), got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("ParseDotenv()[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "missing equals", input: "JUSTAKEY"},
+		{name: "space in key", input: "MY KEY=value"},
+		{name: "unterminated double quote", input: `KEY="value`},
+		{name: "unterminated single quote", input: `KEY='value`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseDotenv(strings.NewReader(tt.input)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadProjectAndCompare(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".env", "API_URL=http://localhost:3000\nLOG_LEVEL=info\n")
+	write(".env.local", "LOG_LEVEL=debug\nFEATURE_X=on\n")
+	write(".env.example", "API_URL=\nLOG_LEVEL=\nSTRIPE_KEY=\n")
+
+	project, err := LoadProject(dir)
+	if err != nil {
+		t.Fatalf("LoadProject() error: %v", err)
+	}
+	if len(project.Files) != 2 || project.Example == nil {
+		t.Fatalf("LoadProject() found %d files, example=%v", len(project.Files), project.Example != nil)
+	}
+	if got := project.Values()["LOG_LEVEL"]; got != "debug" {
+		t.Errorf(".env.local should override .env, LOG_LEVEL = %q", got)
+	}
+
+	running := map[string]string{
+		"API_URL":   "http://localhost:3000",
+		"LOG_LEVEL": "info",
+		"PATH":      "/usr/bin",
+	}
+
+	report := Compare(project, running, time.Now().Add(-time.Hour))
+	if len(report.Missing) != 1 || report.Missing[0] != "STRIPE_KEY" {
+		t.Errorf("Missing = %v, want [STRIPE_KEY]", report.Missing)
+	}
+	if len(report.Drift) != 2 {
+		t.Fatalf("Drift = %+v, want LOG_LEVEL and FEATURE_X", report.Drift)
+	}
+	if report.Drift[0].Key != "FEATURE_X" || report.Drift[0].Status != DiffLeftOnly {
+		t.Errorf("Drift[0] = %+v, want FEATURE_X left-only", report.Drift[0])
+	}
+	if report.Drift[1].Key != "LOG_LEVEL" || report.Drift[1].Status != DiffChanged {
+		t.Errorf("Drift[1] = %+v, want LOG_LEVEL changed", report.Drift[1])
+	}
+	if !report.Stale {
+		t.Error("expected Stale when files changed after start")
+	}
+
+	report = Compare(project, running, time.Now().Add(time.Hour))
+	if report.Stale {
+		t.Error("expected not Stale when service started after the last edit")
+	}
+}
+
+func TestLoadProjectEmptyDir(t *testing.T) {
+	project, err := LoadProject(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadProject() error: %v", err)
+	}
+	if len(project.Files) != 0 || project.Example != nil {
+		t.Errorf("expected no files, got %+v", project)
+	}
+}
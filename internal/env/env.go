@@ -0,0 +1,139 @@
+package env
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// secretMarkers are key substrings that usually indicate a credential.
+var secretMarkers = []string{
+	"PASSWORD", "PASSWD", "SECRET", "TOKEN", "API_KEY", "APIKEY",
+	"PRIVATE", "CREDENTIAL", "AUTH", "ACCESS_KEY", "DSN",
+}
+
+// Parse splits KEY=VALUE entries, as returned by docker inspect or /proc/<pid>/environ, into a map.
+// Entries without '=' are ignored.
+func Parse(entries []string) map[string]string {
+	vars := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) == 2 && parts[0] != "" {
+			vars[parts[0]] = parts[1]
+		}
+	}
+	return vars
+}
+
+// Format converts a map back into sorted KEY=VALUE entries.
+func Format(vars map[string]string) []string {
+	entries := make([]string, 0, len(vars))
+	for _, key := range Keys(vars) {
+		entries = append(entries, key+"="+vars[key])
+	}
+	return entries
+}
+
+// Keys returns the map keys in sorted order.
+func Keys(vars map[string]string) []string {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ReadProcess returns the environment of a running process from /proc/<pid>/environ.
+func ReadProcess(pid int) (map[string]string, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/environ", pid))
+	if err != nil {
+		return nil, fmt.Errorf("read environ: %w", err)
+	}
+
+	var entries []string
+	for _, chunk := range bytes.Split(data, []byte{0}) {
+		if len(chunk) > 0 {
+			entries = append(entries, string(chunk))
+		}
+	}
+	return Parse(entries), nil
+}
+
+// IsSecret reports whether a variable name looks like it holds a credential.
+func IsSecret(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, marker := range secretMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask hides a value while keeping a hint of its length.
+func Mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	n := len(value)
+	if n > 12 {
+		n = 12
+	}
+	return strings.Repeat("•", n)
+}
+
+// DiffStatus describes how a key differs between two environments.
+type DiffStatus string
+
+const (
+	DiffSame      DiffStatus = "same"
+	DiffChanged   DiffStatus = "changed"
+	DiffLeftOnly  DiffStatus = "left-only"
+	DiffRightOnly DiffStatus = "right-only"
+)
+
+// DiffEntry is one key in a comparison between two environments.
+type DiffEntry struct {
+	Key    string
+	Left   string
+	Right  string
+	Status DiffStatus
+}
+
+// Diff compares two environments key by key, sorted by key.
+func Diff(left, right map[string]string) []DiffEntry {
+	seen := make(map[string]bool)
+	var keys []string
+	for key := range left {
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	for key := range right {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	entries := make([]DiffEntry, 0, len(keys))
+	for _, key := range keys {
+		l, inLeft := left[key]
+		r, inRight := right[key]
+		entry := DiffEntry{Key: key, Left: l, Right: r}
+		switch {
+		case inLeft && !inRight:
+			entry.Status = DiffLeftOnly
+		case !inLeft && inRight:
+			entry.Status = DiffRightOnly
+		case l != r:
+			entry.Status = DiffChanged
+		default:
+			entry.Status = DiffSame
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,82 @@
+package env
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	got := Parse([]string{"A=1", "B=x=y", "EMPTY=", "NOEQUALS", "=orphan"})
+
+	want := map[string]string{"A": "1", "B": "x=y", "EMPTY": ""}
+	if len(got) != len(want) {
+		t.Fatalf("Parse() returned %d entries, want %d: %v", len(got), len(want), got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Parse()[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	got := Format(map[string]string{"B": "2", "A": "1"})
+	want := []string{"A=1", "B=2"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Format() = %v, want %v", got, want)
+	}
+}
+
+func TestIsSecret(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"POSTGRES_PASSWORD", true},
+		{"stripe_secret_key", true},
+		{"GITHUB_TOKEN", true},
+		{"DATABASE_DSN", true},
+		{"PATH", false},
+		{"NODE_ENV", false},
+		{"PORT", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := IsSecret(tt.key); got != tt.want {
+				t.Errorf("IsSecret(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMask(t *testing.T) {
+	if got := Mask(""); got != "" {
+		t.Errorf("Mask(\"\") = %q, want empty", got)
+	}
+	if got := Mask("abc"); got != "•••" {
+		t.Errorf("Mask(\"abc\") = %q, want %q", got, "•••")
+	}
+	if got := Mask("a-very-long-secret-value"); got != "••••••••••••" {
+		t.Errorf("Mask(long) = %q, want 12 bullets", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	left := map[string]string{"A": "1", "B": "2", "C": "3"}
+	right := map[string]string{"A": "1", "B": "20", "D": "4"}
+
+	got := Diff(left, right)
+	want := []DiffEntry{
+		{Key: "A", Left: "1", Right: "1", Status: DiffSame},
+		{Key: "B", Left: "2", Right: "20", Status: DiffChanged},
+		{Key: "C", Left: "3", Status: DiffLeftOnly},
+		{Key: "D", Right: "4", Status: DiffRightOnly},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Diff() returned %d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Diff()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// History is a list of entries persisted to a JSON file, oldest first.
+type History struct {
+	path    string
+	limit   int
+	entries []string
+}
+
+// Load reads a history file, keeping at most limit entries. A missing file
+// yields an empty history that is created on the first Add.
+func Load(path string, limit int) (*History, error) {
+	h := &History{path: path, limit: limit}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, fmt.Errorf("read history: %w", err)
+	}
+	if err := json.Unmarshal(data, &h.entries); err != nil {
+		return h, fmt.Errorf("parse %s: %w", path, err)
+	}
+	h.trim()
+	return h, nil
+}
+
+// Entries returns the entries, oldest first.
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Add appends an entry and saves the file. Repeating an earlier entry moves it to the end.
+func (h *History) Add(entry string) error {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return nil
+	}
+	for i, e := range h.entries {
+		if e == entry {
+			h.entries = append(h.entries[:i], h.entries[i+1:]...)
+			break
+		}
+	}
+	h.entries = append(h.entries, entry)
+	h.trim()
+	return h.save()
+}
+
+func (h *History) trim() {
+	if h.limit > 0 && len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+}
+
+func (h *History) save() error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+	data, err := json.MarshalIndent(h.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode history: %w", err)
+	}
+	// queries can contain credentials or customer data, so keep the file private
+	if err := os.WriteFile(h.path, data, 0o600); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// FileName turns a database identifier into a safe file name.
+func FileName(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return strings.Trim(b.String(), "_.") + ".json"
+}
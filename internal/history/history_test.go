@@ -0,0 +1,53 @@
+package history
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHistoryPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "pg.json")
+
+	h, err := Load(path, 3)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	for _, q := range []string{"SELECT 1", "SELECT 2", "  ", "SELECT 1", "SELECT 3", "SELECT 4"} {
+		if err := h.Add(q); err != nil {
+			t.Fatalf("Add(%q) error: %v", q, err)
+		}
+	}
+
+	want := []string{"SELECT 1", "SELECT 3", "SELECT 4"}
+	if !reflect.DeepEqual(h.Entries(), want) {
+		t.Errorf("Entries() = %v, want %v", h.Entries(), want)
+	}
+
+	reloaded, err := Load(path, 2)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if want := []string{"SELECT 3", "SELECT 4"}; !reflect.DeepEqual(reloaded.Entries(), want) {
+		t.Errorf("reloaded Entries() = %v, want %v", reloaded.Entries(), want)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"postgres-api_db_1", "postgres-api_db_1.json"},
+		{"sqlite-/home/dev/app/dev.sqlite3", "sqlite-_home_dev_app_dev.sqlite3.json"},
+		{"../../etc/passwd", "etc_passwd.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := FileName(tt.key); got != tt.want {
+				t.Errorf("FileName(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/eanda22/devhud/internal/service"
+)
+
+// Point is a single timestamped sample.
+type Point struct {
+	At    time.Time
+	Value float64
+}
+
+// Transition records a status change observed between two scans.
+type Transition struct {
+	At   time.Time
+	From service.Status
+	To   service.Status
+}
+
+// Series is the bounded history of one service.
+type Series struct {
+	CPU         *Ring[Point]
+	Memory      *Ring[Point]
+	Restarts    *Ring[Point]
+	Transitions *Ring[Transition]
+	LastStatus  service.Status
+	LastSeen    time.Time
+}
+
+// Values returns the sample values of a ring of points, oldest first.
+func Values(r *Ring[Point]) []float64 {
+	points := r.Items()
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// History keeps a Series per service ID across scans.
+type History struct {
+	capacity  int
+	retention time.Duration
+	series    map[string]*Series
+}
+
+// NewHistory keeps up to capacity samples per service and forgets services
+// that have not been seen for the retention period.
+func NewHistory(capacity int, retention time.Duration) *History {
+	return &History{
+		capacity:  capacity,
+		retention: retention,
+		series:    make(map[string]*Series),
+	}
+}
+
+// Record appends one scan's worth of samples.
+func (h *History) Record(at time.Time, services []*service.Service) {
+	for _, svc := range services {
+		s, ok := h.series[svc.ID]
+		if !ok {
+			s = &Series{
+				CPU:         NewRing[Point](h.capacity),
+				Memory:      NewRing[Point](h.capacity),
+				Restarts:    NewRing[Point](h.capacity),
+				Transitions: NewRing[Transition](h.capacity),
+				LastStatus:  svc.Status,
+			}
+			h.series[svc.ID] = s
+		}
+
+		if svc.Resources != nil {
+			s.CPU.Add(Point{At: at, Value: svc.Resources.CPUPercent})
+			s.Memory.Add(Point{At: at, Value: float64(svc.Resources.MemUsage)})
+		}
+		s.Restarts.Add(Point{At: at, Value: float64(svc.RestartCount)})
+
+		if svc.Status != s.LastStatus {
+			s.Transitions.Add(Transition{At: at, From: s.LastStatus, To: svc.Status})
+			s.LastStatus = svc.Status
+		}
+		s.LastSeen = at
+	}
+
+	for id, s := range h.series {
+		if at.Sub(s.LastSeen) > h.retention {
+			delete(h.series, id)
+		}
+	}
+}
+
+// Get returns the series for a service ID, or nil if none is recorded.
+func (h *History) Get(id string) *Series {
+	return h.series[id]
+}
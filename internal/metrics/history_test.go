@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eanda22/devhud/internal/service"
+)
+
+func TestHistoryRecord(t *testing.T) {
+	h := NewHistory(10, time.Minute)
+	start := time.Now()
+
+	api := &service.Service{
+		ID:        "api",
+		Status:    service.StatusRunning,
+		Resources: &service.Resources{CPUPercent: 10, MemUsage: 100},
+	}
+	h.Record(start, []*service.Service{api})
+
+	api = &service.Service{
+		ID:           "api",
+		Status:       service.StatusStopped,
+		RestartCount: 2,
+	}
+	h.Record(start.Add(2*time.Second), []*service.Service{api})
+
+	s := h.Get("api")
+	if s == nil {
+		t.Fatal("expected series for api")
+	}
+	if s.CPU.Len() != 1 || s.Memory.Len() != 1 {
+		t.Errorf("CPU/Memory samples = %d/%d, want 1/1 (stopped scans carry no resources)", s.CPU.Len(), s.Memory.Len())
+	}
+	if got := Values(s.Restarts); len(got) != 2 || got[1] != 2 {
+		t.Errorf("Restarts = %v, want [0 2]", got)
+	}
+	transitions := s.Transitions.Items()
+	if len(transitions) != 1 || transitions[0].From != service.StatusRunning || transitions[0].To != service.StatusStopped {
+		t.Errorf("Transitions = %+v, want running → stopped", transitions)
+	}
+}
+
+func TestHistoryRetention(t *testing.T) {
+	h := NewHistory(10, time.Minute)
+	start := time.Now()
+
+	h.Record(start, []*service.Service{{ID: "old"}, {ID: "kept"}})
+	h.Record(start.Add(30*time.Second), []*service.Service{{ID: "kept"}})
+	if h.Get("old") == nil {
+		t.Error("old should survive within the retention period")
+	}
+
+	h.Record(start.Add(2*time.Minute), []*service.Service{{ID: "kept"}})
+	if h.Get("old") != nil {
+		t.Error("old should be dropped after the retention period")
+	}
+	if h.Get("kept") == nil {
+		t.Error("kept should still be recorded")
+	}
+}
@@ -0,0 +1,55 @@
+package metrics
+
+// Ring is a fixed-capacity buffer that overwrites its oldest element when full.
+type Ring[T any] struct {
+	items []T
+	start int
+	size  int
+}
+
+// NewRing creates a ring holding at most capacity items.
+func NewRing[T any](capacity int) *Ring[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Ring[T]{items: make([]T, capacity)}
+}
+
+// Add appends an item, evicting the oldest one if the ring is full.
+func (r *Ring[T]) Add(item T) {
+	idx := (r.start + r.size) % len(r.items)
+	r.items[idx] = item
+	if r.size < len(r.items) {
+		r.size++
+	} else {
+		r.start = (r.start + 1) % len(r.items)
+	}
+}
+
+// Len returns the number of items stored.
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+// Cap returns the maximum number of items the ring holds.
+func (r *Ring[T]) Cap() int {
+	return len(r.items)
+}
+
+// Items returns the stored items, oldest first.
+func (r *Ring[T]) Items() []T {
+	out := make([]T, r.size)
+	for i := 0; i < r.size; i++ {
+		out[i] = r.items[(r.start+i)%len(r.items)]
+	}
+	return out
+}
+
+// Last returns the newest item.
+func (r *Ring[T]) Last() (T, bool) {
+	var zero T
+	if r.size == 0 {
+		return zero, false
+	}
+	return r.items[(r.start+r.size-1)%len(r.items)], true
+}
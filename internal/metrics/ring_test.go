@@ -0,0 +1,38 @@
+package metrics
+
+import "testing"
+
+func TestRing(t *testing.T) {
+	r := NewRing[int](3)
+	if _, ok := r.Last(); ok {
+		t.Error("Last() on empty ring should report false")
+	}
+
+	for i := 1; i <= 5; i++ {
+		r.Add(i)
+	}
+
+	if r.Len() != 3 || r.Cap() != 3 {
+		t.Errorf("Len/Cap = %d/%d, want 3/3", r.Len(), r.Cap())
+	}
+	got := r.Items()
+	want := []int{3, 4, 5}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Items() = %v, want %v", got, want)
+		}
+	}
+	if last, ok := r.Last(); !ok || last != 5 {
+		t.Errorf("Last() = %d, %v; want 5, true", last, ok)
+	}
+}
+
+func TestRingPartial(t *testing.T) {
+	r := NewRing[string](4)
+	r.Add("a")
+	r.Add("b")
+	got := r.Items()
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("Items() = %v, want [a b]", got)
+	}
+}
@@ -0,0 +1,78 @@
+package process
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sqliteHeader starts every SQLite 3 database file.
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// sqliteExtensions are the file names checked for a SQLite header. Checking
+// names first avoids reading from every file a process holds open.
+var sqliteExtensions = []string{".sqlite", ".sqlite3", ".db", ".db3"}
+
+// returns the SQLite database files a process holds open, including databases
+// only visible through their -wal or -journal files.
+func SQLiteFiles(pid int) []string {
+	return sqliteFiles("/proc", pid)
+}
+
+func sqliteFiles(procRoot string, pid int) []string {
+	fdDir := filepath.Join(procRoot, strconv.Itoa(pid), "fd")
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, entry := range entries {
+		target, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+		if err != nil || !filepath.IsAbs(target) {
+			// sockets, pipes and anon inodes
+			continue
+		}
+		for _, suffix := range []string{"-wal", "-journal", "-shm"} {
+			target = strings.TrimSuffix(target, suffix)
+		}
+		if seen[target] || !hasSQLiteExtension(target) {
+			continue
+		}
+		seen[target] = true
+		if isSQLiteFile(target) {
+			files = append(files, target)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+func hasSQLiteExtension(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range sqliteExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// reports whether a file starts with the SQLite header.
+func isSQLiteFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header := make([]byte, len(sqliteHeader))
+	if _, err := f.Read(header); err != nil {
+		return false
+	}
+	return bytes.Equal(header, sqliteHeader)
+}
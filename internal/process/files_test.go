@@ -0,0 +1,48 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSQLiteFiles(t *testing.T) {
+	root := t.TempDir()
+	data := t.TempDir()
+	fdDir := filepath.Join(root, "42", "fd")
+	if err := os.MkdirAll(fdDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(name string, content []byte) string {
+		path := filepath.Join(data, name)
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	header := append([]byte("SQLite format 3\x00"), make([]byte, 84)...)
+	dev := write("dev.sqlite3", header)
+	write("cache.db", header)
+	walOnly := write("cache.db-wal", []byte("wal"))
+	fake := write("notes.db", []byte("not a database"))
+	log := write("server.log", header)
+
+	links := []string{dev, dev + "-journal", walOnly, fake, log, "socket:[12345]"}
+	for i, target := range links {
+		if err := os.Symlink(target, filepath.Join(fdDir, string(rune('0'+i)))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := sqliteFiles(root, 42)
+	want := []string{filepath.Join(data, "cache.db"), dev}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sqliteFiles() = %v, want %v", got, want)
+	}
+
+	if got := sqliteFiles(root, 99); got != nil {
+		t.Errorf("sqliteFiles() for missing PID = %v, want nil", got)
+	}
+}
@@ -0,0 +1,39 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// returns the current working directory of a process.
+func WorkingDir(pid int) (string, error) {
+	dir, err := os.Readlink(fmt.Sprintf("/proc/%d/cwd", pid))
+	if err != nil {
+		return "", fmt.Errorf("read cwd: %w", err)
+	}
+	return dir, nil
+}
+
+// returns when a process started, from the starttime in /proc/<pid>/stat.
+func StartTime(pid int) (time.Time, error) {
+	return startTime("/proc", pid)
+}
+
+func startTime(procRoot string, pid int) (time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("read stat: %w", err)
+	}
+	st, err := parseStat(data)
+	if err != nil {
+		return time.Time{}, err
+	}
+	boot := readBootTime(procRoot)
+	if boot.IsZero() {
+		return time.Time{}, fmt.Errorf("read boot time")
+	}
+	return boot.Add(time.Duration(st.startTime) * time.Second / clockTicks), nil
+}
@@ -0,0 +1,30 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStartTime(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "stat"), []byte("cpu 1 2 3\nbtime 1700000000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeProc(t, root, 100, 1, 10, 1000)
+
+	got, err := startTime(root, 100)
+	if err != nil {
+		t.Fatalf("startTime() error: %v", err)
+	}
+	// starttime in the fixture is 500 ticks, 5s after boot
+	want := time.Unix(1700000000, 0).Add(5 * time.Second)
+	if !got.Equal(want) {
+		t.Errorf("startTime() = %v, want %v", got, want)
+	}
+
+	if _, err := startTime(root, 999); err == nil {
+		t.Error("expected error for a missing PID")
+	}
+}
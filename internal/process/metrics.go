@@ -0,0 +1,217 @@
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// clockTicks is USER_HZ, the unit of CPU times in /proc/<pid>/stat. It is 100 on
+// every Linux platform Go supports without cgo.
+const clockTicks = 100
+
+// Usage is the resource usage of a process and all of its descendants.
+type Usage struct {
+	CPUPercent float64
+	RSS        uint64
+	Processes  int
+	StartTime  time.Time
+}
+
+type procStat struct {
+	ppid      int
+	ticks     uint64
+	startTime uint64
+}
+
+type treeSample struct {
+	ticks uint64
+	at    time.Time
+}
+
+// Sampler computes process tree CPU usage between successive samples.
+type Sampler struct {
+	mu       sync.Mutex
+	procRoot string
+	prev     map[int]treeSample
+}
+
+// creates a sampler reading from /proc.
+func NewSampler() *Sampler {
+	return &Sampler{procRoot: "/proc", prev: make(map[int]treeSample)}
+}
+
+// returns usage for each root PID, including its child processes.
+// CPU percent needs a previous sample, so a process's first sample reports 0%.
+// A PID listed more than once is sampled once.
+func (s *Sampler) Sample(pids []int) map[int]*Usage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	stats := s.readAllStats()
+	children := make(map[int][]int)
+	for pid, st := range stats {
+		children[st.ppid] = append(children[st.ppid], pid)
+	}
+	bootTime := s.bootTime()
+
+	result := make(map[int]*Usage, len(pids))
+	for _, root := range pids {
+		rootStat, ok := stats[root]
+		if _, sampled := result[root]; sampled || !ok {
+			continue
+		}
+
+		usage := &Usage{}
+		var ticks uint64
+		for _, pid := range descendants(root, children) {
+			ticks += stats[pid].ticks
+			usage.RSS += s.readRSS(pid)
+			usage.Processes++
+		}
+
+		if !bootTime.IsZero() {
+			offset := time.Duration(rootStat.startTime) * time.Second / clockTicks
+			usage.StartTime = bootTime.Add(offset)
+		}
+
+		if prev, ok := s.prev[root]; ok && ticks >= prev.ticks {
+			elapsed := now.Sub(prev.at).Seconds()
+			if elapsed > 0 {
+				usage.CPUPercent = float64(ticks-prev.ticks) / clockTicks / elapsed * 100
+			}
+		}
+		s.prev[root] = treeSample{ticks: ticks, at: now}
+		result[root] = usage
+	}
+
+	for pid := range s.prev {
+		if _, ok := result[pid]; !ok {
+			delete(s.prev, pid)
+		}
+	}
+
+	return result
+}
+
+// returns root followed by all of its descendants.
+func descendants(root int, children map[int][]int) []int {
+	tree := []int{root}
+	for i := 0; i < len(tree); i++ {
+		tree = append(tree, children[tree[i]]...)
+	}
+	return tree
+}
+
+func (s *Sampler) readAllStats() map[int]procStat {
+	stats := make(map[int]procStat)
+	entries, err := os.ReadDir(s.procRoot)
+	if err != nil {
+		return stats
+	}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.procRoot, entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		st, err := parseStat(data)
+		if err != nil {
+			continue
+		}
+		stats[pid] = st
+	}
+	return stats
+}
+
+// parses /proc/<pid>/stat. The command name may contain spaces and parentheses,
+// so fields are counted from the last ')'.
+func parseStat(data []byte) (procStat, error) {
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return procStat{}, fmt.Errorf("malformed stat")
+	}
+	// fields[0] is state (field 3 in proc(5))
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 20 {
+		return procStat{}, fmt.Errorf("short stat: %d fields", len(fields))
+	}
+
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return procStat{}, fmt.Errorf("parse ppid: %w", err)
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("parse utime: %w", err)
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("parse stime: %w", err)
+	}
+	start, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("parse starttime: %w", err)
+	}
+
+	return procStat{ppid: ppid, ticks: utime + stime, startTime: start}, nil
+}
+
+// returns VmRSS from /proc/<pid>/status in bytes, or 0 for kernel threads and exited processes.
+func (s *Sampler) readRSS(pid int) uint64 {
+	f, err := os.Open(filepath.Join(s.procRoot, strconv.Itoa(pid), "status"))
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return 0
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb * 1024
+	}
+	return 0
+}
+
+func (s *Sampler) bootTime() time.Time {
+	return readBootTime(s.procRoot)
+}
+
+// returns btime from /proc/stat, or the zero time if it cannot be read.
+func readBootTime(procRoot string) time.Time {
+	data, err := os.ReadFile(filepath.Join(procRoot, "stat"))
+	if err != nil {
+		return time.Time{}
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "btime ") {
+			secs, err := strconv.ParseInt(strings.TrimSpace(line[len("btime "):]), 10, 64)
+			if err != nil {
+				return time.Time{}
+			}
+			return time.Unix(secs, 0)
+		}
+	}
+	return time.Time{}
+}
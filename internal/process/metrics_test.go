@@ -0,0 +1,96 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writes a fake /proc entry with the given parent, CPU ticks and RSS in kB.
+func writeProc(t *testing.T, root string, pid, ppid int, ticks uint64, rssKB int) {
+	t.Helper()
+	dir := filepath.Join(root, fmt.Sprint(pid))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stat := fmt.Sprintf("%d (node (worker)) S %d 1 1 0 -1 4194560 100 0 0 0 %d 0 0 0 20 0 1 0 500 1000 10\n", pid, ppid, ticks)
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	status := fmt.Sprintf("Name:\tnode\nVmRSS:\t  %d kB\n", rssKB)
+	if err := os.WriteFile(filepath.Join(dir, "status"), []byte(status), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseStat(t *testing.T) {
+	st, err := parseStat([]byte("42 (next-server (v14)) S 7 42 42 0 -1 0 0 0 0 0 150 50 0 0 20 0 1 0 12345 0 0"))
+	if err != nil {
+		t.Fatalf("parseStat() error: %v", err)
+	}
+	if st.ppid != 7 || st.ticks != 200 || st.startTime != 12345 {
+		t.Errorf("parseStat() = %+v, want ppid 7, ticks 200, start 12345", st)
+	}
+
+	if _, err := parseStat([]byte("42 (broken")); err == nil {
+		t.Error("expected error for malformed stat")
+	}
+}
+
+func TestSamplerIncludesChildren(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "stat"), []byte("cpu 1 2 3\nbtime 1700000000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeProc(t, root, 100, 1, 10, 1000)
+	writeProc(t, root, 101, 100, 20, 2000)
+	writeProc(t, root, 102, 101, 30, 3000)
+	writeProc(t, root, 200, 1, 99, 9000)
+
+	s := &Sampler{procRoot: root, prev: make(map[int]treeSample)}
+
+	usage := s.Sample([]int{100, 999})
+	if _, ok := usage[999]; ok {
+		t.Error("expected no usage for a missing PID")
+	}
+	u := usage[100]
+	if u == nil {
+		t.Fatal("expected usage for PID 100")
+	}
+	if u.Processes != 3 {
+		t.Errorf("Processes = %d, want 3", u.Processes)
+	}
+	if u.RSS != 6000*1024 {
+		t.Errorf("RSS = %d, want %d", u.RSS, 6000*1024)
+	}
+	if u.CPUPercent != 0 {
+		t.Errorf("first sample CPUPercent = %v, want 0", u.CPUPercent)
+	}
+	wantStart := time.Unix(1700000000, 0).Add(5 * time.Second)
+	if !u.StartTime.Equal(wantStart) {
+		t.Errorf("StartTime = %v, want %v", u.StartTime, wantStart)
+	}
+
+	// Pretend the previous sample was taken one second ago with 60 fewer ticks.
+	s.prev[100] = treeSample{ticks: 0, at: time.Now().Add(-time.Second)}
+	u = s.Sample([]int{100})[100]
+	if u.CPUPercent < 50 || u.CPUPercent > 61 {
+		t.Errorf("CPUPercent = %v, want about 60", u.CPUPercent)
+	}
+}
+
+func TestSamplerDuplicatePID(t *testing.T) {
+	root := t.TempDir()
+	writeProc(t, root, 100, 1, 60, 1000)
+
+	s := &Sampler{procRoot: root, prev: make(map[int]treeSample)}
+	s.prev[100] = treeSample{ticks: 0, at: time.Now().Add(-time.Second)}
+
+	// a process listening on a port is listed by PID and by port
+	u := s.Sample([]int{100, 100})[100]
+	if u == nil || u.CPUPercent < 50 || u.CPUPercent > 61 {
+		t.Errorf("usage = %+v, want CPUPercent about 60", u)
+	}
+}
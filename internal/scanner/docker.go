@@ -3,6 +3,8 @@ package scanner
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
@@ -10,7 +12,27 @@ import (
 )
 
 type DockerScanner struct {
-	client *client.Client
+	client     *client.Client
+	mu         sync.Mutex
+	cpuSamples map[string]cpuSample
+	restarts   map[string]restartInfo
+}
+
+// restartInfo caches what inspecting a container found, keyed by the status it
+// was in, so unchanged containers are not inspected on every scan.
+type restartInfo struct {
+	status       string
+	created      int64
+	restartCount int
+	exitCode     int
+}
+
+// reports whether the cached info still holds for c. A restart resets the
+// uptime in the status to seconds, so statuses counting seconds never match:
+// two scans can both read "Up 1 second" across a crash.
+func (info restartInfo) current(c *ContainerInfo) bool {
+	return info.status == c.Status && info.created == c.Created &&
+		!strings.Contains(c.Status, "second")
 }
 
 // initializes a Docker client.
@@ -19,7 +41,11 @@ func NewDockerScanner() (*DockerScanner, error) {
 	if err != nil {
 		return nil, fmt.Errorf("docker client: %w", err)
 	}
-	return &DockerScanner{client: cli}, nil
+	return &DockerScanner{
+		client:     cli,
+		cpuSamples: make(map[string]cpuSample),
+		restarts:   make(map[string]restartInfo),
+	}, nil
 }
 
 // lists all Docker containers (running and stopped).
@@ -49,14 +75,67 @@ func (ds *DockerScanner) ListContainers(ctx context.Context) ([]ContainerInfo, e
 			Name:    name,
 			Image:   imageName,
 			State:   c.State,
+			Status:  c.Status,
 			DBType:  db.DetectType(imageName),
 			Created: c.Created,
 		})
 	}
 
+	ds.addRestartInfo(ctx, found)
+
 	return found, nil
 }
 
+// fills in restart counts and exit codes, which the container list does not report.
+// Containers are only inspected again when their status text or created time changes.
+func (ds *DockerScanner) addRestartInfo(ctx context.Context, containers []ContainerInfo) {
+	ds.mu.Lock()
+	cached := make(map[string]restartInfo, len(ds.restarts))
+	for id, info := range ds.restarts {
+		cached[id] = info
+	}
+	ds.mu.Unlock()
+
+	results := make([]restartInfo, len(containers))
+	inspected := make([]bool, len(containers))
+	var wg sync.WaitGroup
+	for i := range containers {
+		c := &containers[i]
+		if info, ok := cached[c.ID]; ok && info.current(c) {
+			c.RestartCount = info.restartCount
+			c.ExitCode = info.exitCode
+			results[i], inspected[i] = info, true
+			continue
+		}
+		wg.Add(1)
+		go func(i int, c *ContainerInfo) {
+			defer wg.Done()
+			inspect, err := ds.client.ContainerInspect(ctx, c.ID)
+			if err != nil || inspect.ContainerJSONBase == nil {
+				return
+			}
+			c.RestartCount = inspect.RestartCount
+			if inspect.State != nil {
+				c.ExitCode = inspect.State.ExitCode
+			}
+			results[i] = restartInfo{status: c.Status, created: c.Created, restartCount: c.RestartCount, exitCode: c.ExitCode}
+			inspected[i] = true
+		}(i, c)
+	}
+	wg.Wait()
+
+	// failed inspects are not cached, so they are retried next scan
+	restarts := make(map[string]restartInfo, len(containers))
+	for i, c := range containers {
+		if inspected[i] {
+			restarts[c.ID] = results[i]
+		}
+	}
+	ds.mu.Lock()
+	ds.restarts = restarts
+	ds.mu.Unlock()
+}
+
 // closes the Docker client connection.
 func (ds *DockerScanner) Close() error {
 	if ds.client != nil {
@@ -66,10 +145,14 @@ func (ds *DockerScanner) Close() error {
 }
 
 type ContainerInfo struct {
-	ID      string
-	Name    string
-	Image   string
-	State   string
-	DBType  string
-	Created int64
+	ID    string
+	Name  string
+	Image string
+	State string
+	// Status is the list's status text, such as "Up 5 minutes".
+	Status       string
+	DBType       string
+	Created      int64
+	RestartCount int
+	ExitCode     int
 }
@@ -0,0 +1,29 @@
+package scanner
+
+import "testing"
+
+func TestRestartInfoCurrent(t *testing.T) {
+	tests := []struct {
+		name   string
+		cached string
+		status string
+		want   bool
+	}{
+		{name: "unchanged", cached: "Up 3 hours", status: "Up 3 hours", want: true},
+		{name: "restarted", cached: "Up 3 minutes", status: "Up 2 seconds"},
+		{name: "restarted within seconds", cached: "Up 1 second", status: "Up 1 second"},
+		{name: "exited", cached: "Up 3 hours", status: "Exited (1) 3 hours ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := restartInfo{status: tt.cached, created: 100}
+			c := &ContainerInfo{Status: tt.status, Created: 100}
+			if got := info.current(c); got != tt.want {
+				t.Errorf("current() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if (restartInfo{status: "Up 3 hours", created: 100}).current(&ContainerInfo{Status: "Up 3 hours", Created: 200}) {
+		t.Error("current() = true for a recreated container")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/eanda22/devhud/internal/process"
 	"github.com/eanda22/devhud/internal/service"
 )
 
@@ -13,11 +14,11 @@ type Scanner struct {
 	portScanner    *PortScanner
 	processScanner *ProcessScanner
 	dockerScanner  *DockerScanner
-	store          *service.Store
+	sampler        *process.Sampler
 }
 
 // initializes all available discovery methods.
-func NewScanner(store *service.Store) (*Scanner, error) {
+func NewScanner() (*Scanner, error) {
 	dockerScanner, err := NewDockerScanner()
 	if err != nil {
 		dockerScanner = nil
@@ -27,38 +28,92 @@ func NewScanner(store *service.Store) (*Scanner, error) {
 		portScanner:    NewPortScanner(),
 		processScanner: NewProcessScanner(),
 		dockerScanner:  dockerScanner,
-		store:          store,
+		sampler:        process.NewSampler(),
 	}, nil
 }
 
-// discovers all services and updates the store.
-func (s *Scanner) Scan(ctx context.Context) error {
-	// Save existing services to preserve StartTime for processes
+// discovers all services into a new store. previous is the last scan's
+// services, read only to preserve StartTime for processes. The store is only
+// touched by the scan until it is returned.
+func (s *Scanner) Scan(ctx context.Context, previous []*service.Service) (*service.Store, error) {
 	oldServices := make(map[string]*service.Service)
-	for _, svc := range s.store.GetAll() {
+	for _, svc := range previous {
 		oldServices[svc.ID] = svc
 	}
 
-	s.store.Clear()
+	store := service.NewStore()
 
 	if s.dockerScanner != nil {
-		_ = s.scanDocker(ctx)
+		_ = s.scanDocker(ctx, store)
 	}
 
-	_ = s.scanPorts(ctx, oldServices)
+	_ = s.scanPorts(ctx, store, oldServices)
 
-	_ = s.scanProcesses(ctx, oldServices)
+	_ = s.scanProcesses(ctx, store, oldServices)
 
-	return nil
+	s.sampleProcesses(store)
+
+	s.detectSQLite(store)
+
+	return store, nil
+}
+
+// marks processes holding a SQLite database open as browsable databases.
+func (s *Scanner) detectSQLite(store *service.Store) {
+	for _, svc := range store.GetByType(service.ServiceTypeProcess) {
+		if svc.PID == 0 || svc.DBType != "" {
+			continue
+		}
+		if files := process.SQLiteFiles(svc.PID); len(files) > 0 {
+			svc.DBType = "sqlite"
+			svc.DBPath = files[0]
+		}
+	}
+}
+
+// attaches /proc resource usage to process services and replaces their
+// first-seen start time with the real one.
+func (s *Scanner) sampleProcesses(store *service.Store) {
+	var pids []int
+	for _, svc := range store.GetByType(service.ServiceTypeProcess) {
+		if svc.PID != 0 {
+			pids = append(pids, svc.PID)
+		}
+	}
+
+	usage := s.sampler.Sample(pids)
+	for _, svc := range store.GetByType(service.ServiceTypeProcess) {
+		u, ok := usage[svc.PID]
+		if !ok {
+			continue
+		}
+		svc.Resources = &service.Resources{
+			CPUPercent: u.CPUPercent,
+			MemUsage:   u.RSS,
+			Processes:  u.Processes,
+		}
+		if !u.StartTime.IsZero() {
+			svc.StartTime = u.StartTime
+			svc.Uptime = time.Since(u.StartTime)
+		}
+	}
 }
 
 // discovers running Docker containers.
-func (s *Scanner) scanDocker(ctx context.Context) error {
+func (s *Scanner) scanDocker(ctx context.Context, store *service.Store) error {
 	containers, err := s.dockerScanner.ListContainers(ctx)
 	if err != nil {
 		return err
 	}
 
+	var runningIDs []string
+	for _, c := range containers {
+		if c.State == "running" {
+			runningIDs = append(runningIDs, c.ID)
+		}
+	}
+	stats := s.dockerScanner.ContainerStats(ctx, runningIDs)
+
 	for _, c := range containers {
 		startTime := time.Unix(c.Created, 0)
 		uptime := time.Duration(0)
@@ -67,14 +122,17 @@ func (s *Scanner) scanDocker(ctx context.Context) error {
 		}
 
 		svc := &service.Service{
-			ID:          c.ID,
-			Name:        c.Name,
-			Type:        service.ServiceTypeDocker,
-			ContainerID: c.ID,
-			Image:       c.Image,
-			DBType:      c.DBType,
-			StartTime:   startTime,
-			Uptime:      uptime,
+			ID:           c.ID,
+			Name:         c.Name,
+			Type:         service.ServiceTypeDocker,
+			ContainerID:  c.ID,
+			Image:        c.Image,
+			DBType:       c.DBType,
+			RestartCount: c.RestartCount,
+			ExitCode:     c.ExitCode,
+			StartTime:    startTime,
+			Uptime:       uptime,
+			Resources:    stats[c.ID],
 		}
 
 		if c.State == "running" {
@@ -83,14 +141,14 @@ func (s *Scanner) scanDocker(ctx context.Context) error {
 			svc.Status = service.StatusStopped
 		}
 
-		s.store.Upsert(svc)
+		store.Upsert(svc)
 	}
 
 	return nil
 }
 
 // discovers processes listening on common ports.
-func (s *Scanner) scanPorts(ctx context.Context, oldServices map[string]*service.Service) error {
+func (s *Scanner) scanPorts(ctx context.Context, store *service.Store, oldServices map[string]*service.Service) error {
 	portInfos, err := s.portScanner.ListeningPorts()
 	if err != nil {
 		return err
@@ -124,14 +182,14 @@ func (s *Scanner) scanPorts(ctx context.Context, oldServices map[string]*service
 			}
 		}
 
-		s.store.Upsert(svc)
+		store.Upsert(svc)
 	}
 
 	return nil
 }
 
 // discovers target development processes.
-func (s *Scanner) scanProcesses(ctx context.Context, oldServices map[string]*service.Service) error {
+func (s *Scanner) scanProcesses(ctx context.Context, store *service.Store, oldServices map[string]*service.Service) error {
 	processes, err := s.processScanner.FindProcesses()
 	if err != nil {
 		return err
@@ -174,7 +232,7 @@ func (s *Scanner) scanProcesses(ctx context.Context, oldServices map[string]*ser
 			Uptime:    uptime,
 		}
 
-		s.store.Upsert(svc)
+		store.Upsert(svc)
 	}
 
 	return nil
@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+// cpuSample is the cumulative CPU counter pair needed to compute usage between scans.
+type cpuSample struct {
+	total  uint64
+	system uint64
+}
+
+// returns a resource sample for each running container ID, computed concurrently.
+// CPU percent needs a previous sample, so a container's first scan reports 0%.
+func (ds *DockerScanner) ContainerStats(ctx context.Context, ids []string) map[string]*service.Resources {
+	var wg sync.WaitGroup
+	result := make(map[string]*service.Resources, len(ids))
+
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+
+			stats, err := ds.fetchStats(ctx, id)
+			if err != nil {
+				return
+			}
+
+			ds.mu.Lock()
+			defer ds.mu.Unlock()
+			prev, hasPrev := ds.cpuSamples[id]
+			result[id] = containerResources(stats, prev, hasPrev)
+			ds.cpuSamples[id] = cpuSample{
+				total:  stats.CPUStats.CPUUsage.TotalUsage,
+				system: stats.CPUStats.SystemUsage,
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	ds.mu.Lock()
+	for id := range ds.cpuSamples {
+		if _, ok := result[id]; !ok {
+			delete(ds.cpuSamples, id)
+		}
+	}
+	ds.mu.Unlock()
+
+	return result
+}
+
+func (ds *DockerScanner) fetchStats(ctx context.Context, id string) (container.StatsResponse, error) {
+	var stats container.StatsResponse
+
+	reader, err := ds.client.ContainerStatsOneShot(ctx, id)
+	if err != nil {
+		return stats, err
+	}
+	defer reader.Body.Close()
+
+	if err := json.NewDecoder(reader.Body).Decode(&stats); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// converts a Docker stats response into a resource sample, using the same
+// formulas as `docker stats`.
+func containerResources(stats container.StatsResponse, prev cpuSample, hasPrev bool) *service.Resources {
+	res := &service.Resources{}
+
+	pre := prev
+	if stats.PreCPUStats.SystemUsage != 0 {
+		pre = cpuSample{
+			total:  stats.PreCPUStats.CPUUsage.TotalUsage,
+			system: stats.PreCPUStats.SystemUsage,
+		}
+		hasPrev = true
+	}
+	if hasPrev {
+		cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(pre.total)
+		systemDelta := float64(stats.CPUStats.SystemUsage) - float64(pre.system)
+		online := float64(stats.CPUStats.OnlineCPUs)
+		if online == 0 {
+			online = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+		}
+		if cpuDelta > 0 && systemDelta > 0 {
+			res.CPUPercent = cpuDelta / systemDelta * online * 100
+		}
+	}
+
+	res.MemUsage = stats.MemoryStats.Usage
+	if cache, ok := stats.MemoryStats.Stats["total_inactive_file"]; ok && cache < res.MemUsage {
+		res.MemUsage -= cache
+	} else if cache, ok := stats.MemoryStats.Stats["inactive_file"]; ok && cache < res.MemUsage {
+		res.MemUsage -= cache
+	}
+	res.MemLimit = stats.MemoryStats.Limit
+	res.Processes = int(stats.PidsStats.Current)
+
+	for _, net := range stats.Networks {
+		res.NetRx += net.RxBytes
+		res.NetTx += net.TxBytes
+	}
+
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			res.BlockRead += entry.Value
+		case "write":
+			res.BlockWrite += entry.Value
+		}
+	}
+
+	return res
+}
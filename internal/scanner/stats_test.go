@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"math"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestContainerResources(t *testing.T) {
+	stats := container.StatsResponse{
+		CPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 3_000_000},
+			SystemUsage: 20_000_000,
+			OnlineCPUs:  4,
+		},
+		MemoryStats: container.MemoryStats{
+			Usage: 300 << 20,
+			Limit: 1 << 30,
+			Stats: map[string]uint64{"inactive_file": 100 << 20},
+		},
+		Networks: map[string]container.NetworkStats{
+			"eth0": {RxBytes: 1000, TxBytes: 200},
+			"eth1": {RxBytes: 500, TxBytes: 50},
+		},
+		BlkioStats: container.BlkioStats{
+			IoServiceBytesRecursive: []container.BlkioStatEntry{
+				{Op: "Read", Value: 4096},
+				{Op: "write", Value: 8192},
+				{Op: "Total", Value: 12288},
+			},
+		},
+	}
+
+	t.Run("first sample has no cpu", func(t *testing.T) {
+		res := containerResources(stats, cpuSample{}, false)
+		if res.CPUPercent != 0 {
+			t.Errorf("CPUPercent = %v, want 0", res.CPUPercent)
+		}
+		if res.MemUsage != 200<<20 {
+			t.Errorf("MemUsage = %d, want %d", res.MemUsage, 200<<20)
+		}
+		if res.MemLimit != 1<<30 {
+			t.Errorf("MemLimit = %d, want %d", res.MemLimit, 1<<30)
+		}
+		if res.NetRx != 1500 || res.NetTx != 250 {
+			t.Errorf("Net = %d/%d, want 1500/250", res.NetRx, res.NetTx)
+		}
+		if res.BlockRead != 4096 || res.BlockWrite != 8192 {
+			t.Errorf("Block = %d/%d, want 4096/8192", res.BlockRead, res.BlockWrite)
+		}
+	})
+
+	t.Run("previous scan sample", func(t *testing.T) {
+		prev := cpuSample{total: 1_000_000, system: 10_000_000}
+		res := containerResources(stats, prev, true)
+		// 2M of 10M system ticks across 4 CPUs = 80%
+		if math.Abs(res.CPUPercent-80) > 0.001 {
+			t.Errorf("CPUPercent = %v, want 80", res.CPUPercent)
+		}
+	})
+
+	t.Run("precpu stats take priority", func(t *testing.T) {
+		withPre := stats
+		withPre.PreCPUStats = container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 2_000_000},
+			SystemUsage: 10_000_000,
+		}
+		res := containerResources(withPre, cpuSample{}, false)
+		if math.Abs(res.CPUPercent-40) > 0.001 {
+			t.Errorf("CPUPercent = %v, want 40", res.CPUPercent)
+		}
+	})
+}
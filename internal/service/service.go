@@ -22,19 +22,35 @@ const (
 )
 
 type Service struct {
-	ID          string
-	Name        string
-	Type        ServiceType
-	Status      Status
-	Port        int
-	PID         int
-	ContainerID string
-	Image       string
-	DBType      string
-	Uptime      time.Duration
-	StartTime   time.Time
-	Project     string
-	DependsOn   []string
+	ID           string
+	Name         string
+	Type         ServiceType
+	Status       Status
+	Port         int
+	PID          int
+	ContainerID  string
+	Image        string
+	DBType       string
+	DBPath       string
+	RestartCount int
+	ExitCode     int
+	Uptime       time.Duration
+	StartTime    time.Time
+	Project      string
+	DependsOn    []string
+	Resources    *Resources
+}
+
+// Resources is a resource usage sample taken during a scan.
+type Resources struct {
+	CPUPercent float64
+	MemUsage   uint64
+	MemLimit   uint64
+	NetRx      uint64
+	NetTx      uint64
+	BlockRead  uint64
+	BlockWrite uint64
+	Processes  int
 }
 
 type Store struct {
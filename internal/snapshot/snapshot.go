@@ -0,0 +1,263 @@
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/docker"
+)
+
+const (
+	dumpExt = ".sql.gz"
+	metaExt = ".json"
+	// errorLines is how much of a failed command's stderr is reported.
+	errorLines = 5
+)
+
+// Snapshot describes a saved dump of one database.
+type Snapshot struct {
+	Name      string    `json:"name"`
+	Container string    `json:"container"`
+	DBType    string    `json:"db_type"`
+	Database  string    `json:"database"`
+	Created   time.Time `json:"created"`
+	// Size is the size of the compressed dump in bytes.
+	Size int64 `json:"size"`
+}
+
+// Store keeps the snapshots of one container in a directory, as a gzipped SQL
+// dump and a JSON metadata file per snapshot.
+type Store struct {
+	dir string
+}
+
+// NewStore returns the store for a container's snapshots under root.
+func NewStore(root, container string) *Store {
+	return &Store{dir: filepath.Join(root, "snapshots", safeName(container))}
+}
+
+// Dir returns the directory the snapshots are stored in.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// DefaultName names a snapshot after the time it is taken.
+func DefaultName(t time.Time) string {
+	return t.Format("2006-01-02_150405")
+}
+
+// ValidateName checks that a snapshot name is usable as a file name.
+func ValidateName(name string) error {
+	if name == "" {
+		return errors.New("snapshot name is empty")
+	}
+	if safeName(name) != name {
+		return fmt.Errorf("snapshot name %q may only contain letters, digits, '-', '_' and '.'", name)
+	}
+	return nil
+}
+
+// List returns the snapshots, newest first. A missing directory has none.
+func (s *Store) List() ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read snapshots: %w", err)
+	}
+
+	var snaps []Snapshot
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), metaExt) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot: %w", err)
+		}
+		var snap Snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", e.Name(), err)
+		}
+		snaps = append(snaps, snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool {
+		return snaps[i].Created.After(snaps[j].Created)
+	})
+	return snaps, nil
+}
+
+// Save stores a snapshot whose dump is written by dump. The dump is compressed
+// into a temporary file first, so a failed dump leaves no snapshot behind.
+func (s *Store) Save(snap Snapshot, dump func(w io.Writer) error) (*Snapshot, error) {
+	if err := ValidateName(snap.Name); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(s.path(snap.Name, metaExt)); err == nil {
+		return nil, fmt.Errorf("snapshot %q already exists", snap.Name)
+	}
+	// dumps hold the whole database, so keep them private like query history
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create snapshot dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, snap.Name+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	err = dump(gz)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(tmp.Name())
+	if err != nil {
+		return nil, fmt.Errorf("stat snapshot: %w", err)
+	}
+	snap.Size = info.Size()
+	if err := os.Rename(tmp.Name(), s.path(snap.Name, dumpExt)); err != nil {
+		return nil, fmt.Errorf("save snapshot: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode snapshot: %w", err)
+	}
+	if err := os.WriteFile(s.path(snap.Name, metaExt), data, 0o600); err != nil {
+		return nil, fmt.Errorf("write snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// Open returns a reader for a snapshot's uncompressed SQL dump.
+func (s *Store) Open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(name, dumpExt))
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot: %w", err)
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	return &dumpReader{Reader: gz, file: f}, nil
+}
+
+// Delete removes a snapshot's dump and metadata.
+func (s *Store) Delete(name string) error {
+	for _, ext := range []string{dumpExt, metaExt} {
+		if err := os.Remove(s.path(name, ext)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("delete snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) path(name, ext string) string {
+	return filepath.Join(s.dir, name+ext)
+}
+
+// dumpReader closes the gzip stream and the file under it.
+type dumpReader struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (r *dumpReader) Close() error {
+	r.Reader.Close()
+	return r.file.Close()
+}
+
+// Target is the database a snapshot is taken from or restored to.
+type Target struct {
+	ContainerID string
+	Container   string
+	DBType      string
+	Config      *db.ConnectionConfig
+}
+
+// Take dumps the target's database inside its container and saves it under name.
+func Take(ctx context.Context, dockerClient *docker.Client, store *Store, target Target, name string) (*Snapshot, error) {
+	dump, _, err := db.DumpCommands(target.DBType, target.Config)
+	if err != nil {
+		return nil, err
+	}
+	snap := Snapshot{
+		Name:      name,
+		Container: target.Container,
+		DBType:    target.DBType,
+		Database:  target.Config.Database,
+		Created:   time.Now(),
+	}
+	return store.Save(snap, func(w io.Writer) error {
+		return run(ctx, dockerClient, target.ContainerID, "dump", dump, nil, w)
+	})
+}
+
+// Restore loads a snapshot back into the target's database.
+func Restore(ctx context.Context, dockerClient *docker.Client, store *Store, target Target, name string) error {
+	_, restore, err := db.DumpCommands(target.DBType, target.Config)
+	if err != nil {
+		return err
+	}
+	dump, err := store.Open(name)
+	if err != nil {
+		return err
+	}
+	defer dump.Close()
+	return run(ctx, dockerClient, target.ContainerID, "restore", restore, dump, io.Discard)
+}
+
+// runs a dump or restore command and reports the end of its stderr if it fails.
+func run(ctx context.Context, dockerClient *docker.Client, containerID, action string, cmd db.ContainerCommand, stdin io.Reader, stdout io.Writer) error {
+	var stderr bytes.Buffer
+	code, err := dockerClient.Exec(ctx, containerID, docker.ExecOptions{Cmd: cmd.Cmd, Env: cmd.Env}, stdin, stdout, &stderr)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+		msg := strings.Join(lines[max(0, len(lines)-errorLines):], "\n")
+		if msg == "" {
+			msg = "no output"
+		}
+		return fmt.Errorf("%s exited with code %d: %s", action, code, msg)
+	}
+	return nil
+}
+
+// replaces characters that are unsafe in file names with '_'.
+func safeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return strings.Trim(b.String(), ".")
+}
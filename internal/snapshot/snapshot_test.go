@@ -0,0 +1,89 @@
+package snapshot
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir(), "myapp/db-1")
+
+	snaps, err := store.List()
+	if err != nil || len(snaps) != 0 {
+		t.Fatalf("List() on a new store = %v, %v, want none", snaps, err)
+	}
+
+	older := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	for i, name := range []string{"before-migration", "nightly"} {
+		snap := Snapshot{Name: name, Container: "db-1", DBType: "postgres", Database: "app", Created: older.Add(time.Duration(i) * time.Hour)}
+		saved, err := store.Save(snap, func(w io.Writer) error {
+			_, err := io.WriteString(w, "CREATE TABLE "+name+" ();\n")
+			return err
+		})
+		if err != nil {
+			t.Fatalf("Save(%q) error: %v", name, err)
+		}
+		if saved.Size <= 0 {
+			t.Errorf("Save(%q) size = %d, want > 0", name, saved.Size)
+		}
+	}
+
+	if _, err := store.Save(Snapshot{Name: "nightly"}, func(io.Writer) error { return nil }); err == nil {
+		t.Error("Save() overwrote an existing snapshot")
+	}
+	if _, err := store.Save(Snapshot{Name: "broken"}, func(io.Writer) error { return errors.New("dump failed") }); err == nil {
+		t.Error("Save() ignored a failed dump")
+	}
+
+	snaps, err = store.List()
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(snaps) != 2 || snaps[0].Name != "nightly" || snaps[1].Name != "before-migration" {
+		t.Fatalf("List() = %+v, want nightly then before-migration", snaps)
+	}
+	if snaps[1].Database != "app" || !snaps[1].Created.Equal(older) {
+		t.Errorf("List() metadata = %+v", snaps[1])
+	}
+
+	r, err := store.Open("before-migration")
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || string(data) != "CREATE TABLE before-migration ();\n" {
+		t.Errorf("Open() read %q, %v", data, err)
+	}
+
+	if err := store.Delete("nightly"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if snaps, _ := store.List(); len(snaps) != 1 {
+		t.Errorf("List() after Delete() = %+v, want 1 snapshot", snaps)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "2024-05-01_090000", valid: true},
+		{name: "before.migration", valid: true},
+		{name: "", valid: false},
+		{name: "with space", valid: false},
+		{name: "../escape", valid: false},
+		{name: "..", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateName(tt.name); (err == nil) != tt.valid {
+				t.Errorf("ValidateName(%q) = %v, want valid %v", tt.name, err, tt.valid)
+			}
+		})
+	}
+}
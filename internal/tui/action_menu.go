@@ -1,8 +1,12 @@
 package tui
 
 import (
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/db"
 	"github.com/eanda22/devhud/internal/docker"
 	"github.com/eanda22/devhud/internal/service"
 )
@@ -11,18 +15,21 @@ type ActionMenuView struct {
 	service       *service.Service
 	dockerClient  *docker.Client
 	actions       []string
+	commands      []config.ExecCommand
 	selectedIndex int
 	shouldExit    bool
 	executeAction string
+	execCommand   *config.ExecCommand
 	width         int
 	height        int
 }
 
-func NewActionMenuView(svc *service.Service, dockerClient *docker.Client, w, h int) *ActionMenuView {
+func NewActionMenuView(svc *service.Service, dockerClient *docker.Client, commands []config.ExecCommand, w, h int) *ActionMenuView {
 	return &ActionMenuView{
 		service:       svc,
 		dockerClient:  dockerClient,
-		actions:       getActionMenuItems(svc),
+		actions:       getActionMenuItems(svc, commands),
+		commands:      commands,
 		selectedIndex: 0,
 		shouldExit:    false,
 		executeAction: "",
@@ -55,6 +62,7 @@ func (a *ActionMenuView) Update(msg tea.Msg) (*ActionMenuView, tea.Cmd) {
 		case "enter":
 			if a.selectedIndex < len(a.actions) {
 				a.executeAction = a.actions[a.selectedIndex]
+				a.execCommand = a.selectedCommand()
 				a.shouldExit = true
 			}
 			return a, nil
@@ -96,7 +104,9 @@ func (a *ActionMenuView) View() string {
 	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, box)
 }
 
-func getActionMenuItems(svc *service.Service) []string {
+// lists the actions for a service. Custom commands for the container's image
+// follow the shell.
+func getActionMenuItems(svc *service.Service, commands []config.ExecCommand) []string {
 	var items []string
 
 	if svc.Type == service.ServiceTypeDocker || svc.Type == service.ServiceTypeCompose {
@@ -105,20 +115,55 @@ func getActionMenuItems(svc *service.Service) []string {
 			if svc.DBType != "" {
 				items = append(items, "Browse Database")
 			}
+			if db.CanDump(svc.DBType) {
+				items = append(items, "Snapshots")
+			}
+			items = append(items, "View Metrics")
 			items = append(items, "Restart Container")
 			items = append(items, "Stop Container")
+			items = append(items, "Environment")
 			items = append(items, "Inspect JSON")
-			items = append(items, "Open Shell (/bin/sh)")
+			items = append(items, shellAction, shellAsAction)
+			for _, c := range commands {
+				items = append(items, execActionPrefix+c.Label())
+			}
 			items = append(items, "Delete Container")
 		} else {
 			items = append(items, "Start Container")
+			items = append(items, "View Metrics")
+			items = append(items, "Environment")
 			items = append(items, "Inspect JSON")
 			items = append(items, "Delete Container")
 		}
 	} else if svc.Type == service.ServiceTypeProcess {
 		items = append(items, "View Logs")
+		if svc.DBType != "" {
+			items = append(items, "Browse Database")
+		}
+		items = append(items, "View Metrics")
+		if svc.PID != 0 {
+			items = append(items, "Environment")
+		}
 		items = append(items, "Kill Process")
 	}
 
 	return items
 }
+
+// returns the custom command at the selected item, found by its position
+// among the commands since labels may repeat.
+func (a *ActionMenuView) selectedCommand() *config.ExecCommand {
+	if !strings.HasPrefix(a.actions[a.selectedIndex], execActionPrefix) {
+		return nil
+	}
+	i := 0
+	for _, action := range a.actions[:a.selectedIndex] {
+		if strings.HasPrefix(action, execActionPrefix) {
+			i++
+		}
+	}
+	if i >= len(a.commands) {
+		return nil
+	}
+	return &a.commands[i]
+}
@@ -0,0 +1,388 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+// activityRefresh is how often the session list refreshes itself.
+const activityRefresh = 2 * time.Second
+
+// activityGen numbers activity views, so a refresh scheduled by a closed view
+// does not start a second refresh loop in the next one.
+var activityGen int
+
+var (
+	blockingSessionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+	blockedSessionStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C"))
+)
+
+// ActivityView lists the sessions on a database server with their state,
+// query and the locks they wait for, and cancels queries or terminates
+// sessions after confirmation. It refreshes itself until paused.
+type ActivityView struct {
+	service       *service.Service
+	client        *db.Client
+	sessions      []db.Session
+	selectedID    int64
+	selectedIndex int
+	showIdle      bool
+	paused        bool
+	confirm       string
+	confirmID     int64
+	gen           int
+	viewport      viewport.Model
+	statusMessage string
+	error         error
+	ready         bool
+	shouldExit    bool
+}
+
+// creates a session monitor for a database client.
+func NewActivityView(svc *service.Service, client *db.Client, width, height int) *ActivityView {
+	vp := viewport.New(width-4, height-6)
+	vp.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+
+	activityGen++
+	return &ActivityView{
+		service:  svc,
+		client:   client,
+		gen:      activityGen,
+		viewport: vp,
+	}
+}
+
+func (v *ActivityView) Init() tea.Cmd {
+	return v.fetchSessionsCmd(true)
+}
+
+func (v *ActivityView) Update(msg tea.Msg) (*ActivityView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return v, tea.Quit
+		}
+		if v.confirm != "" {
+			return v.updateConfirm(msg)
+		}
+		if handled, keyCmd := v.updateKeys(msg); handled {
+			return v, keyCmd
+		}
+
+	case SessionsFetchedMsg:
+		if msg.Gen != v.gen {
+			return v, nil
+		}
+		v.ready = true
+		var next tea.Cmd
+		if msg.Scheduled {
+			next = v.tickCmd()
+		}
+		if msg.Error != nil {
+			v.error = msg.Error
+			v.viewport.SetContent("Error listing sessions: " + msg.Error.Error())
+			return v, next
+		}
+		v.error = nil
+		v.sessions = msg.Sessions
+		v.updateViewportContent()
+		return v, next
+
+	case ActivityTickMsg:
+		if msg.Gen != v.gen {
+			return v, nil
+		}
+		if v.paused {
+			return v, v.tickCmd()
+		}
+		return v, v.fetchSessionsCmd(true)
+
+	case SessionSignalledMsg:
+		switch {
+		case msg.Error != nil && msg.Terminate:
+			v.statusMessage = fmt.Sprintf("Terminate failed: %v", msg.Error)
+		case msg.Error != nil:
+			v.statusMessage = fmt.Sprintf("Cancel failed: %v", msg.Error)
+		case msg.Terminate:
+			v.statusMessage = fmt.Sprintf("Terminated session %d", msg.ID)
+		default:
+			v.statusMessage = fmt.Sprintf("Cancelled the query of session %d", msg.ID)
+		}
+		if msg.Error != nil {
+			return v, nil
+		}
+		return v, v.fetchSessionsCmd(false)
+
+	case tea.WindowSizeMsg:
+		v.viewport.Width = msg.Width - 4
+		v.viewport.Height = msg.Height - 6
+		v.updateViewportContent()
+	}
+
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func (v *ActivityView) updateKeys(msg tea.KeyMsg) (bool, tea.Cmd) {
+	visible := v.visible()
+	switch msg.String() {
+	case "q":
+		return true, tea.Quit
+	case "esc":
+		v.shouldExit = true
+		return true, nil
+	case "r":
+		return true, v.fetchSessionsCmd(false)
+	case "p":
+		v.paused = !v.paused
+		return true, nil
+	case "i":
+		v.showIdle = !v.showIdle
+		v.updateViewportContent()
+		return true, nil
+	case "up", "k":
+		if v.selectedIndex > 0 {
+			v.selectedIndex--
+			v.selectedID = visible[v.selectedIndex].ID
+			v.updateViewportContent()
+		}
+		return true, nil
+	case "down", "j":
+		if v.selectedIndex < len(visible)-1 {
+			v.selectedIndex++
+			v.selectedID = visible[v.selectedIndex].ID
+			v.updateViewportContent()
+		}
+		return true, nil
+	case "b":
+		if s, ok := v.selected(); ok && len(s.BlockedBy) > 0 {
+			v.selectedID = s.BlockedBy[0]
+			v.showIdle = true
+			v.updateViewportContent()
+		}
+		return true, nil
+	case "c", "T":
+		s, ok := v.selected()
+		if !ok {
+			return true, nil
+		}
+		if s.Own {
+			v.statusMessage = "That is devhud's own session"
+			return true, nil
+		}
+		// the list refreshes while asking, so remember which session was picked
+		v.confirm = msg.String()
+		v.confirmID = s.ID
+		v.statusMessage = ""
+		return true, nil
+	}
+	return false, nil
+}
+
+func (v *ActivityView) updateConfirm(msg tea.KeyMsg) (*ActivityView, tea.Cmd) {
+	action := v.confirm
+	v.confirm = ""
+	if msg.String() != "y" && msg.String() != "Y" {
+		v.statusMessage = "Cancelled"
+		return v, nil
+	}
+	return v, v.signalCmd(action, v.confirmID)
+}
+
+// returns the sessions shown, leaving out idle ones unless asked for.
+func (v *ActivityView) visible() []db.Session {
+	if v.showIdle {
+		return v.sessions
+	}
+	var sessions []db.Session
+	for _, s := range v.sessions {
+		if !s.Idle() {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions
+}
+
+func (v *ActivityView) selected() (db.Session, bool) {
+	visible := v.visible()
+	if v.selectedIndex >= len(visible) {
+		return db.Session{}, false
+	}
+	return visible[v.selectedIndex], true
+}
+
+func (v *ActivityView) View() string {
+	if !v.ready {
+		return "Loading sessions..."
+	}
+
+	visible := v.visible()
+	title := fmt.Sprintf("Database Activity: %s", v.service.Name)
+	if hidden := len(v.sessions) - len(visible); hidden > 0 {
+		title += "  " + subtleStyle.Render(fmt.Sprintf("%d idle hidden", hidden))
+	}
+	if v.paused {
+		title += "  " + subtleStyle.Render("[paused]")
+	}
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render(title)
+
+	var hint string
+	switch v.confirm {
+	case "c":
+		hint = confirmDeleteStyle.Render(" CANCEL ") + fmt.Sprintf("  Cancel the query of session %d? [y/N]", v.confirmID)
+	case "T":
+		hint = confirmDeleteStyle.Render(" TERMINATE ") +
+			fmt.Sprintf("  Terminate session %d and roll back its transaction? [y/N]", v.confirmID)
+	default:
+		hint = "[esc] back  [↑/↓] navigate  [c]ancel query  [T]erminate session  [b]locker  [i]dle  [p]ause  [r]efresh"
+	}
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render(hint)
+	if v.statusMessage != "" && v.confirm == "" {
+		footer += "  " + subtleStyle.Render(v.statusMessage)
+	}
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
+}
+
+func (v *ActivityView) updateViewportContent() {
+	if v.error != nil {
+		return
+	}
+	visible := v.visible()
+	if len(visible) == 0 {
+		v.selectedIndex = 0
+		v.viewport.SetContent("No active sessions. Press i to show idle ones.")
+		return
+	}
+
+	// keep the selection on the same session across refreshes
+	v.selectedIndex = min(v.selectedIndex, len(visible)-1)
+	for i, s := range visible {
+		if s.ID == v.selectedID {
+			v.selectedIndex = i
+		}
+	}
+	v.selectedID = visible[v.selectedIndex].ID
+
+	width := max(v.viewport.Width-v.viewport.Style.GetHorizontalFrameSize(), 40)
+	lines := []string{subtleStyle.Render(fmt.Sprintf("  %-8s %-12s %-12s %-20s %8s %8s  %s",
+		"ID", "USER", "DATABASE", "STATE", "TIME", "TX", "QUERY"))}
+	for i, s := range visible {
+		line := fmt.Sprintf("%-8d %-12s %-12s %-20s %8s %8s  ",
+			s.ID,
+			truncate(s.User, 12),
+			truncate(s.Database, 12),
+			truncate(s.State, 20),
+			formatSessionDuration(s.Duration),
+			formatSessionDuration(s.Transaction))
+		line += truncate(strings.Join(strings.Fields(s.Query), " "), max(width-len(line)-2, 10))
+
+		switch {
+		case i == v.selectedIndex:
+			line = selectedRowStyle.Render("> " + line)
+		case s.Blocking > 0:
+			line = "  " + blockingSessionStyle.Render(line)
+		case len(s.BlockedBy) > 0:
+			line = "  " + blockedSessionStyle.Render(line)
+		default:
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", subtleStyle.Render(strings.Repeat("─", width-2)))
+	lines = append(lines, sessionDetails(visible[v.selectedIndex])...)
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+	// the header line comes before the first session
+	ensureLineVisible(&v.viewport, v.selectedIndex+1)
+}
+
+// describes the selected session below the list.
+func sessionDetails(s db.Session) []string {
+	var lines []string
+	add := func(label, value string) {
+		if value != "" {
+			lines = append(lines, fmt.Sprintf("%-10s %s", label, value))
+		}
+	}
+	client := s.Client
+	if s.Own {
+		client += " (devhud)"
+	}
+	add("Client", client)
+	add("Waiting", s.Wait)
+	if len(s.BlockedBy) > 0 {
+		ids := make([]string, len(s.BlockedBy))
+		for i, id := range s.BlockedBy {
+			ids[i] = fmt.Sprint(id)
+		}
+		add("Blocked", blockedSessionStyle.Render(fmt.Sprintf("by %s waiting for %s", strings.Join(ids, ", "), s.Lock)))
+	}
+	if s.Blocking > 0 {
+		add("Blocking", blockingSessionStyle.Render(fmt.Sprintf("%d session(s)", s.Blocking)))
+	}
+	if s.Query != "" {
+		lines = append(lines, "", s.Query)
+	}
+	return lines
+}
+
+// shows durations under a minute in seconds and longer ones as for uptimes.
+func formatSessionDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return formatUptime(d)
+}
+
+func (v *ActivityView) tickCmd() tea.Cmd {
+	gen := v.gen
+	return tea.Tick(activityRefresh, func(time.Time) tea.Msg { return ActivityTickMsg{Gen: gen} })
+}
+
+// lists the sessions; scheduled fetches start the next refresh when done.
+func (v *ActivityView) fetchSessionsCmd(scheduled bool) tea.Cmd {
+	client, gen := v.client, v.gen
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		sessions, err := client.Sessions(ctx)
+		return SessionsFetchedMsg{Gen: gen, Sessions: sessions, Scheduled: scheduled, Error: err}
+	}
+}
+
+// cancels the query of a session ("c") or terminates it ("T").
+func (v *ActivityView) signalCmd(action string, id int64) tea.Cmd {
+	client := v.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if action == "T" {
+			return SessionSignalledMsg{Terminate: true, ID: id, Error: client.TerminateSession(ctx, id)}
+		}
+		return SessionSignalledMsg{ID: id, Error: client.CancelQuery(ctx, id)}
+	}
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/alert"
+)
+
+// AlertsView lists crash alerts with their exit codes and last log lines.
+// It edits the App's alert list in place so dismissals persist.
+type AlertsView struct {
+	alerts        *[]alert.Alert
+	selectedIndex int
+	viewport      viewport.Model
+	shouldExit    bool
+}
+
+// creates an alerts view over the app's alert list.
+func NewAlertsView(alerts *[]alert.Alert, width, height int) *AlertsView {
+	vp := viewport.New(width-4, height-6)
+	vp.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#E74C3C")).
+		Padding(0, 1)
+
+	v := &AlertsView{
+		alerts:   alerts,
+		viewport: vp,
+	}
+	v.updateViewportContent()
+	return v
+}
+
+func (v *AlertsView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *AlertsView) Update(msg tea.Msg) (*AlertsView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return v, tea.Quit
+		case "esc", "!":
+			v.shouldExit = true
+			return v, nil
+		case "up", "k":
+			if v.selectedIndex > 0 {
+				v.selectedIndex--
+			}
+		case "down", "j":
+			if v.selectedIndex < len(*v.alerts)-1 {
+				v.selectedIndex++
+			}
+		case "x":
+			v.dismiss(v.selectedIndex)
+		case "X":
+			*v.alerts = nil
+			v.selectedIndex = 0
+		}
+		v.updateViewportContent()
+		return v, nil
+
+	case tea.WindowSizeMsg:
+		v.viewport.Width = msg.Width - 4
+		v.viewport.Height = msg.Height - 6
+	}
+
+	v.updateViewportContent()
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func (v *AlertsView) View() string {
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#E74C3C")).
+		Bold(true).
+		Render(fmt.Sprintf("Alerts (%d)", len(*v.alerts)))
+
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render("[esc] back  [↑/↓] select  [x] dismiss  [X] dismiss all")
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
+}
+
+func (v *AlertsView) dismiss(index int) {
+	alerts := *v.alerts
+	// displayed newest first
+	i := len(alerts) - 1 - index
+	if i < 0 || i >= len(alerts) {
+		return
+	}
+	*v.alerts = append(alerts[:i], alerts[i+1:]...)
+	if v.selectedIndex >= len(*v.alerts) && v.selectedIndex > 0 {
+		v.selectedIndex--
+	}
+}
+
+func (v *AlertsView) updateViewportContent() {
+	alerts := *v.alerts
+	if len(alerts) == 0 {
+		v.viewport.SetContent("No alerts")
+		return
+	}
+
+	logStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	var lines []string
+	selectedLine := 0
+	for idx := 0; idx < len(alerts); idx++ {
+		a := alerts[len(alerts)-1-idx]
+		prefix := "  "
+		if idx == v.selectedIndex {
+			prefix = "> "
+			selectedLine = len(lines)
+		}
+		lines = append(lines, fmt.Sprintf("%s%s  %-13s %s", prefix, a.At.Format("15:04:05"), a.Kind, a.Message))
+		if idx != v.selectedIndex {
+			continue
+		}
+		if len(a.LastLogs) == 0 {
+			lines = append(lines, logStyle.Render("    (no log lines captured)"))
+		}
+		for _, l := range a.LastLogs {
+			lines = append(lines, logStyle.Render("    "+l))
+		}
+		lines = append(lines, "")
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+	ensureLineVisible(&v.viewport, selectedLine)
+}
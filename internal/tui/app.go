@@ -3,19 +3,24 @@ package tui
 import (
 	"context"
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/alert"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/db"
 	"github.com/eanda22/devhud/internal/docker"
+	"github.com/eanda22/devhud/internal/metrics"
 	"github.com/eanda22/devhud/internal/process"
 	"github.com/eanda22/devhud/internal/scanner"
 	"github.com/eanda22/devhud/internal/service"
 )
 
 type ScanCompleteMsg struct {
+	// Store replaces the app's services. It is nil when the scan failed.
+	Store    *service.Store
 	Services []*service.Service
 	Error    error
 }
@@ -28,8 +33,11 @@ type DiskUsageMsg struct {
 }
 
 type App struct {
-	services         *service.Store
-	scanner          *scanner.Scanner
+	services *service.Store
+	scanner  *scanner.Scanner
+	// scanning is set while a scan runs; rescan asks for another when it ends.
+	scanning         bool
+	rescan           bool
 	dockerClient     *docker.Client
 	ticker           *time.Ticker
 	selectedIndex    int
@@ -41,8 +49,11 @@ type App struct {
 	logsView         *LogsView
 	actionMenuView   *ActionMenuView
 	inspectView      *InspectView
+	snapshotView     *SnapshotView
 	dbTablesView     *DBTablesView
 	dbDataView       *DBDataView
+	envView          *EnvView
+	metricsView      *MetricsView
 	helpView         *HelpView
 	width            int
 	height           int
@@ -56,8 +67,37 @@ type App struct {
 	searchInput      textinput.Model
 	searchFilter     string
 	commandBar       *CommandBar
+	history          *metrics.History
+	config           *config.Config
+	detector         *alert.Detector
+	alerts           []alert.Alert
+	alertsView       *AlertsView
+	redisView        *RedisView
+	queryView        *QueryView
+	schemaView       *SchemaView
+	activityView     *ActivityView
+	cleanupView      *CleanupView
+	shellView        *ShellView
+	imagesPanel      *ImagesPanel
+	volumesPanel     *VolumesPanel
+	networksPanel    *NetworksPanel
+	panels           map[string]resourcePanel
+	quitOnDBExit     bool
 }
 
+// History keeps 30 minutes of samples at the 2 second refresh interval.
+const (
+	historyCapacity  = 900
+	historyRetention = 30 * time.Minute
+)
+
+// A container restarting this many times within the window is reported as a restart loop.
+const (
+	restartLoopThreshold = 3
+	restartLoopWindow    = 2 * time.Minute
+	alertLogLines        = 20
+)
+
 type Focus int
 
 const (
@@ -73,9 +113,9 @@ const (
 	ModeSearch
 )
 
-func NewApp() (*App, error) {
+func NewApp(cfg *config.Config) (*App, error) {
 	store := service.NewStore()
-	scan, err := scanner.NewScanner(store)
+	scan, err := scanner.NewScanner()
 	if err != nil {
 		return nil, fmt.Errorf("scanner: %w", err)
 	}
@@ -87,38 +127,96 @@ func NewApp() (*App, error) {
 	si.CharLimit = 64
 	si.Prompt = "/"
 
+	images := NewImagesPanel(dockerClient)
+	volumes := NewVolumesPanel(dockerClient)
+	networks := NewNetworksPanel(dockerClient)
+
 	return &App{
 		services:       store,
 		scanner:        scan,
 		dockerClient:   dockerClient,
 		mode:           "dashboard",
-		categories:     []string{"Containers", "Local Procs"},
+		categories:     []string{"Containers", "Local Procs", "Images", "Volumes", "Networks"},
 		activeCatIndex: 0,
 		focus:          FocusSidebar,
 		searchInput:    si,
 		commandBar:     newCommandBar(),
+		history:        metrics.NewHistory(historyCapacity, historyRetention),
+		config:         cfg,
+		detector:       alert.NewDetector(restartLoopThreshold, restartLoopWindow),
+		imagesPanel:    images,
+		volumesPanel:   volumes,
+		networksPanel:  networks,
+		panels: map[string]resourcePanel{
+			"Images":   images,
+			"Volumes":  volumes,
+			"Networks": networks,
+		},
 	}, nil
 }
 
 func (a *App) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		a.scanCmd(),
 		a.tickCmd(),
 		a.fetchDiskUsageCmd(),
-	)
+	}
+	if a.mode == "db_tables" && a.dbTablesView != nil {
+		cmds = append(cmds, a.dbTablesView.Init())
+	}
+	if a.mode == "redis" && a.redisView != nil {
+		cmds = append(cmds, a.redisView.Init())
+	}
+	return tea.Batch(cmds...)
 }
 
-// performs service discovery.
+// starts the app in the database browser for svc and quits when the browser is closed.
+func (a *App) OpenDatabase(svc *service.Service) {
+	a.dbTablesView = NewDBTablesView(svc, a.dockerClient, a.width, a.height)
+	a.mode = "db_tables"
+	a.quitOnDBExit = true
+}
+
+// starts the app in the database browser for a saved connection and quits
+// when the browser is closed.
+func (a *App) OpenConnection(conn config.Connection) {
+	a.showConnection(conn)
+	a.quitOnDBExit = true
+}
+
+// switches to the browser for a saved connection. It connects once initialized.
+func (a *App) showConnection(conn config.Connection) {
+	svc := connectionService(conn)
+	if conn.Type == "redis" {
+		a.redisView = NewRedisView(svc, a.dockerClient, a.width, a.height)
+		a.redisView.connection = &conn
+		a.mode = "redis"
+		return
+	}
+	a.dbTablesView = NewDBTablesView(svc, a.dockerClient, a.width, a.height)
+	a.dbTablesView.connection = &conn
+	a.mode = "db_tables"
+}
+
+// performs service discovery. Only one scan runs at a time; a scan requested
+// while one is running starts when it completes.
 func (a *App) scanCmd() tea.Cmd {
+	if a.scanning {
+		a.rescan = true
+		return nil
+	}
+	a.scanning = true
+	previous := a.services.GetAll()
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		if err := a.scanner.Scan(ctx); err != nil {
+		store, err := a.scanner.Scan(ctx, previous)
+		if err != nil {
 			return ScanCompleteMsg{Error: err}
 		}
 
-		return ScanCompleteMsg{Services: a.services.GetAll()}
+		return ScanCompleteMsg{Store: store, Services: store.GetAll()}
 	}
 }
 
@@ -202,6 +300,22 @@ func (a *App) fetchDiskUsageCmd() tea.Cmd {
 	}
 }
 
+// captures the last log lines of a dead container and sends the desktop notification.
+func (a *App) enrichAlertCmd(al alert.Alert) tea.Cmd {
+	return func() tea.Msg {
+		if al.ContainerID != "" && a.dockerClient != nil {
+			if logs, err := a.dockerClient.GetLogs(al.ContainerID, alertLogLines); err == nil {
+				al.LastLogs = logs
+			}
+		}
+		var err error
+		if a.config != nil && a.config.Notify.Command != "" {
+			err = alert.Notify(a.config.Notify.Command, al)
+		}
+		return AlertEnrichedMsg{Alert: al, Error: err}
+	}
+}
+
 // executes action selected from action menu.
 func (a *App) executeActionFromMenu(actionName string, svc *service.Service) tea.Cmd {
 	switch actionName {
@@ -212,12 +326,14 @@ func (a *App) executeActionFromMenu(actionName string, svc *service.Service) tea
 
 	case "Restart Container":
 		a.mode = "dashboard"
+		a.detector.Expect(svc.ID)
 		a.statusMessage = "Restarting container..."
 		a.operatingOnID = svc.ID
 		return a.restartServiceCmd(svc.ContainerID)
 
 	case "Stop Container":
 		a.mode = "dashboard"
+		a.detector.Expect(svc.ID)
 		a.statusMessage = "Stopping container..."
 		a.operatingOnID = svc.ID
 		return a.stopServiceCmd(svc.ContainerID)
@@ -230,34 +346,54 @@ func (a *App) executeActionFromMenu(actionName string, svc *service.Service) tea
 
 	case "Kill Process":
 		a.mode = "dashboard"
+		a.detector.Expect(svc.ID)
 		a.statusMessage = "Stopping process..."
 		a.operatingOnID = svc.ID
 		return a.stopProcessCmd(svc.PID)
 
 	case "Browse Database":
+		if svc.DBType == "redis" {
+			a.redisView = NewRedisView(svc, a.dockerClient, a.width, a.height)
+			a.mode = "redis"
+			return a.redisView.Init()
+		}
 		a.dbTablesView = NewDBTablesView(svc, a.dockerClient, a.width, a.height)
 		a.mode = "db_tables"
 		return a.dbTablesView.Init()
 
+	case "Snapshots":
+		a.snapshotView = NewSnapshotView(svc, a.dockerClient, a.width, a.height)
+		a.mode = "snapshots"
+		return a.snapshotView.Init()
+
 	case "Delete Container":
 		a.mode = "dashboard"
 		a.confirmOperation = svc.ContainerID
 		return nil
 
+	case "Environment":
+		a.envView = NewEnvView(svc, a.dockerClient, a.services.GetAll(), a.width, a.height)
+		a.mode = "env"
+		return a.envView.Init()
+
+	case "View Metrics":
+		a.metricsView = NewMetricsView(svc, a.history, a.width, a.height)
+		a.mode = "metrics"
+		return a.metricsView.Init()
+
 	case "Inspect JSON":
 		a.inspectView = NewInspectView(svc, a.dockerClient, a.width, a.height)
 		a.mode = "inspect"
 		return a.inspectView.Init()
 
-	case "Open Shell (/bin/sh)":
+	case shellAction:
 		a.mode = "dashboard"
-		c := exec.Command("docker", "exec", "-it", svc.ContainerID, "/bin/sh")
-		return tea.ExecProcess(c, func(err error) tea.Msg {
-			if err != nil {
-				return OperationCompleteMsg{Success: false, Message: fmt.Sprintf("Shell failed: %v", err)}
-			}
-			return TickMsg{}
-		})
+		return a.execCmd(svc, docker.ExecOptions{}, "Shell")
+
+	case shellAsAction:
+		a.shellView = NewShellView(svc, a.dockerClient, a.width, a.height)
+		a.mode = "shell"
+		return a.shellView.Init()
 
 	default:
 		a.mode = "dashboard"
@@ -265,6 +401,13 @@ func (a *App) executeActionFromMenu(actionName string, svc *service.Service) tea
 	}
 }
 
+// opens the cleanup of docker disk usage.
+func (a *App) openCleanup() tea.Cmd {
+	a.cleanupView = NewCleanupView(a.dockerClient, a.width, a.height)
+	a.mode = "cleanup"
+	return a.cleanupView.Init()
+}
+
 // returns services filtered by active category.
 func (a *App) getFilteredServices() []*service.Service {
 	if a.searchFilter != "" {
@@ -304,6 +447,20 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.height = wmsg.Height
 	}
 
+	// Background refresh keeps running while full-screen views are open.
+	switch msg.(type) {
+	case TickMsg, ScanCompleteMsg, DiskUsageMsg, AlertEnrichedMsg,
+		ImagesListedMsg, ImageActionMsg, VolumesListedMsg, VolumeActionMsg, NetworksListedMsg:
+		return a.updateMessages(msg)
+	}
+
+	// a connection that completes after its browser was closed has no view to
+	// close it
+	if msg, ok := msg.(RedisConnectedMsg); ok && msg.Client != nil && (a.mode != "redis" || a.redisView == nil) {
+		msg.Client.Close()
+		return a, nil
+	}
+
 	if cmd, handled := a.updateFullScreenView(msg); handled {
 		return a, cmd
 	}
@@ -344,6 +501,12 @@ func (a *App) updateFullScreenView(msg tea.Msg) (tea.Cmd, bool) {
 		a.actionMenuView = updatedView
 
 		if a.actionMenuView.shouldExit {
+			if c := a.actionMenuView.execCommand; c != nil {
+				a.mode = "dashboard"
+				actionCmd := a.runExecCommand(a.actionMenuView.service, *c)
+				a.actionMenuView = nil
+				return actionCmd, true
+			}
 			if a.actionMenuView.executeAction != "" {
 				actionCmd := a.executeActionFromMenu(a.actionMenuView.executeAction, a.actionMenuView.service)
 				a.actionMenuView = nil
@@ -379,19 +542,72 @@ func (a *App) updateFullScreenView(msg tea.Msg) (tea.Cmd, bool) {
 		return cmd, true
 	}
 
+	if a.mode == "snapshots" && a.snapshotView != nil {
+		updatedView, cmd := a.snapshotView.Update(msg)
+		a.snapshotView = updatedView
+		if a.snapshotView.shouldExit {
+			a.mode = "dashboard"
+			a.snapshotView = nil
+			return a.scanCmd(), true
+		}
+		return cmd, true
+	}
+
+	if a.mode == "env" && a.envView != nil {
+		updatedView, cmd := a.envView.Update(msg)
+		a.envView = updatedView
+		if a.envView.shouldExit {
+			a.mode = "dashboard"
+			a.envView = nil
+			return a.scanCmd(), true
+		}
+		return cmd, true
+	}
+
+	if a.mode == "alerts" && a.alertsView != nil {
+		updatedView, cmd := a.alertsView.Update(msg)
+		a.alertsView = updatedView
+		if a.alertsView.shouldExit {
+			a.mode = "dashboard"
+			a.alertsView = nil
+			return nil, true
+		}
+		return cmd, true
+	}
+
+	if a.mode == "metrics" && a.metricsView != nil {
+		updatedView, cmd := a.metricsView.Update(msg)
+		a.metricsView = updatedView
+		if a.metricsView.shouldExit {
+			a.mode = "dashboard"
+			a.metricsView = nil
+			return nil, true
+		}
+		return cmd, true
+	}
+
 	if a.mode == "db_tables" && a.dbTablesView != nil {
 		updatedView, cmd := a.dbTablesView.Update(msg)
 		a.dbTablesView = updatedView
 
 		if a.dbTablesView.shouldExit {
 			a.mode = "dashboard"
+			a.dbTablesView.stopCounting()
 			if a.dbTablesView.dbClient != nil {
 				a.dbTablesView.dbClient.Close()
 			}
 			a.dbTablesView = nil
+			if a.quitOnDBExit {
+				return tea.Quit, true
+			}
 			return a.scanCmd(), true
 		}
 
+		if a.dbTablesView.openTable != "" || a.dbTablesView.openQuery || a.dbTablesView.openSchema != "" || a.dbTablesView.openActivity {
+			// counts resume when the table list is shown again
+			a.dbTablesView.stopCounting()
+		}
+
 		if a.dbTablesView.openTable != "" {
 			tableName := a.dbTablesView.openTable
 			a.dbDataView = NewDBDataView(a.dbTablesView.service, tableName, a.dbTablesView.dbClient, a.width, a.height)
@@ -400,6 +616,79 @@ func (a *App) updateFullScreenView(msg tea.Msg) (tea.Cmd, bool) {
 			return a.dbDataView.Init(), true
 		}
 
+		if a.dbTablesView.openQuery {
+			a.dbTablesView.openQuery = false
+			client := a.dbTablesView.dbClient.(*db.Client)
+			a.queryView = NewQueryView(a.dbTablesView.service, client, a.width, a.height)
+			a.mode = "query"
+			return a.queryView.Init(), true
+		}
+
+		if a.dbTablesView.openSchema != "" {
+			client := a.dbTablesView.dbClient.(*db.Client)
+			a.schemaView = NewSchemaView(a.dbTablesView.service, client, a.dbTablesView.openSchema, a.width, a.height)
+			a.mode = "db_schema"
+			a.dbTablesView.openSchema = ""
+			return a.schemaView.Init(), true
+		}
+
+		if a.dbTablesView.openActivity {
+			a.dbTablesView.openActivity = false
+			client := a.dbTablesView.dbClient.(*db.Client)
+			a.activityView = NewActivityView(a.dbTablesView.service, client, a.width, a.height)
+			a.mode = "db_activity"
+			return a.activityView.Init(), true
+		}
+
+		return cmd, true
+	}
+
+	if a.mode == "query" && a.queryView != nil {
+		updatedView, cmd := a.queryView.Update(msg)
+		a.queryView = updatedView
+		if a.queryView.shouldExit {
+			a.mode = "db_tables"
+			a.queryView.Close()
+			a.queryView = nil
+			return a.dbTablesView.startCounting(), true
+		}
+		return cmd, true
+	}
+
+	if a.mode == "db_schema" && a.schemaView != nil {
+		updatedView, cmd := a.schemaView.Update(msg)
+		a.schemaView = updatedView
+		if a.schemaView.shouldExit {
+			a.mode = "db_tables"
+			a.schemaView = nil
+			return a.dbTablesView.startCounting(), true
+		}
+		return cmd, true
+	}
+
+	if a.mode == "db_activity" && a.activityView != nil {
+		updatedView, cmd := a.activityView.Update(msg)
+		a.activityView = updatedView
+		if a.activityView.shouldExit {
+			a.mode = "db_tables"
+			a.activityView = nil
+			return a.dbTablesView.startCounting(), true
+		}
+		return cmd, true
+	}
+
+	if a.mode == "redis" && a.redisView != nil {
+		updatedView, cmd := a.redisView.Update(msg)
+		a.redisView = updatedView
+		if a.redisView.shouldExit {
+			a.mode = "dashboard"
+			a.redisView.Close()
+			a.redisView = nil
+			if a.quitOnDBExit {
+				return tea.Quit, true
+			}
+			return a.scanCmd(), true
+		}
 		return cmd, true
 	}
 
@@ -409,10 +698,41 @@ func (a *App) updateFullScreenView(msg tea.Msg) (tea.Cmd, bool) {
 
 		if a.dbDataView.shouldExit {
 			a.mode = "db_tables"
+			a.dbDataView.export.Stop()
 			a.dbDataView = nil
+			return a.dbTablesView.startCounting(), true
+		}
+
+		return cmd, true
+	}
+
+	if a.mode == "shell" && a.shellView != nil {
+		updatedView, cmd := a.shellView.Update(msg)
+		a.shellView = updatedView
+		if a.shellView.shouldExit {
+			a.mode = "dashboard"
+			svc, run := a.shellView.service, a.shellView.run
+			a.shellView = nil
+			if run != nil {
+				return a.execCmd(svc, *run, "Shell"), true
+			}
 			return nil, true
 		}
+		return cmd, true
+	}
 
+	if a.mode == "cleanup" && a.cleanupView != nil {
+		updatedView, cmd := a.cleanupView.Update(msg)
+		a.cleanupView = updatedView
+		if a.cleanupView.shouldExit {
+			a.mode = "dashboard"
+			a.cleanupView = nil
+			cmds := []tea.Cmd{a.scanCmd(), a.fetchDiskUsageCmd()}
+			if panel := a.activePanel(); panel != nil {
+				cmds = append(cmds, panel.Refresh())
+			}
+			return tea.Batch(cmds...), true
+		}
 		return cmd, true
 	}
 
@@ -433,9 +753,37 @@ func (a *App) updateFullScreenView(msg tea.Msg) (tea.Cmd, bool) {
 func (a *App) updateMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ScanCompleteMsg:
+		a.scanning = false
 		if msg.Error != nil {
 			a.lastError = msg.Error
 		}
+		if msg.Store != nil {
+			a.services = msg.Store
+		}
+		var cmds []tea.Cmd
+		if a.rescan {
+			a.rescan = false
+			cmds = append(cmds, a.scanCmd())
+		}
+		now := time.Now()
+		a.history.Record(now, msg.Services)
+		if msg.Error == nil {
+			for _, al := range a.detector.Check(now, msg.Services) {
+				a.alerts = append(a.alerts, al)
+				cmds = append(cmds, a.enrichAlertCmd(al))
+			}
+		}
+		return a, tea.Batch(cmds...)
+
+	case AlertEnrichedMsg:
+		for i := range a.alerts {
+			if a.alerts[i].ID == msg.Alert.ID {
+				a.alerts[i].LastLogs = msg.Alert.LastLogs
+			}
+		}
+		if msg.Error != nil {
+			a.statusMessage = fmt.Sprintf("Notification failed: %v", msg.Error)
+		}
 		return a, nil
 
 	case OperationCompleteMsg:
@@ -444,13 +792,32 @@ func (a *App) updateMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.scanCmd()
 
 	case TickMsg:
-		return a, a.scanCmd()
+		// a slow scan skips ticks rather than queueing more scans behind it
+		if a.scanning {
+			return a, a.tickCmd()
+		}
+		return a, tea.Batch(a.scanCmd(), a.tickCmd())
 
 	case DiskUsageMsg:
 		if msg.Error == nil {
 			a.dockerDiskUsage = msg.Usage
 		}
 		return a, nil
+
+	case ImagesListedMsg:
+		return a, a.imagesPanel.Update(msg)
+
+	case ImageActionMsg:
+		return a, tea.Batch(a.imagesPanel.Update(msg), a.fetchDiskUsageCmd())
+
+	case VolumesListedMsg:
+		return a, a.volumesPanel.Update(msg)
+
+	case VolumeActionMsg:
+		return a, a.volumesPanel.Update(msg)
+
+	case NetworksListedMsg:
+		return a, a.networksPanel.Update(msg)
 	}
 
 	return a, nil
@@ -467,6 +834,11 @@ func (a *App) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if a.selectedIndex < len(services) {
 				a.operatingOnID = services[a.selectedIndex].ID
 			}
+			for _, svc := range services {
+				if svc.ContainerID == containerID {
+					a.detector.Expect(svc.ID)
+				}
+			}
 			a.confirmOperation = ""
 			a.statusMessage = "Deleting container..."
 			return a, a.deleteServiceCmd(containerID)
@@ -479,6 +851,11 @@ func (a *App) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	panel := a.activePanel()
+	if panel != nil && panel.Prompt() != "" {
+		return a, panel.Update(keyMsg)
+	}
+
 	switch keyMsg.String() {
 	case "esc":
 		if a.searchFilter != "" {
@@ -493,48 +870,46 @@ func (a *App) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ":":
 		a.inputMode = ModeCommand
 		return a, a.commandBar.Focus()
+	case "!":
+		a.alertsView = NewAlertsView(&a.alerts, a.width, a.height)
+		a.mode = "alerts"
+		return a, a.alertsView.Init()
 	case "?":
 		a.helpView = NewHelpView(a.width, a.height)
 		a.mode = "help"
 		return a, a.helpView.Init()
+	case "C":
+		return a, a.openCleanup()
 	case "/":
 		a.inputMode = ModeSearch
 		a.searchInput.SetValue(a.searchFilter)
 		a.searchInput.Focus()
 		return a, a.searchInput.Cursor.BlinkCmd()
-	case "1":
-		a.activeCatIndex = 0
-		a.selectedIndex = 0
-		a.focus = FocusMainList
-		return a, a.fetchDiskUsageCmd()
-	case "2":
-		a.activeCatIndex = 1
-		a.selectedIndex = 0
+	case "1", "2", "3", "4", "5":
 		a.focus = FocusMainList
-		return a, nil
+		return a, a.selectCategory(int(keyMsg.String()[0] - '1'))
 	}
 
 	if a.focus == FocusSidebar {
 		switch keyMsg.String() {
 		case "up", "k":
 			if a.activeCatIndex > 0 {
-				a.activeCatIndex--
-				a.selectedIndex = 0
-				if a.activeCatIndex == 0 {
-					return a, a.fetchDiskUsageCmd()
-				}
+				return a, a.selectCategory(a.activeCatIndex - 1)
 			}
 		case "down", "j":
 			if a.activeCatIndex < len(a.categories)-1 {
-				a.activeCatIndex++
-				a.selectedIndex = 0
-				if a.activeCatIndex == 0 {
-					return a, a.fetchDiskUsageCmd()
-				}
+				return a, a.selectCategory(a.activeCatIndex + 1)
 			}
 		case "enter", "right", "l":
 			a.focus = FocusMainList
 		}
+	} else if panel != nil {
+		switch keyMsg.String() {
+		case "left", "h":
+			a.focus = FocusSidebar
+			return a, nil
+		}
+		return a, panel.Update(keyMsg)
 	} else {
 		if keyMsg.String() != "g" {
 			a.waitingForG = false
@@ -555,7 +930,7 @@ func (a *App) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			services := a.getFilteredServices()
 			if a.selectedIndex < len(services) {
 				svc := services[a.selectedIndex]
-				a.actionMenuView = NewActionMenuView(svc, a.dockerClient, a.width, a.height)
+				a.actionMenuView = NewActionMenuView(svc, a.dockerClient, a.execCommands(svc), a.width, a.height)
 				a.mode = "action_menu"
 				return a, a.actionMenuView.Init()
 			}
@@ -611,6 +986,22 @@ func (a *App) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			a.statusMessage = "Inspect not available"
 			return a, nil
+		case "e":
+			svc := a.selectedService()
+			if svc == nil {
+				return a, nil
+			}
+			if isDockerOrCompose(svc) || svc.PID != 0 {
+				return a, a.executeActionFromMenu("Environment", svc)
+			}
+			a.statusMessage = "Environment not available"
+			return a, nil
+		case "m":
+			svc := a.selectedService()
+			if svc == nil {
+				return a, nil
+			}
+			return a, a.executeActionFromMenu("View Metrics", svc)
 		case "b":
 			svc := a.selectedService()
 			if svc == nil {
@@ -704,12 +1095,42 @@ func (a *App) View() string {
 	if a.mode == "inspect" && a.inspectView != nil {
 		return a.inspectView.View()
 	}
+	if a.mode == "snapshots" && a.snapshotView != nil {
+		return a.snapshotView.View()
+	}
+	if a.mode == "env" && a.envView != nil {
+		return a.envView.View()
+	}
+	if a.mode == "alerts" && a.alertsView != nil {
+		return a.alertsView.View()
+	}
+	if a.mode == "metrics" && a.metricsView != nil {
+		return a.metricsView.View()
+	}
 	if a.mode == "db_tables" && a.dbTablesView != nil {
 		return a.dbTablesView.View()
 	}
+	if a.mode == "query" && a.queryView != nil {
+		return a.queryView.View()
+	}
+	if a.mode == "db_schema" && a.schemaView != nil {
+		return a.schemaView.View()
+	}
+	if a.mode == "redis" && a.redisView != nil {
+		return a.redisView.View()
+	}
+	if a.mode == "db_activity" && a.activityView != nil {
+		return a.activityView.View()
+	}
 	if a.mode == "db_data" && a.dbDataView != nil {
 		return a.dbDataView.View()
 	}
+	if a.mode == "cleanup" && a.cleanupView != nil {
+		return a.cleanupView.View()
+	}
+	if a.mode == "shell" && a.shellView != nil {
+		return a.shellView.View()
+	}
 	if a.mode == "help" && a.helpView != nil {
 		return a.helpView.View()
 	}
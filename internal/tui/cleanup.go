@@ -0,0 +1,302 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/docker"
+)
+
+// pruneLabels names each kind of prune in the cleanup.
+var pruneLabels = map[string]string{
+	docker.PruneContainers: "Stopped containers",
+	docker.PruneImages:     "Dangling images",
+	docker.PruneVolumes:    "Unused volumes",
+	docker.PruneBuildCache: "Build cache",
+}
+
+// PruneResult is the outcome of one kind of prune.
+type PruneResult struct {
+	Kind      string
+	Reclaimed uint64
+	Error     error
+}
+
+// CleanupView breaks down the space docker could reclaim, lets the user tick
+// what to prune, previews every object that would be deleted and runs the
+// prunes after confirmation. Unused volumes start unticked since pruning them
+// loses their data.
+type CleanupView struct {
+	dockerClient *docker.Client
+	cleanup      *docker.Cleanup
+	plans        []docker.PrunePlan
+	selected     map[string]bool
+	cursor       int
+	// mode is "plan", "confirm" or "done".
+	mode       string
+	busy       string
+	results    []PruneResult
+	viewport   viewport.Model
+	error      error
+	ready      bool
+	shouldExit bool
+}
+
+// creates the cleanup view.
+func NewCleanupView(dockerClient *docker.Client, width, height int) *CleanupView {
+	vp := viewport.New(width-4, height-6)
+	vp.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+
+	return &CleanupView{
+		dockerClient: dockerClient,
+		selected: map[string]bool{
+			docker.PruneContainers: true,
+			docker.PruneImages:     true,
+			docker.PruneBuildCache: true,
+		},
+		mode:     "plan",
+		viewport: vp,
+	}
+}
+
+func (v *CleanupView) Init() tea.Cmd {
+	return v.loadCmd()
+}
+
+func (v *CleanupView) Update(msg tea.Msg) (*CleanupView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return v, tea.Quit
+		}
+		if v.busy != "" {
+			return v, nil
+		}
+		if v.mode == "confirm" {
+			return v.updateConfirm(msg)
+		}
+		if handled, keyCmd := v.updateKeys(msg); handled {
+			return v, keyCmd
+		}
+
+	case CleanupLoadedMsg:
+		v.ready = true
+		v.busy = ""
+		v.error = msg.Error
+		if msg.Error == nil {
+			v.cleanup = msg.Cleanup
+			v.plans = v.cleanup.Plan(v.selected)
+		}
+		v.updateViewportContent()
+		return v, nil
+
+	case CleanupDoneMsg:
+		v.busy = ""
+		v.mode = "done"
+		v.results = msg.Results
+		v.updateViewportContent()
+		return v, nil
+
+	case tea.WindowSizeMsg:
+		v.viewport.Width = msg.Width - 4
+		v.viewport.Height = msg.Height - 6
+	}
+
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func (v *CleanupView) updateKeys(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return true, tea.Quit
+	case "esc":
+		v.shouldExit = true
+		return true, nil
+	case "r":
+		v.mode = "plan"
+		v.results = nil
+		v.busy = "Reading disk usage..."
+		return true, v.loadCmd()
+	}
+
+	if v.mode != "plan" || v.cleanup == nil {
+		return false, nil
+	}
+	switch msg.String() {
+	case "up", "k":
+		v.cursor = moveSelection(v.cursor, -1, len(v.plans))
+	case "down", "j":
+		v.cursor = moveSelection(v.cursor, 1, len(v.plans))
+	case " ", "x":
+		kind := v.plans[v.cursor].Kind
+		v.selected[kind] = !v.selected[kind]
+		v.plans = v.cleanup.Plan(v.selected)
+	case "enter":
+		if _, count := v.selectedTotal(); count > 0 {
+			v.mode = "confirm"
+		}
+	default:
+		return false, nil
+	}
+	v.updateViewportContent()
+	return true, nil
+}
+
+func (v *CleanupView) updateConfirm(msg tea.KeyMsg) (*CleanupView, tea.Cmd) {
+	v.mode = "plan"
+	if msg.String() != "y" && msg.String() != "Y" {
+		return v, nil
+	}
+	var kinds []string
+	for _, p := range v.plans {
+		if v.selected[p.Kind] && len(p.Items) > 0 {
+			kinds = append(kinds, p.Kind)
+		}
+	}
+	v.busy = "Pruning..."
+	return v, v.pruneCmd(kinds)
+}
+
+// sums the space and objects the ticked prunes delete.
+func (v *CleanupView) selectedTotal() (int64, int) {
+	var size int64
+	var count int
+	for _, p := range v.plans {
+		if v.selected[p.Kind] {
+			size += p.Size
+			count += len(p.Items)
+		}
+	}
+	return size, count
+}
+
+func (v *CleanupView) View() string {
+	if !v.ready {
+		return "Reading Docker disk usage..."
+	}
+
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render("Docker Cleanup")
+
+	var hint string
+	switch v.mode {
+	case "confirm":
+		size, count := v.selectedTotal()
+		hint = confirmDeleteStyle.Render(" PRUNE ") +
+			fmt.Sprintf("  Delete %d objects to reclaim %s? [y/N]", count, formatBytes(size))
+	case "done":
+		hint = "[esc] back  [r] check again"
+	default:
+		hint = "[esc] back  [j/k] select  [space] tick  [enter] prune  [r]efresh"
+	}
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render(hint)
+	if v.busy != "" {
+		footer += "  " + subtleStyle.Render(v.busy)
+	}
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
+}
+
+func (v *CleanupView) updateViewportContent() {
+	if v.error != nil {
+		v.viewport.SetContent("Error reading disk usage: " + v.error.Error())
+		return
+	}
+	if v.mode == "done" {
+		v.viewport.SetContent(v.summary())
+		return
+	}
+
+	lines := []string{"Reclaimable space", ""}
+	for i, p := range v.plans {
+		box := "[ ]"
+		if v.selected[p.Kind] {
+			box = "[x]"
+		}
+		line := fmt.Sprintf("%s %-20s %5d  %10s", box, pruneLabels[p.Kind], len(p.Items), formatBytes(p.Size))
+		if p.Kind == docker.PruneVolumes {
+			line += subtleStyle.Render("  deletes the data in them")
+		}
+		if i == v.cursor {
+			lines = append(lines, selectedRowStyle.Render("> "+line))
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	size, count := v.selectedTotal()
+	lines = append(lines, "", fmt.Sprintf("Selected: %s from %d objects", formatBytes(size), count))
+	if count > 0 {
+		lines = append(lines, "", "Will delete")
+	}
+	for _, p := range v.plans {
+		if !v.selected[p.Kind] || len(p.Items) == 0 {
+			continue
+		}
+		lines = append(lines, "  "+pruneLabels[p.Kind])
+		for _, item := range p.Items {
+			itemSize := "-"
+			if item.Size >= 0 {
+				itemSize = formatBytes(item.Size)
+			}
+			lines = append(lines, fmt.Sprintf("    %-48s %10s", truncate(item.Name, 48), itemSize))
+		}
+	}
+
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+	ensureLineVisible(&v.viewport, v.cursor+2)
+}
+
+// reports the space each prune reclaimed.
+func (v *CleanupView) summary() string {
+	if len(v.results) == 0 {
+		return "Nothing to prune."
+	}
+	lines := []string{"Cleanup finished", ""}
+	var total uint64
+	for _, r := range v.results {
+		if r.Error != nil {
+			lines = append(lines, fmt.Sprintf("  %-20s failed: %v", pruneLabels[r.Kind], r.Error))
+			continue
+		}
+		total += r.Reclaimed
+		lines = append(lines, fmt.Sprintf("  %-20s reclaimed %s", pruneLabels[r.Kind], formatBytes(int64(r.Reclaimed))))
+	}
+	lines = append(lines, "", fmt.Sprintf("Total reclaimed: %s", formatBytes(int64(total))))
+	return strings.Join(lines, "\n")
+}
+
+func (v *CleanupView) loadCmd() tea.Cmd {
+	return func() tea.Msg {
+		if v.dockerClient == nil {
+			return CleanupLoadedMsg{Error: fmt.Errorf("docker unavailable")}
+		}
+		cleanup, err := v.dockerClient.LoadCleanup()
+		return CleanupLoadedMsg{Cleanup: cleanup, Error: err}
+	}
+}
+
+// runs the prunes in order, carrying on past failures.
+func (v *CleanupView) pruneCmd(kinds []string) tea.Cmd {
+	return func() tea.Msg {
+		var results []PruneResult
+		for _, kind := range kinds {
+			reclaimed, err := v.dockerClient.Prune(kind)
+			results = append(results, PruneResult{Kind: kind, Reclaimed: reclaimed, Error: err})
+		}
+		return CleanupDoneMsg{Results: results}
+	}
+}
@@ -32,6 +32,12 @@ var categoryAliases = map[string]string{
 	"processes":  "processes",
 	"db":         "containers",
 	"databases":  "containers",
+	"img":        "images",
+	"images":     "images",
+	"vol":        "volumes",
+	"volumes":    "volumes",
+	"net":        "networks",
+	"networks":   "networks",
 }
 
 var verbRegistry map[string]*verbDef
@@ -79,7 +85,7 @@ func init() {
 	}
 	shell := &verbDef{
 		filter:   func(svc *service.Service) bool { return isDockerOrCompose(svc) && svc.Status == service.StatusRunning },
-		action:   "Open Shell (/bin/sh)",
+		action:   shellAction,
 		errMsg:   "shell not available",
 		category: "containers",
 	}
@@ -95,6 +101,16 @@ func init() {
 		errMsg:   "not a database container",
 		category: "containers",
 	}
+	envVerb := &verbDef{
+		filter:   func(svc *service.Service) bool { return isDockerOrCompose(svc) || svc.PID != 0 },
+		action:   "Environment",
+		errMsg:   "environment not available",
+		category: "containers",
+	}
+	metricsVerb := &verbDef{
+		action:   "View Metrics",
+		category: "containers",
+	}
 	toggle := &verbDef{
 		filter: func(svc *service.Service) bool {
 			return isDockerOrCompose(svc) || svc.Type == service.ServiceTypeProcess
@@ -104,10 +120,10 @@ func init() {
 
 	verbRegistry = map[string]*verbDef{
 		"stop": stop, "start": start, "restart": restart,
-		"kill": kill, "logs": logs, "inspect": inspect,
-		"shell": shell, "delete": del, "browse": browse,
+		"kill": kill, "logs": logs, "inspect": inspect, "metrics": metricsVerb,
+		"shell": shell, "delete": del, "browse": browse, "env": envVerb,
 		"r": restart, "l": logs, "i": inspect,
-		"d": del, "b": browse, "s": toggle,
+		"d": del, "b": browse, "s": toggle, "e": envVerb, "m": metricsVerb,
 	}
 }
 
@@ -201,6 +217,18 @@ func (a *App) executeCommand(p Parsed) tea.Cmd {
 		return a.executeContainerCommand(p)
 	case "processes":
 		return a.executeProcessCommand(p)
+	case "images":
+		return a.executeImageCommand(p)
+	case "volumes":
+		return a.executeVolumeCommand(p)
+	case "networks":
+		if p.Action != "" && p.Action != "list" {
+			a.statusMessage = "unknown networks action: " + p.Action
+			return nil
+		}
+		a.searchFilter = ""
+		a.focus = FocusMainList
+		return a.selectCategory(4)
 	default:
 		switch strings.ToLower(p.Action) {
 		case "help":
@@ -209,6 +237,10 @@ func (a *App) executeCommand(p Parsed) tea.Cmd {
 			return a.helpView.Init()
 		case "quit", "q":
 			return tea.Quit
+		case "connect":
+			return a.executeConnect(p.Target)
+		case "cleanup":
+			return a.openCleanup()
 		default:
 			a.statusMessage = "unknown command: " + p.Raw
 		}
@@ -216,6 +248,28 @@ func (a *App) executeCommand(p Parsed) tea.Cmd {
 	}
 }
 
+// opens the browser for a saved connection from the config file.
+func (a *App) executeConnect(name string) tea.Cmd {
+	if name == "" {
+		a.statusMessage = "usage: connect <connection>"
+		return nil
+	}
+	if a.config == nil {
+		a.statusMessage = "no saved connections"
+		return nil
+	}
+	conn, ok := a.config.Connection(name)
+	if !ok {
+		a.statusMessage = "no saved connection: " + name
+		return nil
+	}
+	a.showConnection(conn)
+	if a.mode == "redis" {
+		return a.redisView.Init()
+	}
+	return a.dbTablesView.Init()
+}
+
 func (a *App) executeVerbCommand(p Parsed) tea.Cmd {
 	vd := verbRegistry[p.Action]
 	if vd == nil {
@@ -265,11 +319,9 @@ func resolveErrorMessage(errMsg, target, filterErrMsg string) string {
 func (a *App) executeContainerCommand(p Parsed) tea.Cmd {
 	switch p.Action {
 	case "list":
-		a.activeCatIndex = 0
-		a.selectedIndex = 0
 		a.focus = FocusMainList
-		return a.fetchDiskUsageCmd()
-	case "stop", "start", "restart", "logs", "inspect", "shell", "delete", "browse":
+		return a.selectCategory(0)
+	case "stop", "start", "restart", "logs", "inspect", "shell", "delete", "browse", "env", "metrics":
 		if p.Target == "" {
 			a.statusMessage = "usage: containers " + p.Action + " <name>"
 			return nil
@@ -291,9 +343,11 @@ func (a *App) executeContainerCommand(p Parsed) tea.Cmd {
 			"restart": "Restart Container",
 			"logs":    "View Logs",
 			"inspect": "Inspect JSON",
-			"shell":   "Open Shell (/bin/sh)",
+			"shell":   shellAction,
 			"delete":  "Delete Container",
 			"browse":  "Browse Database",
+			"env":     "Environment",
+			"metrics": "View Metrics",
 		}
 		return a.executeActionFromMenu(actionMap[p.Action], svc)
 	default:
@@ -305,18 +359,16 @@ func (a *App) executeContainerCommand(p Parsed) tea.Cmd {
 func (a *App) executeProcessCommand(p Parsed) tea.Cmd {
 	switch p.Action {
 	case "list":
-		a.activeCatIndex = 1
-		a.selectedIndex = 0
 		a.focus = FocusMainList
-		return nil
-	case "kill":
+		return a.selectCategory(1)
+	case "kill", "env":
 		if p.Target == "" {
-			a.statusMessage = "usage: processes kill <name>"
+			a.statusMessage = "usage: processes " + p.Action + " <name>"
 			return nil
 		}
 		var filter func(*service.Service) bool
 		var filterErr string
-		if vd, ok := verbRegistry["kill"]; ok {
+		if vd, ok := verbRegistry[p.Action]; ok {
 			filter = vd.filter
 			filterErr = vd.errMsg
 		}
@@ -325,6 +377,9 @@ func (a *App) executeProcessCommand(p Parsed) tea.Cmd {
 			a.statusMessage = resolveErrorMessage(errMsg, p.Target, filterErr)
 			return nil
 		}
+		if p.Action == "env" {
+			return a.executeActionFromMenu("Environment", svc)
+		}
 		return a.executeActionFromMenu("Kill Process", svc)
 	default:
 		a.statusMessage = "unknown processes action: " + p.Action
@@ -332,20 +387,82 @@ func (a *App) executeProcessCommand(p Parsed) tea.Cmd {
 	}
 }
 
+// lists, pulls, removes and prunes images. Removing and pruning switch to the
+// images list to ask for confirmation there.
+func (a *App) executeImageCommand(p Parsed) tea.Cmd {
+	switch p.Action {
+	case "", "list":
+		a.focus = FocusMainList
+		return a.selectCategory(2)
+	case "pull", "remove", "rm":
+		if p.Target == "" {
+			a.statusMessage = "usage: images " + p.Action + " <image>"
+			return nil
+		}
+	case "prune":
+	default:
+		a.statusMessage = "unknown images action: " + p.Action
+		return nil
+	}
+
+	a.searchFilter = ""
+	a.focus = FocusMainList
+	refresh := a.selectCategory(2)
+	switch p.Action {
+	case "pull":
+		return tea.Batch(refresh, a.imagesPanel.Pull(p.Target))
+	case "prune":
+		a.imagesPanel.ConfirmPrune()
+	default:
+		a.imagesPanel.ConfirmRemove(p.Target)
+	}
+	return refresh
+}
+
+// lists, removes and prunes volumes. Removing and pruning switch to the
+// volumes list to ask for confirmation there.
+func (a *App) executeVolumeCommand(p Parsed) tea.Cmd {
+	switch p.Action {
+	case "", "list", "prune":
+	case "remove", "rm":
+		if p.Target == "" {
+			a.statusMessage = "usage: volumes " + p.Action + " <volume>"
+			return nil
+		}
+	default:
+		a.statusMessage = "unknown volumes action: " + p.Action
+		return nil
+	}
+
+	a.searchFilter = ""
+	a.focus = FocusMainList
+	refresh := a.selectCategory(3)
+	switch p.Action {
+	case "prune":
+		a.volumesPanel.ConfirmPrune()
+	case "remove", "rm":
+		a.volumesPanel.ConfirmRemove(p.Target)
+	}
+	return refresh
+}
+
 func (a *App) completions(input string) []string {
 	parts := strings.Fields(input)
 	trailingSpace := strings.HasSuffix(input, " ")
 
-	verbNames := []string{"stop", "start", "restart", "kill", "logs", "inspect", "shell", "delete", "browse"}
-	categories := []string{"containers", "processes"}
-	builtins := []string{"help", "quit"}
+	verbNames := []string{"stop", "start", "restart", "kill", "logs", "inspect", "shell", "delete", "browse", "env", "metrics"}
+	categories := []string{"containers", "processes", "images", "volumes", "networks"}
+	builtins := []string{"cleanup", "connect", "help", "quit"}
 	topLevel := make([]string, 0, len(verbNames)+len(categories)+len(builtins))
 	topLevel = append(topLevel, verbNames...)
 	topLevel = append(topLevel, categories...)
 	topLevel = append(topLevel, builtins...)
 
-	containerActions := []string{"list", "stop", "start", "restart", "logs", "inspect", "shell", "delete", "browse"}
-	processActions := []string{"list", "kill"}
+	containerActions := []string{"list", "stop", "start", "restart", "logs", "inspect", "shell", "delete", "browse", "env", "metrics"}
+	processActions := []string{"list", "kill", "env"}
+	imageActions := []string{"list", "pull", "remove", "prune"}
+	volumeActions := []string{"list", "remove", "prune"}
+	networkActions := []string{"list"}
 
 	if len(parts) == 0 {
 		return topLevel
@@ -357,6 +474,10 @@ func (a *App) completions(input string) []string {
 		return filterPrefix(topLevel, first)
 	}
 
+	if first == "connect" {
+		return filterPrefix(a.connectionNames(), strings.Join(parts[1:], " "))
+	}
+
 	if cat, ok := categoryAliases[first]; ok {
 		if len(parts) == 1 && trailingSpace {
 			switch cat {
@@ -364,6 +485,12 @@ func (a *App) completions(input string) []string {
 				return containerActions
 			case "processes":
 				return processActions
+			case "images":
+				return imageActions
+			case "volumes":
+				return volumeActions
+			case "networks":
+				return networkActions
 			}
 			return nil
 		}
@@ -373,6 +500,12 @@ func (a *App) completions(input string) []string {
 				return filterPrefix(containerActions, parts[1])
 			case "processes":
 				return filterPrefix(processActions, parts[1])
+			case "images":
+				return filterPrefix(imageActions, parts[1])
+			case "volumes":
+				return filterPrefix(volumeActions, parts[1])
+			case "networks":
+				return filterPrefix(networkActions, parts[1])
 			}
 			return nil
 		}
@@ -382,6 +515,17 @@ func (a *App) completions(input string) []string {
 			if len(parts) >= 3 {
 				prefix = strings.Join(parts[2:], " ")
 			}
+			switch cat {
+			case "images":
+				return a.imageRefs(action, prefix)
+			case "volumes":
+				if a.volumesPanel == nil || (action != "remove" && action != "rm") {
+					return nil
+				}
+				return filterPrefix(a.volumesPanel.Names(), prefix)
+			case "networks":
+				return nil
+			}
 			if vd, ok := verbRegistry[action]; ok {
 				return a.filteredServiceNames(vd.filter, prefix)
 			}
@@ -401,6 +545,31 @@ func (a *App) completions(input string) []string {
 	return nil
 }
 
+// completes the tags and dangling image IDs to remove, or the tags to pull again.
+func (a *App) imageRefs(action, prefix string) []string {
+	if a.imagesPanel == nil || (action != "remove" && action != "rm" && action != "pull") {
+		return nil
+	}
+	var refs []string
+	for _, ref := range a.imagesPanel.Refs() {
+		if action != "pull" || strings.Contains(ref, ":") {
+			refs = append(refs, ref)
+		}
+	}
+	return filterPrefix(refs, prefix)
+}
+
+func (a *App) connectionNames() []string {
+	if a.config == nil {
+		return nil
+	}
+	names := make([]string, len(a.config.Connections))
+	for i, conn := range a.config.Connections {
+		names[i] = conn.Name
+	}
+	return names
+}
+
 func (a *App) filteredServiceNames(filter func(*service.Service) bool, prefix string) []string {
 	var names []string
 	for _, svc := range a.services.GetAll() {
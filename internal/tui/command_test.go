@@ -3,6 +3,8 @@ package tui
 import (
 	"testing"
 
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/docker"
 	"github.com/eanda22/devhud/internal/service"
 )
 
@@ -249,6 +251,15 @@ func TestCompletions(t *testing.T) {
 	node := &service.Service{ID: "4", Name: "node-app", Type: service.ServiceTypeProcess, Status: service.StatusRunning}
 
 	app := testApp(nginx, redis, postgres, node)
+	app.config = &config.Config{Connections: []config.Connection{
+		{Name: "staging", Type: "postgres"},
+		{Name: "local-mysql", Type: "mysql"},
+	}}
+	app.imagesPanel = &ImagesPanel{rows: imageRows([]docker.Image{
+		{ID: "sha256:1111", Tags: []string{"nginx:1.25", "nginx:latest"}},
+		{ID: "sha256:2222"},
+	})}
+	app.volumesPanel = &VolumesPanel{volumes: []docker.Volume{{Name: "shop_pgdata"}, {Name: "shop_redis"}}}
 
 	tests := []struct {
 		name  string
@@ -258,7 +269,7 @@ func TestCompletions(t *testing.T) {
 		{
 			name:  "empty shows verbs and categories",
 			input: "",
-			want:  []string{"stop", "start", "restart", "kill", "logs", "inspect", "shell", "delete", "browse", "containers", "processes", "help", "quit"},
+			want:  []string{"stop", "start", "restart", "kill", "logs", "inspect", "shell", "delete", "browse", "env", "metrics", "containers", "processes", "images", "volumes", "networks", "cleanup", "connect", "help", "quit"},
 		},
 		{
 			name:  "partial st matches stop and start",
@@ -288,12 +299,12 @@ func TestCompletions(t *testing.T) {
 		{
 			name:  "containers space shows actions",
 			input: "containers ",
-			want:  []string{"list", "stop", "start", "restart", "logs", "inspect", "shell", "delete", "browse"},
+			want:  []string{"list", "stop", "start", "restart", "logs", "inspect", "shell", "delete", "browse", "env", "metrics"},
 		},
 		{
 			name:  "processes space shows actions",
 			input: "p ",
-			want:  []string{"list", "kill"},
+			want:  []string{"list", "kill", "env"},
 		},
 		{
 			name:  "containers stop space shows running containers",
@@ -320,6 +331,46 @@ func TestCompletions(t *testing.T) {
 			input: "b ",
 			want:  []string{"postgres"},
 		},
+		{
+			name:  "env space shows containers and processes with a PID",
+			input: "env ",
+			want:  []string{"nginx", "postgres", "redis"},
+		},
+		{
+			name:  "connect space shows saved connections",
+			input: "connect ",
+			want:  []string{"staging", "local-mysql"},
+		},
+		{
+			name:  "connect partial name",
+			input: "connect st",
+			want:  []string{"staging"},
+		},
+		{
+			name:  "images space shows actions",
+			input: "images ",
+			want:  []string{"list", "pull", "remove", "prune"},
+		},
+		{
+			name:  "images remove shows tags and dangling IDs",
+			input: "img remove ",
+			want:  []string{"nginx:1.25", "nginx:latest", "2222"},
+		},
+		{
+			name:  "images pull shows tags only",
+			input: "images pull nginx:l",
+			want:  []string{"nginx:latest"},
+		},
+		{
+			name:  "volumes remove completes volume names",
+			input: "vol rm shop_p",
+			want:  []string{"shop_pgdata"},
+		},
+		{
+			name:  "networks space shows actions",
+			input: "networks ",
+			want:  []string{"list"},
+		},
 		{
 			name:  "stop ng filters to nginx",
 			input: "stop ng",
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+// connectionFields labels the inputs of the connection form, in order.
+var connectionFields = []string{"Host", "Port", "User", "Password", "Database", "SSL mode"}
+
+const (
+	fieldHost = iota
+	fieldPort
+	fieldUser
+	fieldPassword
+	fieldDatabase
+	fieldSSLMode
+)
+
+// connectionForm edits the settings a database browser connects with, for when
+// discovery from the container gets them wrong.
+type connectionForm struct {
+	active bool
+	focus  int
+	inputs []textinput.Model
+	base   db.ConnectionConfig
+	err    string
+}
+
+func newConnectionForm() connectionForm {
+	inputs := make([]textinput.Model, len(connectionFields))
+	for i := range inputs {
+		inputs[i] = textinput.New()
+		inputs[i].Prompt = ""
+		inputs[i].CharLimit = 256
+	}
+	inputs[fieldPassword].EchoMode = textinput.EchoPassword
+	inputs[fieldSSLMode].Placeholder = "disable"
+	return connectionForm{inputs: inputs}
+}
+
+// opens the form filled in with config.
+func (f *connectionForm) Start(config db.ConnectionConfig) tea.Cmd {
+	f.active = true
+	f.base = config
+	f.err = ""
+	values := []string{config.Host, config.Port, config.User, config.Password, config.Database, config.SSLMode}
+	for i := range f.inputs {
+		f.inputs[i].SetValue(values[i])
+		f.inputs[i].CursorEnd()
+	}
+	return f.focusField(0)
+}
+
+func (f *connectionForm) Active() bool {
+	return f.active
+}
+
+func (f *connectionForm) focusField(i int) tea.Cmd {
+	f.inputs[f.focus].Blur()
+	f.focus = (i + len(f.inputs)) % len(f.inputs)
+	return f.inputs[f.focus].Focus()
+}
+
+// handles a key while the form is open. It returns the edited settings on
+// enter; esc closes the form without them.
+func (f *connectionForm) Update(msg tea.KeyMsg) (*db.ConnectionConfig, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		f.active = false
+		f.inputs[f.focus].Blur()
+		return nil, nil
+	case "tab", "down":
+		return nil, f.focusField(f.focus + 1)
+	case "shift+tab", "up":
+		return nil, f.focusField(f.focus - 1)
+	case "enter":
+		config, err := f.config()
+		if err != nil {
+			f.err = err.Error()
+			return nil, nil
+		}
+		f.active = false
+		f.inputs[f.focus].Blur()
+		return config, nil
+	}
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return nil, cmd
+}
+
+// reads the inputs over the settings the form was opened with. The schema is
+// dropped when the database changes, since it belonged to the old one.
+func (f *connectionForm) config() (*db.ConnectionConfig, error) {
+	value := func(i int) string { return strings.TrimSpace(f.inputs[i].Value()) }
+
+	config := f.base
+	config.Host = value(fieldHost)
+	config.Port = value(fieldPort)
+	config.User = value(fieldUser)
+	config.Password = f.inputs[fieldPassword].Value()
+	config.Database = value(fieldDatabase)
+	config.SSLMode = value(fieldSSLMode)
+	if config.Database != f.base.Database {
+		config.Schema = ""
+	}
+
+	if config.Host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if port, err := strconv.Atoi(config.Port); err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("port must be a number from 1 to 65535")
+	}
+	switch config.SSLMode {
+	case "", "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		return nil, fmt.Errorf("unknown SSL mode %q", config.SSLMode)
+	}
+	return &config, nil
+}
+
+// renders the form for the viewport.
+func (f *connectionForm) View() string {
+	labelStyle := lipgloss.NewStyle().Width(10)
+	var lines []string
+	for i, input := range f.inputs {
+		label := labelStyle.Render(connectionFields[i])
+		if i == f.focus {
+			lines = append(lines, selectedRowStyle.Render("> "+label)+" "+input.View())
+		} else {
+			lines = append(lines, "  "+label+" "+input.View())
+		}
+	}
+	lines = append(lines, "", subtleStyle.Render("SSL mode is one of disable, require, verify-ca or verify-full."))
+	if f.err != "" {
+		lines = append(lines, "", confirmDeleteStyle.Render(" ERROR ")+" "+f.err)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// connectionService stands in for a saved connection where views expect a service.
+func connectionService(conn config.Connection) *service.Service {
+	return &service.Service{
+		ID:     "connection-" + conn.Name,
+		Name:   conn.Name,
+		DBType: conn.Type,
+	}
+}
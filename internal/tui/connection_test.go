@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/eanda22/devhud/internal/db"
+)
+
+func TestConnectionFormConfig(t *testing.T) {
+	base := db.ConnectionConfig{Host: "localhost", Port: "5432", User: "postgres", Database: "app", Schema: "tenant_1"}
+
+	tests := []struct {
+		name    string
+		set     map[int]string
+		want    db.ConnectionConfig
+		wantErr bool
+	}{
+		{
+			name: "unchanged keeps the schema",
+			want: base,
+		},
+		{
+			name: "overrides",
+			set:  map[int]string{fieldPort: "15432", fieldUser: "app", fieldPassword: " pw ", fieldSSLMode: "require"},
+			want: db.ConnectionConfig{Host: "localhost", Port: "15432", User: "app", Password: " pw ", Database: "app", Schema: "tenant_1", SSLMode: "require"},
+		},
+		{
+			name: "new database drops the schema",
+			set:  map[int]string{fieldDatabase: "other"},
+			want: db.ConnectionConfig{Host: "localhost", Port: "5432", User: "postgres", Database: "other"},
+		},
+		{name: "bad port", set: map[int]string{fieldPort: "54x"}, wantErr: true},
+		{name: "empty host", set: map[int]string{fieldHost: " "}, wantErr: true},
+		{name: "unknown sslmode", set: map[int]string{fieldSSLMode: "on"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := newConnectionForm()
+			form.Start(base)
+			for field, value := range tt.set {
+				form.inputs[field].SetValue(value)
+			}
+			got, err := form.config()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("config() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("config() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/alert"
 	"github.com/eanda22/devhud/internal/docker"
+	"github.com/eanda22/devhud/internal/metrics"
 	"github.com/eanda22/devhud/internal/service"
+	"github.com/mattn/go-runewidth"
 )
 
 const (
@@ -30,15 +33,20 @@ func RenderDashboard(a *App) string {
 	if a.inputMode == ModeCommand {
 		commandBarHeight = 4 + a.commandBar.CompletionLines()
 	}
+	banner := renderAlertBanner(a.alerts, a.width)
+	if banner != "" {
+		commandBarHeight++
+	}
 	panelHeight := a.height - 2 - commandBarHeight
+	panel := a.activePanel()
 
-	if len(services) == 0 {
+	if len(services) == 0 && panel == nil {
 		msg := "No services discovered. Scanning..."
 		if a.lastError != nil {
 			msg += fmt.Sprintf("\nLast error: %v", a.lastError)
 		}
 		style := dashboardStyle.Copy().Height(panelHeight).Width(a.width - sidebarWidth - 6)
-		panels := lipgloss.JoinHorizontal(lipgloss.Top, renderSidebar(a, panelHeight), style.Render(msg))
+		panels := banner + lipgloss.JoinHorizontal(lipgloss.Top, renderSidebar(a, panelHeight), style.Render(msg))
 		if a.inputMode == ModeCommand {
 			return panels + a.commandBar.BoxView(a.width)
 		}
@@ -55,19 +63,21 @@ func RenderDashboard(a *App) string {
 	}
 
 	mainWidth := a.width - sidebarWidth - 6
-	if a.showDetailPanel {
+	if a.showDetailPanel && panel == nil {
 		mainWidth = a.width - sidebarWidth - detailWidth - 10
 	}
 
 	mainContent := renderMainPanel(a, services, selectedCategory, mainWidth, panelHeight)
 
 	var panels string
-	if a.showDetailPanel && a.selectedIndex < len(services) {
-		detail := renderDetailPanel(services[a.selectedIndex], panelHeight)
+	if a.showDetailPanel && panel == nil && a.selectedIndex < len(services) {
+		selected := services[a.selectedIndex]
+		detail := renderDetailPanel(selected, a.history.Get(selected.ID), panelHeight)
 		panels = lipgloss.JoinHorizontal(lipgloss.Top, sidebar, mainContent, detail)
 	} else {
 		panels = lipgloss.JoinHorizontal(lipgloss.Top, sidebar, mainContent)
 	}
+	panels = banner + panels
 
 	if a.inputMode == ModeCommand {
 		return panels + a.commandBar.BoxView(a.width)
@@ -75,9 +85,31 @@ func RenderDashboard(a *App) string {
 	return panels
 }
 
+// renders a one-line banner for the latest alert, or "" when there are none.
+func renderAlertBanner(alerts []alert.Alert, width int) string {
+	if len(alerts) == 0 {
+		return ""
+	}
+	latest := alerts[len(alerts)-1]
+	text := fmt.Sprintf(" ! %s", latest.Message)
+	if len(alerts) > 1 {
+		text = fmt.Sprintf(" ! %d alerts | latest: %s", len(alerts), latest.Message)
+	}
+	hint := "  [!] details "
+	if max := width - len(hint) - 1; max > 0 {
+		text = runewidth.Truncate(text, max, "...")
+	}
+	return alertBannerStyle.Width(width).Render(text+hint) + "\n"
+}
+
 func renderMainPanel(a *App, services []*service.Service, category string, width, height int) string {
 	header := renderHeader(category)
 	rows := buildServiceRows(services, a.activeCatIndex, a.selectedIndex, a.focus, a.operatingOnID, a.dockerDiskUsage)
+	selected := a.selectedIndex
+	if panel := a.activePanel(); panel != nil {
+		rows = panel.Rows(a.focus == FocusMainList)
+		selected = panel.Selected()
+	}
 
 	var footer string
 	var footerLines int
@@ -92,7 +124,7 @@ func renderMainPanel(a *App, services []*service.Service, category string, width
 	contentHeight := height - 4
 	maxRows := contentHeight - footerLines - 1
 
-	start, end := visibleWindow(len(rows), a.selectedIndex, maxRows)
+	start, end := visibleWindow(len(rows), selected, maxRows)
 	visibleRows := buildVisibleRows(rows, start, end)
 
 	usedLines := 1 + len(visibleRows) + footerLines
@@ -156,8 +188,8 @@ func buildServiceRows(
 		diskOrPortHeader = "PORT"
 	}
 
-	headerLine := fmt.Sprintf("%-6s %-40s %-10s %-10s %-10s\n",
-		"STATUS", "NAME", "TYPE", diskOrPortHeader, "UPTIME")
+	headerLine := fmt.Sprintf("%-6s %-40s %-10s %-7s %-10s %-10s %-10s\n",
+		"STATUS", "NAME", "TYPE", "CPU", "MEM", diskOrPortHeader, "UPTIME")
 
 	rows := []string{headerLine}
 
@@ -186,16 +218,26 @@ func formatServiceRow(svc *service.Service, activeCatIndex int, dockerDiskUsage
 	}
 
 	diskColumn := getDiskColumn(svc, dockerDiskUsage)
+	cpuColumn, memColumn := getResourceColumns(svc)
 
-	return fmt.Sprintf("%-6s %-40s %-10s %-10s %-10s",
+	return fmt.Sprintf("%-6s %-40s %-10s %-7s %-10s %-10s %-10s",
 		status,
 		truncate(serviceName, 38),
 		string(svc.Type),
+		cpuColumn,
+		memColumn,
 		diskColumn,
 		uptime,
 	)
 }
 
+func getResourceColumns(svc *service.Service) (string, string) {
+	if svc.Resources == nil {
+		return "-", "-"
+	}
+	return fmt.Sprintf("%.1f%%", svc.Resources.CPUPercent), formatBytes(int64(svc.Resources.MemUsage))
+}
+
 func getDiskColumn(svc *service.Service, dockerDiskUsage *docker.DiskUsage) string {
 	if svc.Type == service.ServiceTypeDocker || svc.Type == service.ServiceTypeCompose {
 		if dockerDiskUsage != nil && dockerDiskUsage.ContainerSizes != nil {
@@ -215,8 +257,13 @@ func buildStatusLine(a *App) string {
 	if a.confirmOperation != "" {
 		return "\n" + confirmDeleteStyle.Render(" DELETE ") + "  Confirm delete? [y/N]"
 	}
+	panel := a.activePanel()
+	if panel != nil && panel.Prompt() != "" {
+		return "\n" + panel.Prompt()
+	}
 
 	var mode, hints string
+	status := a.statusMessage
 
 	switch a.inputMode {
 	case ModeCommand:
@@ -230,16 +277,22 @@ func buildStatusLine(a *App) string {
 		if a.searchFilter != "" {
 			mode += "  " + subtleStyle.Render("filter: "+a.searchFilter+" [/ edit, Esc clear]")
 		}
-		if a.focus == FocusSidebar {
-			hints = "[j/k] Nav  [l/Enter] Select  [/] Search  [:] Cmd"
-		} else {
+		switch {
+		case a.focus == FocusSidebar:
+			hints = "[j/k] Nav  [l/Enter] Select  [C]leanup  [/] Search  [:] Cmd"
+		case panel != nil:
+			hints = panel.Hints()
+		default:
 			hints = buildMainListHints(a.selectedService())
 		}
 	}
+	if panel != nil && panel.Status() != "" {
+		status = panel.Status()
+	}
 
 	line := "\n" + mode + "  " + hints
-	if a.statusMessage != "" {
-		line += "  " + subtleStyle.Render(a.statusMessage)
+	if status != "" {
+		line += "  " + subtleStyle.Render(status)
 	}
 
 	return line
@@ -261,9 +314,12 @@ func buildMainListHints(svc *service.Service) string {
 		}
 
 		if isDocker {
-			parts = append(parts, "[l]ogs", "[d]el", "[i]nspect")
+			parts = append(parts, "[l]ogs", "[d]el", "[i]nspect", "[e]nv")
 		} else if svc.Type == service.ServiceTypeProcess {
 			parts = append(parts, "[l]ogs")
+			if svc.PID != 0 {
+				parts = append(parts, "[e]nv")
+			}
 		}
 
 		if svc.DBType != "" {
@@ -271,6 +327,10 @@ func buildMainListHints(svc *service.Service) string {
 		}
 	}
 
+	if svc != nil {
+		parts = append(parts, "[m]etrics")
+	}
+
 	parts = append(parts, "[h] Back", "[:] Cmd")
 	return strings.Join(parts, "  ")
 }
@@ -293,7 +353,7 @@ func visibleWindow(totalItems, selectedIndex, maxVisible int) (int, int) {
 	return start, end
 }
 
-func renderDetailPanel(svc *service.Service, height int) string {
+func renderDetailPanel(svc *service.Service, series *metrics.Series, height int) string {
 	var serviceInfo string
 
 	switch svc.Type {
@@ -335,10 +395,54 @@ func renderDetailPanel(svc *service.Service, height int) string {
 		)
 	}
 
+	serviceInfo += renderResourceDetail(svc, series)
+
 	style := dashboardStyle.Copy().Width(detailWidth).Height(height)
 	return style.Render(serviceInfo)
 }
 
+func renderResourceDetail(svc *service.Service, series *metrics.Series) string {
+	var lines []string
+	if svc.RestartCount > 0 {
+		lines = append(lines, "", fmt.Sprintf("Restarts: %d", svc.RestartCount))
+	}
+
+	res := svc.Resources
+	if res == nil {
+		if len(lines) == 0 {
+			return ""
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	sparkWidth := detailWidth - 4
+	lines = append(lines, "", fmt.Sprintf("CPU: %.1f%%", res.CPUPercent))
+	if series != nil {
+		lines = append(lines, sparkStyle.Render(sparkline(metrics.Values(series.CPU), sparkWidth, 0)))
+	}
+
+	mem := formatBytes(int64(res.MemUsage))
+	if res.MemLimit > 0 {
+		mem += " / " + formatBytes(int64(res.MemLimit))
+	}
+	lines = append(lines, "Mem: "+mem)
+	if series != nil {
+		lines = append(lines, sparkStyle.Render(sparkline(metrics.Values(series.Memory), sparkWidth, 0)))
+	}
+
+	if res.Processes > 1 {
+		lines = append(lines, fmt.Sprintf("Procs: %d", res.Processes))
+	}
+	if res.NetRx > 0 || res.NetTx > 0 {
+		lines = append(lines, fmt.Sprintf("Net: ↓%s ↑%s", formatBytes(int64(res.NetRx)), formatBytes(int64(res.NetTx))))
+	}
+	if res.BlockRead > 0 || res.BlockWrite > 0 {
+		lines = append(lines, fmt.Sprintf("Block: R %s W %s", formatBytes(int64(res.BlockRead)), formatBytes(int64(res.BlockWrite))))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
 func statusIcon(s service.Status) string {
 	switch s {
 	case service.StatusRunning:
@@ -393,6 +497,8 @@ func renderSidebar(a *App, panelHeight int) string {
 	}
 	if selectedCategory == "Containers" && a.dockerDiskUsage != nil {
 		diskInfo = subtleStyle.Render(fmt.Sprintf("Disk: %s", formatBytes(a.dockerDiskUsage.Total)))
+	} else if panel := a.panels[selectedCategory]; panel != nil && panel.Summary() != "" {
+		diskInfo = subtleStyle.Render(panel.Summary())
 	}
 
 	if diskInfo != "" {
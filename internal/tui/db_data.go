@@ -3,8 +3,10 @@ package tui
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -15,33 +17,75 @@ import (
 type DBDataView struct {
 	service    *service.Service
 	tableName  string
-	dbClient   *db.Client
+	dbClient   db.Browser
 	columns    []db.ColumnInfo
 	rows       db.RowData
 	viewport   viewport.Model
+	grid       *Grid
+	showGrid   bool
 	error      error
 	ready      bool
 	shouldExit bool
 	page       int
 	pageSize   int
+	filter     string
+	filterBox  textinput.Model
+	filtering  bool
+	filters    []db.Filter
+	filterCol  string
+	orderBy    string
+	desc       bool
+	keys       []string
+	keysLoaded bool
+	cursors    [][]interface{}
+	total      int
+	export     exportPrompt
+	status     string
+	edits      *pendingEdits
+	display    db.RowData
+	editBox    textinput.Model
+	editing    bool
+	editCol    string
+	editRow    int
+	reviewing  bool
+	statements []db.Statement
 }
 
 // creates a new database data view for a table.
-func NewDBDataView(svc *service.Service, tableName string, dbClient *db.Client, width, height int) *DBDataView {
+func NewDBDataView(svc *service.Service, tableName string, dbClient db.Browser, width, height int) *DBDataView {
 	vp := viewport.New(width-4, height-6)
 	vp.Style = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("#7D56F4")).
 		Padding(0, 1)
 
+	ti := textinput.New()
+	ti.Prompt = "filter: "
+	ti.Placeholder = `{"status": "active"}`
+	ti.CharLimit = 1024
+
+	eb := textinput.New()
+	eb.CharLimit = 0
+
+	pageSize := 100
+	if _, ok := dbClient.(db.DocumentStore); ok {
+		// documents render over many lines each
+		pageSize = 20
+	}
+
 	return &DBDataView{
 		service:   svc,
 		tableName: tableName,
 		dbClient:  dbClient,
 		viewport:  vp,
+		grid:      NewGrid(width-4, height-6),
 		ready:     false,
 		page:      0,
-		pageSize:  100,
+		pageSize:  pageSize,
+		filterBox: ti,
+		total:     -1,
+		export:    newExportPrompt(),
+		editBox:   eb,
 	}
 }
 
@@ -54,124 +98,592 @@ func (v *DBDataView) Update(msg tea.Msg) (*DBDataView, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if v.filtering {
+			return v.updateFilter(msg)
+		}
+		if v.editing {
+			return v.updateEdit(msg)
+		}
+		if v.reviewing {
+			return v.updateReview(msg)
+		}
+		if v.export.Running() && msg.String() == "esc" {
+			v.export.Stop()
+			return v, nil
+		}
+		if v.export.Active() && msg.String() != "ctrl+c" {
+			req, cmd := v.export.Update(msg)
+			if req != nil {
+				return v, v.exportCmd(*req)
+			}
+			return v, cmd
+		}
+		if v.showGrid && v.grid.DetailOpen() && msg.String() != "ctrl+c" {
+			v.grid.Update(msg)
+			return v, nil
+		}
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return v, tea.Quit
 		case "esc":
+			if v.filter != "" || len(v.filters) > 0 {
+				v.filter = ""
+				v.filterBox.SetValue("")
+				v.filters = nil
+				v.total = -1
+				v.resetPages()
+				return v, v.fetchDataCmd()
+			}
+			if n := v.pendingCount(); n > 0 {
+				v.status = fmt.Sprintf("%d unsaved change(s): [w]rite to review and commit, [U] to discard", n)
+				return v, nil
+			}
 			v.shouldExit = true
 			return v, nil
+		case "e", "a", "D", "u", "U", "w":
+			if v.showGrid && v.sqlClient() != nil {
+				return v, v.updateEditKey(msg.String())
+			}
+		case "/":
+			if _, ok := v.dbClient.(db.DocumentStore); ok {
+				v.filtering = true
+				v.filterBox.Prompt = "filter: "
+				v.filterBox.Placeholder = `{"status": "active"}`
+				v.filterBox.SetValue(v.filter)
+				v.filterBox.CursorEnd()
+				return v, v.filterBox.Focus()
+			}
+			if col, ok := v.grid.Column(); ok && v.sqlClient() != nil {
+				v.filtering = true
+				v.filterCol = col.Name
+				v.filterBox.Prompt = col.Name + " "
+				v.filterBox.Placeholder = "= 5, > 5, like %ada%, null, 10..20"
+				v.filterBox.SetValue(v.columnFilter(col.Name))
+				v.filterBox.CursorEnd()
+				return v, v.filterBox.Focus()
+			}
+		case "s":
+			if col, ok := v.grid.Column(); ok && v.sqlClient() != nil {
+				v.cycleSort(col.Name)
+				v.resetPages()
+				return v, v.fetchDataCmd()
+			}
+		case "x":
+			if v.export.Running() {
+				v.status = "Export running: [esc] to cancel"
+				return v, nil
+			}
+			if v.sqlClient() != nil && v.error == nil {
+				v.export.Start(v.tableName,
+					exportScope{key: "p", label: "current page"},
+					exportScope{key: "t", label: "whole table"})
+				return v, nil
+			}
 		case "r":
+			v.total = -1
 			return v, v.fetchDataCmd()
 		case "n":
-			v.page++
-			return v, v.fetchDataCmd()
+			if v.total < 0 || v.page+1 < v.totalPages() {
+				v.page++
+				return v, v.fetchDataCmd()
+			}
+			return v, nil
 		case "p":
 			if v.page > 0 {
 				v.page--
 				return v, v.fetchDataCmd()
 			}
 		}
+		if v.showGrid && v.grid.Update(msg) {
+			return v, nil
+		}
 
 	case TableDataFetchedMsg:
+		v.showGrid = false
 		if msg.Error != nil {
 			v.error = msg.Error
 			v.viewport.SetContent("Error fetching table data: " + msg.Error.Error())
 		} else {
 			v.columns = msg.Columns
 			v.rows = msg.Rows
+			v.keys = msg.Keys
+			v.keysLoaded = true
+			v.total = msg.Total
+			v.rememberCursor()
 			v.updateViewportContent()
 		}
 		v.ready = true
 		return v, nil
 
+	case ExportDoneMsg:
+		v.export.Stop()
+		v.status = exportStatus(msg)
+		return v, nil
+
+	case EditsAppliedMsg:
+		if msg.Error != nil {
+			v.status = fmt.Sprintf("Rolled back: %v", msg.Error)
+			return v, nil
+		}
+		v.edits = nil
+		v.status = fmt.Sprintf("Committed %d change(s)", msg.Count)
+		v.total = -1
+		return v, v.fetchDataCmd()
+
 	case tea.WindowSizeMsg:
 		v.viewport.Width = msg.Width - 4
 		v.viewport.Height = msg.Height - 6
+		v.grid.SetSize(msg.Width-4, msg.Height-6)
 	}
 
 	v.viewport, cmd = v.viewport.Update(msg)
 	return v, cmd
 }
 
+func (v *DBDataView) updateFilter(msg tea.KeyMsg) (*DBDataView, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return v, tea.Quit
+	case "esc":
+		v.filtering = false
+		v.filterBox.Blur()
+		return v, nil
+	case "enter":
+		v.filtering = false
+		v.filterBox.Blur()
+		if v.sqlClient() != nil {
+			return v, v.applyColumnFilter(v.filterCol, v.filterBox.Value())
+		}
+		v.filter = strings.TrimSpace(v.filterBox.Value())
+		v.resetPages()
+		return v, v.fetchDataCmd()
+	}
+	var cmd tea.Cmd
+	v.filterBox, cmd = v.filterBox.Update(msg)
+	return v, cmd
+}
+
 func (v *DBDataView) View() string {
 	if !v.ready {
 		return "Loading table data..."
 	}
 
+	_, documents := v.dbClient.(db.DocumentStore)
+	page := fmt.Sprintf("Page %d", v.page+1)
+	if v.total >= 0 {
+		page = fmt.Sprintf("Page %d/%d, %d rows", v.page+1, v.totalPages(), v.total)
+	}
+	title := fmt.Sprintf("Table: %s (%s)", v.tableName, page)
+	if documents {
+		title = fmt.Sprintf("Collection: %s (%s)", v.tableName, page)
+	}
+	if v.filter != "" {
+		title += "  filter: " + v.filter
+	}
+	for _, f := range v.filters {
+		title += "  where " + f.String()
+	}
 	header := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#7D56F4")).
 		Bold(true).
-		Render(fmt.Sprintf("Table: %s (Page %d)", v.tableName, v.page+1))
+		Render(title)
+	if n := v.pendingCount(); n > 0 {
+		header += "  " + gridEditedStyle.Render(fmt.Sprintf("%d pending change(s)", n))
+	}
 
+	hint := "[esc] back  [r]efresh  [n]ext page  [p]revious page  [↑/↓] scroll"
+	if v.showGrid {
+		header += "  " + subtleStyle.Render(v.grid.Position())
+		hint = "[esc] back  [n]ext/[p]rev page  [↑/↓/←/→] move  [enter] full value  [/] filter column  [s]ort  e[x]port  [e]dit  [a]dd  [D]elete  [u]ndo  [w]rite"
+		if v.grid.DetailOpen() {
+			hint = "[↑/↓] scroll  [esc] close"
+		}
+	}
+	if v.filtering {
+		hint = v.filterBox.View() + "  [enter] apply  [esc] cancel"
+	} else if v.editing {
+		hint = v.editBox.View() + "  [enter] stage  [esc] cancel  (" + nullInput + " for NULL)"
+	} else if v.reviewing {
+		hint = confirmDeleteStyle.Render(" COMMIT ") +
+			fmt.Sprintf("  Run %d statement(s) in one transaction? [c]ommit  [esc] keep editing  [U] discard all", len(v.statements))
+	} else if v.export.Active() {
+		hint = v.export.View()
+	} else if documents {
+		hint += "  [/] filter"
+	}
 	footer := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")).
-		Render("[esc] back  [r]efresh  [n]ext page  [p]revious page  [↑/↓] scroll")
+		Render(hint)
+	if v.status != "" && !v.filtering && !v.editing && !v.reviewing && !v.export.Active() {
+		footer += "  " + subtleStyle.Render(v.status)
+	}
 
-	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
+	body := v.viewport.View()
+	if v.showGrid && !v.reviewing {
+		body = v.grid.View()
+	}
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, body, footer)
 }
 
 func (v *DBDataView) updateViewportContent() {
-	if len(v.rows) == 0 {
+	// SQL tables keep the grid when empty so rows can be added
+	if len(v.rows) == 0 && v.sqlClient() == nil {
 		v.viewport.SetContent("No data found")
 		return
 	}
 
-	var lines []string
+	if _, ok := v.dbClient.(db.DocumentStore); ok {
+		var docs []string
+		for _, row := range v.rows {
+			docs = append(docs, fmt.Sprintf("%v", row["document"]))
+		}
+		v.viewport.SetContent(strings.Join(docs, "\n\n"))
+		return
+	}
+
+	v.grid.SetMarks(v.headerMarks())
+	v.showEdits()
+	v.showGrid = true
+}
+
+// shows the page with staged edits applied. SetData moves the cursor to the
+// first row, so callers that edit put it back.
+func (v *DBDataView) showEdits() {
+	v.display = v.rows
+	var marks GridEdits
+	if v.edits != nil {
+		v.display, marks = v.edits.Apply(v.rows)
+	}
+	v.grid.SetData(v.columns, v.display)
+	v.grid.SetEdits(marks)
+}
+
+func (v *DBDataView) pendingCount() int {
+	if v.edits == nil {
+		return 0
+	}
+	return v.edits.Count()
+}
 
-	colNames := make([]string, len(v.columns))
-	for i, col := range v.columns {
-		colNames[i] = col.Name
+// handles the keys that stage and write edits. Rows are addressed by primary
+// key, so tables without one cannot be edited.
+func (v *DBDataView) updateEditKey(key string) tea.Cmd {
+	if len(v.keys) == 0 {
+		v.status = db.ErrNoPrimaryKey.Error() + "; editing is disabled"
+		return nil
+	}
+	if v.edits == nil {
+		v.edits = newPendingEdits(v.keys)
 	}
-	lines = append(lines, strings.Join(colNames, " | "))
-	lines = append(lines, strings.Repeat("-", 80))
+	row := v.grid.Row()
 
-	for _, row := range v.rows {
-		values := make([]string, len(v.columns))
-		for i, col := range v.columns {
-			val := row[col.Name]
-			if val == nil {
-				values[i] = "NULL"
-			} else {
-				values[i] = fmt.Sprintf("%v", val)
-				if len(values[i]) > 30 {
-					values[i] = values[i][:27] + "..."
-				}
-			}
+	switch key {
+	case "e":
+		col, ok := v.grid.Column()
+		if !ok || row < 0 {
+			return nil
+		}
+		text := editText(v.display[row][col.Name])
+		if _, set := v.display[row][col.Name]; !set {
+			// an inserted row's column that is still at its default
+			text = ""
+		}
+		v.editing = true
+		v.editRow, v.editCol = row, col.Name
+		v.editBox.Prompt = col.Name + ": "
+		v.editBox.SetValue(text)
+		v.editBox.CursorEnd()
+		return v.editBox.Focus()
+	case "a":
+		row = len(v.rows) + v.edits.Insert()
+	case "D":
+		if row < 0 {
+			return nil
 		}
-		lines = append(lines, strings.Join(values, " | "))
+		v.edits.ToggleDelete(v.rows, row)
+	case "u":
+		if row < 0 {
+			return nil
+		}
+		v.edits.Undo(v.rows, row)
+	case "U":
+		v.edits = nil
+		v.status = "Discarded pending changes"
+	case "w":
+		if v.edits.Count() == 0 {
+			v.status = "No pending changes"
+			return nil
+		}
+		statements, err := v.edits.Statements(v.sqlClient(), v.tableName)
+		if err != nil {
+			v.status = fmt.Sprintf("Cannot build statements: %v", err)
+			return nil
+		}
+		v.statements = statements
+		v.reviewing = true
+		previews := make([]string, len(statements))
+		for i, stmt := range statements {
+			previews[i] = stmt.Preview
+		}
+		v.viewport.SetContent("BEGIN;\n" + strings.Join(previews, "\n") + "\nCOMMIT;")
+		v.viewport.GotoTop()
+		return nil
 	}
+	v.showEdits()
+	v.grid.SelectRow(row)
+	return nil
+}
 
-	v.viewport.SetContent(strings.Join(lines, "\n"))
+func (v *DBDataView) updateEdit(msg tea.KeyMsg) (*DBDataView, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return v, tea.Quit
+	case "esc":
+		v.editing = false
+		v.editBox.Blur()
+		return v, nil
+	case "enter":
+		v.editing = false
+		v.editBox.Blur()
+		v.edits.Set(v.rows, v.editRow, v.editCol, parseEditText(v.editBox.Value()))
+		v.showEdits()
+		v.grid.SelectRow(v.editRow)
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.editBox, cmd = v.editBox.Update(msg)
+	return v, cmd
+}
+
+// handles keys while the SQL preview is shown.
+func (v *DBDataView) updateReview(msg tea.KeyMsg) (*DBDataView, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return v, tea.Quit
+	case "esc":
+		v.reviewing = false
+		return v, nil
+	case "U":
+		v.reviewing = false
+		v.edits = nil
+		v.status = "Discarded pending changes"
+		row := v.grid.Row()
+		v.showEdits()
+		v.grid.SelectRow(row)
+		return v, nil
+	case "c":
+		v.reviewing = false
+		v.status = "Committing..."
+		return v, v.applyEditsCmd()
+	}
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+// runs the staged statements in one transaction.
+func (v *DBDataView) applyEditsCmd() tea.Cmd {
+	client, statements := v.sqlClient(), v.statements
+	return func() tea.Msg {
+		err := client.ApplyChanges(context.Background(), statements)
+		return EditsAppliedMsg{Count: len(statements), Error: err}
+	}
+}
+
+// returns the client when the table is in a SQL database, which supports
+// server-side filters and sorting.
+func (v *DBDataView) sqlClient() *db.Client {
+	client, _ := v.dbClient.(*db.Client)
+	return client
+}
+
+// marks sorted and filtered columns in the grid header.
+func (v *DBDataView) headerMarks() map[string]string {
+	marks := make(map[string]string)
+	for _, f := range v.filters {
+		marks[f.Column] = "*"
+	}
+	if v.orderBy != "" {
+		arrow := "▲"
+		if v.desc {
+			arrow = "▼"
+		}
+		marks[v.orderBy] += arrow
+	}
+	return marks
+}
+
+// returns the expression of the filter on a column, for editing.
+func (v *DBDataView) columnFilter(column string) string {
+	for _, f := range v.filters {
+		if f.Column == column {
+			return strings.TrimPrefix(f.String(), column+" ")
+		}
+	}
+	return ""
+}
+
+// replaces the filter on a column; an empty expression removes it.
+func (v *DBDataView) applyColumnFilter(column, expr string) tea.Cmd {
+	var filters []db.Filter
+	for _, f := range v.filters {
+		if f.Column != column {
+			filters = append(filters, f)
+		}
+	}
+	if strings.TrimSpace(expr) != "" {
+		f, err := db.ParseCondition(column, expr)
+		if err != nil {
+			v.viewport.SetContent("Invalid filter: " + err.Error())
+			v.showGrid = false
+			return nil
+		}
+		filters = append(filters, f)
+	}
+	v.filters = filters
+	v.total = -1
+	v.resetPages()
+	return v.fetchDataCmd()
+}
+
+// sorts by a column ascending, then descending, then back to key order.
+func (v *DBDataView) cycleSort(column string) {
+	switch {
+	case v.orderBy != column:
+		v.orderBy, v.desc = column, false
+	case !v.desc:
+		v.desc = true
+	default:
+		v.orderBy, v.desc = "", false
+	}
+}
+
+func (v *DBDataView) resetPages() {
+	v.page = 0
+	v.cursors = nil
+}
+
+func (v *DBDataView) totalPages() int {
+	return max(1, (v.total+v.pageSize-1)/v.pageSize)
+}
+
+// records the key of the page's last row so the next page can start after it.
+func (v *DBDataView) rememberCursor() {
+	if len(v.keys) == 0 || len(v.rows) == 0 {
+		return
+	}
+	last := v.rows[len(v.rows)-1]
+	after := make([]interface{}, len(v.keys))
+	for i, key := range v.keys {
+		after[i] = last[key]
+	}
+	for len(v.cursors) <= v.page+1 {
+		v.cursors = append(v.cursors, nil)
+	}
+	v.cursors[v.page+1] = after
+}
+
+// returns the keyset cursor for the current page, if the previous page was loaded.
+func (v *DBDataView) pageCursor() []interface{} {
+	if v.page < len(v.cursors) {
+		return v.cursors[v.page]
+	}
+	return nil
+}
+
+// exports the page on screen, or every row matching the filters in the current sort order.
+func (v *DBDataView) exportCmd(req exportRequest) tea.Cmd {
+	client, tableName := v.sqlClient(), v.tableName
+	v.status = "Exporting..."
+	if req.scope == "p" {
+		columns, rows := v.columns, v.rows
+		return exportCmd(v.export.Begin(), req.path, func(_ context.Context, w io.Writer) (int, error) {
+			return client.ExportRows(columns, rows, tableName, req.format, w)
+		})
+	}
+	q := db.TableQuery{
+		Filters: v.filters,
+		OrderBy: v.orderBy,
+		Desc:    v.desc,
+		Keys:    v.keys,
+	}
+	v.status = "Exporting... [esc] cancel"
+	return exportCmd(v.export.Begin(), req.path, func(ctx context.Context, w io.Writer) (int, error) {
+		return client.ExportTable(ctx, tableName, q, req.format, w)
+	})
 }
 
 // fetches table data from the database.
 func (v *DBDataView) fetchDataCmd() tea.Cmd {
+	if client := v.sqlClient(); client != nil {
+		return v.fetchTableCmd(client)
+	}
+
 	return func() tea.Msg {
 		ctx := context.Background()
 
 		columns, err := v.dbClient.GetTableColumns(ctx, v.tableName)
 		if err != nil {
-			return TableDataFetchedMsg{
-				Columns: nil,
-				Rows:    nil,
-				Error:   fmt.Errorf("get columns: %w", err),
-			}
+			return TableDataFetchedMsg{Error: fmt.Errorf("get columns: %w", err)}
 		}
 
 		offset := v.page * v.pageSize
-		rows, err := v.dbClient.GetTableData(ctx, v.tableName, v.pageSize, offset)
+		var rows db.RowData
+		if store, ok := v.dbClient.(db.DocumentStore); ok {
+			rows, err = store.FindDocuments(ctx, v.tableName, v.filter, v.pageSize, offset)
+		} else {
+			rows, err = v.dbClient.GetTableData(ctx, v.tableName, v.pageSize, offset)
+		}
+		if err != nil {
+			return TableDataFetchedMsg{Error: fmt.Errorf("get data: %w", err)}
+		}
+
+		return TableDataFetchedMsg{Columns: columns, Rows: rows, Total: -1}
+	}
+}
+
+// fetches a page of a SQL table with filters and sorting applied on the server.
+// Pages follow the primary key when rows are in key order. Rows are counted
+// only until the count is known, so turning pages does not scan the table;
+// changing the filters or the data clears the count.
+func (v *DBDataView) fetchTableCmd(client *db.Client) tea.Cmd {
+	tableName := v.tableName
+	keys, keysLoaded := v.keys, v.keysLoaded
+	total := v.total
+	q := db.TableQuery{
+		Filters: v.filters,
+		OrderBy: v.orderBy,
+		Desc:    v.desc,
+		Limit:   v.pageSize,
+		Offset:  v.page * v.pageSize,
+		After:   v.pageCursor(),
+	}
+
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		columns, err := client.GetTableColumns(ctx, tableName)
 		if err != nil {
-			return TableDataFetchedMsg{
-				Columns: nil,
-				Rows:    nil,
-				Error:   fmt.Errorf("get data: %w", err),
+			return TableDataFetchedMsg{Error: fmt.Errorf("get columns: %w", err)}
+		}
+		if !keysLoaded {
+			if keys, err = client.PrimaryKey(ctx, tableName); err != nil {
+				return TableDataFetchedMsg{Error: fmt.Errorf("get primary key: %w", err)}
 			}
 		}
+		q.Keys = keys
 
-		return TableDataFetchedMsg{
-			Columns: columns,
-			Rows:    rows,
-			Error:   nil,
+		rows, err := client.QueryTable(ctx, tableName, q)
+		if err != nil {
+			return TableDataFetchedMsg{Error: fmt.Errorf("get data: %w", err)}
 		}
+
+		if total < 0 {
+			if total, err = client.CountRows(ctx, tableName, q.Filters); err != nil {
+				total = -1
+			}
+		}
+
+		return TableDataFetchedMsg{Columns: columns, Rows: rows, Keys: keys, Total: total}
 	}
 }
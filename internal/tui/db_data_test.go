@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+func TestDBDataCountsRowsOncePerFilter(t *testing.T) {
+	ctx := context.Background()
+	client, err := db.NewClient(ctx, &db.ConnectionConfig{Database: filepath.Join(t.TempDir(), "t.db")}, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	for _, stmt := range []string{
+		"CREATE TABLE items (id INTEGER PRIMARY KEY, n INTEGER)",
+		"INSERT INTO items (n) VALUES (1), (2), (3)",
+	} {
+		if _, err := client.Query(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v := NewDBDataView(&service.Service{Name: "app"}, "items", client, 80, 24)
+	fetch := func() {
+		t.Helper()
+		msg := v.fetchDataCmd()().(TableDataFetchedMsg)
+		if msg.Error != nil {
+			t.Fatal(msg.Error)
+		}
+		v, _ = v.Update(msg)
+	}
+	fetch()
+	if v.total != 3 {
+		t.Fatalf("total = %d, want 3", v.total)
+	}
+
+	// rows added behind the view's back are not counted again on a page turn
+	if _, err := client.Query(ctx, "INSERT INTO items (n) VALUES (4)"); err != nil {
+		t.Fatal(err)
+	}
+	fetch()
+	if v.total != 3 {
+		t.Errorf("total after refetch = %d, want the cached 3", v.total)
+	}
+
+	v.applyColumnFilter("n", "> 1")
+	fetch()
+	if v.total != 3 {
+		t.Errorf("total after filtering = %d, want 3 rows with n > 1", v.total)
+	}
+}
@@ -8,15 +8,19 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/config"
 	"github.com/eanda22/devhud/internal/db"
 	"github.com/eanda22/devhud/internal/docker"
 	"github.com/eanda22/devhud/internal/service"
 )
 
+// tableCountWorkers bounds how many exact COUNT(*) queries run at once.
+const tableCountWorkers = 4
+
 type DBTablesView struct {
 	service       *service.Service
 	dockerClient  *docker.Client
-	dbClient      *db.Client
+	dbClient      db.Browser
 	tables        []db.TableInfo
 	selectedIndex int
 	viewport      viewport.Model
@@ -24,6 +28,27 @@ type DBTablesView struct {
 	ready         bool
 	shouldExit    bool
 	openTable     string
+	openQuery     bool
+	openSchema    string
+	openActivity  bool
+	statusMessage string
+	namespace     *db.Namespace
+	namespaces    []db.Namespace
+	nsIndex       int
+	choosingNS    bool
+	countCancel   context.CancelFunc
+	countGen      int
+	// countPending holds the tables the running count covers; countTried the
+	// tables counted, or that failed to count, since the tables were loaded.
+	countPending map[string]bool
+	countTried   map[string]bool
+	// connection is the saved connection browsed instead of a container.
+	connection *config.Connection
+	// override replaces discovery once the connection form is submitted.
+	override *db.ConnectionConfig
+	// config is what the last connection attempt used, to fill in the form.
+	config *db.ConnectionConfig
+	form   connectionForm
 }
 
 // creates a new database tables view for a service.
@@ -39,6 +64,7 @@ func NewDBTablesView(svc *service.Service, dockerClient *docker.Client, width, h
 		dockerClient: dockerClient,
 		viewport:     vp,
 		ready:        false,
+		form:         newConnectionForm(),
 	}
 }
 
@@ -51,24 +77,82 @@ func (v *DBTablesView) Update(msg tea.Msg) (*DBTablesView, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if v.form.Active() && msg.String() != "ctrl+c" {
+			config, cmd := v.form.Update(msg)
+			if config != nil {
+				v.override = config
+				v.namespace = nil
+				v.statusMessage = "Connecting..."
+				return v, v.fetchTablesCmd()
+			}
+			if !v.form.Active() {
+				v.updateViewportContent()
+			}
+			return v, cmd
+		}
+		if v.choosingNS {
+			return v.updateNamespaces(msg)
+		}
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return v, tea.Quit
 		case "esc":
+			v.stopCounting()
 			v.shouldExit = true
 			return v, nil
+		case "d":
+			client, ok := v.dbClient.(*db.Client)
+			if !ok {
+				if v.dbClient != nil {
+					v.statusMessage = "This database has no other databases or schemas to switch to"
+				}
+				return v, nil
+			}
+			v.statusMessage = "Loading databases and schemas..."
+			return v, fetchNamespacesCmd(client)
 		case "r":
 			return v, v.fetchTablesCmd()
+		case "c":
+			return v, v.startConnectionForm()
+		case "a":
+			if client, ok := v.dbClient.(*db.Client); ok && client.CanMonitor() {
+				v.openActivity = true
+			} else if v.dbClient != nil {
+				v.statusMessage = "Session monitoring is not available for this database"
+			}
+			return v, nil
+		case "s":
+			if _, ok := v.dbClient.(*db.Client); ok {
+				v.openQuery = true
+			} else if v.dbClient != nil {
+				v.statusMessage = "SQL console is not available for this database"
+			}
+			return v, nil
+		case "i":
+			if _, ok := v.dbClient.(*db.Client); !ok {
+				if v.dbClient != nil {
+					v.statusMessage = "Schema inspection is not available for this database"
+				}
+				return v, nil
+			}
+			if v.selectedIndex < len(v.tables) {
+				v.openSchema = v.tables[v.selectedIndex].Name
+			}
+			return v, nil
 		case "up", "k":
 			if v.selectedIndex > 0 {
 				v.selectedIndex--
 				v.updateViewportContent()
+				ensureLineVisible(&v.viewport, v.selectedIndex)
 			}
+			return v, v.startCounting()
 		case "down", "j":
 			if v.selectedIndex < len(v.tables)-1 {
 				v.selectedIndex++
 				v.updateViewportContent()
+				ensureLineVisible(&v.viewport, v.selectedIndex)
 			}
+			return v, v.startCounting()
 		case "enter":
 			if v.selectedIndex < len(v.tables) {
 				v.openTable = v.tables[v.selectedIndex].Name
@@ -77,17 +161,66 @@ func (v *DBTablesView) Update(msg tea.Msg) (*DBTablesView, tea.Cmd) {
 		}
 
 	case TablesFetchedMsg:
+		if msg.Config != nil {
+			v.config = msg.Config
+		}
+		v.statusMessage = ""
 		if msg.Error != nil {
 			v.error = msg.Error
-			v.viewport.SetContent("Error connecting to database: " + msg.Error.Error())
-		} else if len(msg.Tables) == 0 {
-			v.viewport.SetContent("No tables found")
 		} else {
-			v.tables = msg.Tables
+			if v.dbClient != nil {
+				v.dbClient.Close()
+			}
+			v.error = nil
 			v.dbClient = msg.Client
-			v.updateViewportContent()
+			v.tables = msg.Tables
+			v.selectedIndex = 0
+			v.countTried = make(map[string]bool)
+			v.viewport.GotoTop()
 		}
+		v.updateViewportContent()
 		v.ready = true
+		return v, v.startCounting()
+
+	case TableCountMsg:
+		if msg.Gen != v.countGen {
+			return v, nil
+		}
+		if msg.Done {
+			v.countCancel = nil
+			v.countPending = nil
+			return v, nil
+		}
+		delete(v.countPending, msg.Result.Table)
+		v.countTried[msg.Result.Table] = true
+		if msg.Result.Err == nil {
+			for i := range v.tables {
+				if v.tables[i].Name == msg.Result.Table {
+					v.tables[i].RowCount = msg.Result.Count
+					v.tables[i].Approximate = false
+				}
+			}
+			if !v.choosingNS {
+				v.updateViewportContent()
+			}
+		}
+		return v, waitForTableCount(msg.Results, msg.Gen)
+
+	case NamespacesFetchedMsg:
+		if msg.Error != nil {
+			v.statusMessage = msg.Error.Error()
+			return v, nil
+		}
+		v.statusMessage = ""
+		v.namespaces = msg.Namespaces
+		v.nsIndex = 0
+		for i, ns := range msg.Namespaces {
+			if ns.Current {
+				v.nsIndex = i
+			}
+		}
+		v.choosingNS = true
+		v.updateViewportContent()
 		return v, nil
 
 	case tea.WindowSizeMsg:
@@ -96,6 +229,13 @@ func (v *DBTablesView) Update(msg tea.Msg) (*DBTablesView, tea.Cmd) {
 	}
 
 	v.viewport, cmd = v.viewport.Update(msg)
+	switch msg.(type) {
+	case tea.KeyMsg, tea.WindowSizeMsg:
+		// scrolling or resizing may bring uncounted tables on screen
+		if v.ready && !v.choosingNS && !v.form.Active() {
+			return v, tea.Batch(cmd, v.startCounting())
+		}
+	}
 	return v, cmd
 }
 
@@ -104,42 +244,275 @@ func (v *DBTablesView) View() string {
 		return "Connecting to database..."
 	}
 
+	title := fmt.Sprintf("Database Tables: %s", v.title())
+	hint := "[esc] back  [r]efresh  [↑/↓] navigate  [enter] view table  [i]nspect schema  [s]ql console  [d]atabases  [a]ctivity  [c]onnection"
+	if v.error != nil {
+		hint = "[esc] back  [r]etry  [c]onnection settings"
+	}
+	switch {
+	case v.form.Active():
+		title = fmt.Sprintf("Connection: %s", v.service.Name)
+		hint = "[tab/↑/↓] field  [enter] connect  [esc] cancel"
+	case v.choosingNS:
+		title = fmt.Sprintf("Databases and Schemas: %s", v.service.Name)
+		hint = "[esc] back to tables  [↑/↓] navigate  [enter] switch"
+	}
 	header := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#7D56F4")).
 		Bold(true).
-		Render(fmt.Sprintf("Database Tables: %s", v.service.Name))
+		Render(title)
 
 	footer := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")).
-		Render("[esc] back  [r]efresh  [↑/↓] navigate  [enter] view table")
+		Render(hint)
+	if v.statusMessage != "" {
+		footer += "  " + subtleStyle.Render(v.statusMessage)
+	}
 
 	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
 }
 
+// names the service, or the file for SQLite databases, and the namespace in use.
+func (v *DBTablesView) title() string {
+	if v.service.DBPath != "" {
+		return fmt.Sprintf("%s (%s)", v.service.Name, v.service.DBPath)
+	}
+	if client, ok := v.dbClient.(*db.Client); ok {
+		config := client.Config()
+		ns := db.Namespace{Database: config.Database, Schema: config.Schema}
+		return fmt.Sprintf("%s (%s)", v.service.Name, ns)
+	}
+	return v.service.Name
+}
+
+func (v *DBTablesView) updateNamespaces(msg tea.KeyMsg) (*DBTablesView, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return v, tea.Quit
+	case "esc":
+		v.choosingNS = false
+		v.updateViewportContent()
+	case "up", "k":
+		if v.nsIndex > 0 {
+			v.nsIndex--
+			v.updateViewportContent()
+		}
+	case "down", "j":
+		if v.nsIndex < len(v.namespaces)-1 {
+			v.nsIndex++
+			v.updateViewportContent()
+		}
+	case "enter":
+		if v.nsIndex < len(v.namespaces) {
+			ns := v.namespaces[v.nsIndex]
+			v.namespace = &ns
+			v.choosingNS = false
+			return v, v.fetchTablesCmd()
+		}
+	}
+	return v, nil
+}
+
+func (v *DBTablesView) renderNamespaces() {
+	var lines []string
+	for i, ns := range v.namespaces {
+		prefix := "  "
+		if i == v.nsIndex {
+			prefix = "> "
+		}
+		line := prefix + ns.String()
+		if ns.Current {
+			line += "  " + subtleStyle.Render("(current)")
+		}
+		lines = append(lines, line)
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
 func (v *DBTablesView) updateViewportContent() {
+	if v.form.Active() {
+		v.viewport.SetContent(v.form.View())
+		return
+	}
+	if v.choosingNS {
+		v.renderNamespaces()
+		return
+	}
+	if v.error != nil {
+		v.viewport.SetContent("Error connecting to database: " + v.error.Error())
+		return
+	}
+	if len(v.tables) == 0 {
+		v.viewport.SetContent("No tables found")
+		return
+	}
+	_, documents := v.dbClient.(db.DocumentStore)
+
 	var lines []string
 	for i, table := range v.tables {
 		prefix := "  "
 		if i == v.selectedIndex {
 			prefix = "> "
 		}
-		line := fmt.Sprintf("%s%-40s  Rows: %-10d  Columns: %d",
-			prefix,
-			table.Name,
-			table.RowCount,
-			table.ColumnCount,
-		)
+		var line string
+		if documents {
+			line = fmt.Sprintf("%s%-40s  Documents: %d", prefix, table.Name, table.RowCount)
+		} else {
+			line = fmt.Sprintf("%s%-40s  Rows: %-10s  Columns: %d",
+				prefix,
+				table.Name,
+				formatRowCount(table),
+				table.ColumnCount,
+			)
+			if table.Kind != db.KindTable {
+				line += "  " + subtleStyle.Render("["+table.Kind+"]")
+			}
+		}
 		lines = append(lines, line)
 	}
 	v.viewport.SetContent(strings.Join(lines, "\n"))
 }
 
+// opens the connection form with the settings of the last attempt, or the
+// database's defaults when discovery failed.
+func (v *DBTablesView) startConnectionForm() tea.Cmd {
+	if v.service.DBPath != "" {
+		v.statusMessage = "SQLite files have no connection settings"
+		return nil
+	}
+	config := db.ConnectionConfig{Host: "localhost"}
+	if v.config != nil {
+		config = *v.config
+	} else if driver, err := db.Lookup(v.service.DBType); err == nil {
+		config.Port = driver.DefaultPort()
+	}
+	v.statusMessage = ""
+	cmd := v.form.Start(config)
+	v.updateViewportContent()
+	return cmd
+}
+
+// returns the config for a database file, the connection form or a saved
+// connection, or discovers it from the service's container.
+func (v *DBTablesView) connectionConfig(ctx context.Context) (*db.ConnectionConfig, error) {
+	if v.service.DBPath != "" {
+		return &db.ConnectionConfig{Database: v.service.DBPath}, nil
+	}
+	var config *db.ConnectionConfig
+	switch {
+	case v.override != nil:
+		override := *v.override
+		config = &override
+	case v.connection != nil:
+		saved, err := db.SavedConfig(ctx, *v.connection)
+		if err != nil {
+			return nil, err
+		}
+		config = saved
+	case v.dockerClient == nil:
+		return nil, fmt.Errorf("docker unavailable")
+	default:
+		discovered, err := db.DiscoverConfig(ctx, v.dockerClient.GetRawClient(), v.service.ContainerID, v.service.DBType)
+		if err != nil {
+			return nil, err
+		}
+		config = discovered
+	}
+	if v.namespace != nil {
+		config.Database = v.namespace.Database
+		config.Schema = v.namespace.Schema
+	}
+	return config, nil
+}
+
+// shows exact counts as is, estimates with a leading "~" and unknown counts as "?".
+func formatRowCount(table db.TableInfo) string {
+	switch {
+	case table.RowCount < 0 && table.Kind != db.KindTable:
+		return "-"
+	case table.RowCount < 0:
+		return "?"
+	case table.Approximate:
+		return fmt.Sprintf("~%d", table.RowCount)
+	}
+	return fmt.Sprintf("%d", table.RowCount)
+}
+
+// counts the rows of the tables on screen that only have estimates, a few at a
+// time, in the background. Each table is counted once per load, so scrolling
+// back or returning to the view does not count it again. A running count is
+// replaced only when new tables came on screen.
+func (v *DBTablesView) startCounting() tea.Cmd {
+	client, ok := v.dbClient.(*db.Client)
+	if !ok {
+		return nil
+	}
+	var names []string
+	covered := true
+	for _, table := range v.visibleTables() {
+		if table.Kind != db.KindTable || !(table.Approximate || table.RowCount < 0) || v.countTried[table.Name] {
+			continue
+		}
+		names = append(names, table.Name)
+		if !v.countPending[table.Name] {
+			covered = false
+		}
+	}
+	if covered {
+		return nil
+	}
+
+	v.stopCounting()
+	v.countPending = make(map[string]bool, len(names))
+	for _, name := range names {
+		v.countPending[name] = true
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	v.countCancel = cancel
+	v.countGen++
+	return waitForTableCount(client.CountTables(ctx, names, tableCountWorkers), v.countGen)
+}
+
+// returns the tables whose lines are in the viewport.
+func (v *DBTablesView) visibleTables() []db.TableInfo {
+	start := min(v.viewport.YOffset, len(v.tables))
+	visible := v.viewport.Height - v.viewport.Style.GetVerticalFrameSize()
+	end := min(start+max(visible, 0), len(v.tables))
+	return v.tables[start:end]
+}
+
+// cancels background counting, e.g. when leaving the view.
+func (v *DBTablesView) stopCounting() {
+	if v.countCancel != nil {
+		v.countCancel()
+		v.countCancel = nil
+	}
+	v.countPending = nil
+}
+
+// waits for the next exact row count.
+func waitForTableCount(results <-chan db.TableCount, gen int) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-results
+		return TableCountMsg{Gen: gen, Result: result, Done: !ok, Results: results}
+	}
+}
+
+// lists the databases and schemas on the server.
+func fetchNamespacesCmd(client *db.Client) tea.Cmd {
+	return func() tea.Msg {
+		namespaces, err := client.Namespaces(context.Background())
+		return NamespacesFetchedMsg{Namespaces: namespaces, Error: err}
+	}
+}
+
 // fetches tables from the database.
 func (v *DBTablesView) fetchTablesCmd() tea.Cmd {
+	v.stopCounting()
 	return func() tea.Msg {
 		ctx := context.Background()
 
-		config, err := db.DiscoverConfig(ctx, v.dockerClient.GetRawClient(), v.service.ContainerID, v.service.DBType)
+		config, err := v.connectionConfig(ctx)
 		if err != nil {
 			return TablesFetchedMsg{
 				Tables: nil,
@@ -148,11 +521,12 @@ func (v *DBTablesView) fetchTablesCmd() tea.Cmd {
 			}
 		}
 
-		client, err := db.NewClient(ctx, config, v.service.DBType)
+		client, err := db.Open(ctx, config, v.service.DBType)
 		if err != nil {
 			return TablesFetchedMsg{
 				Tables: nil,
 				Client: nil,
+				Config: config,
 				Error:  fmt.Errorf("connect to database: %w", err),
 			}
 		}
@@ -163,6 +537,7 @@ func (v *DBTablesView) fetchTablesCmd() tea.Cmd {
 			return TablesFetchedMsg{
 				Tables: nil,
 				Client: nil,
+				Config: config,
 				Error:  fmt.Errorf("list tables: %w", err),
 			}
 		}
@@ -170,6 +545,7 @@ func (v *DBTablesView) fetchTablesCmd() tea.Cmd {
 		return TablesFetchedMsg{
 			Tables: tables,
 			Client: client,
+			Config: config,
 			Error:  nil,
 		}
 	}
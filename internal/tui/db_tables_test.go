@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+func TestDBTablesCountsVisibleTablesOnce(t *testing.T) {
+	client, err := db.NewClient(context.Background(), &db.ConnectionConfig{Database: filepath.Join(t.TempDir(), "t.db")}, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var tables []db.TableInfo
+	for i := range 6 {
+		tables = append(tables, db.TableInfo{Name: fmt.Sprintf("t%d", i), Kind: db.KindTable, RowCount: -1})
+	}
+	// a 4-line viewport with a border shows two tables
+	v := NewDBTablesView(&service.Service{Name: "app"}, nil, 80, 10)
+	v, cmd := v.Update(TablesFetchedMsg{Tables: tables, Client: client})
+	defer v.stopCounting()
+
+	if cmd == nil || len(v.countPending) != 2 || !v.countPending["t0"] || !v.countPending["t1"] {
+		t.Fatalf("countPending = %v, want t0 and t1", v.countPending)
+	}
+
+	v, _ = v.Update(TableCountMsg{Gen: v.countGen, Result: db.TableCount{Table: "t0", Count: 3}})
+	v, _ = v.Update(TableCountMsg{Gen: v.countGen, Result: db.TableCount{Table: "t1", Count: 5}})
+	if cmd := v.startCounting(); cmd != nil {
+		t.Errorf("startCounting() recounted tables already counted")
+	}
+
+	for range 3 {
+		v, _ = v.Update(runeKey('j'))
+	}
+	if len(v.countPending) != 2 || !v.countPending["t2"] || !v.countPending["t3"] {
+		t.Errorf("countPending after scrolling = %v, want t2 and t3", v.countPending)
+	}
+}
@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eanda22/devhud/internal/db"
+)
+
+// nullInput is typed in the cell editor to set a cell to NULL.
+const nullInput = `\N`
+
+// pendingRow is a staged change to one row. Updates and deletes are keyed by
+// the row's primary key; inserts have no key.
+type pendingRow struct {
+	key     []db.ColumnValue
+	values  map[string]interface{}
+	columns []string
+	deleted bool
+}
+
+func (r *pendingRow) set(column string, value interface{}) {
+	if _, ok := r.values[column]; !ok {
+		r.columns = append(r.columns, column)
+	}
+	r.values[column] = value
+}
+
+// change returns the db change for the row, or false when nothing is staged.
+func (r *pendingRow) change() (db.RowChange, bool) {
+	var values []db.ColumnValue
+	for _, col := range r.columns {
+		values = append(values, db.ColumnValue{Column: col, Value: r.values[col]})
+	}
+	switch {
+	case r.key == nil:
+		return db.RowChange{Kind: db.ChangeInsert, Values: values}, true
+	case r.deleted:
+		return db.RowChange{Kind: db.ChangeDelete, Key: r.key}, true
+	case len(values) > 0:
+		return db.RowChange{Kind: db.ChangeUpdate, Key: r.key, Values: values}, true
+	}
+	return db.RowChange{}, false
+}
+
+// pendingEdits holds staged changes to a table until they are written in one
+// transaction. Changes to existing rows survive paging because they are keyed
+// by primary key; inserted rows are shown after the rows of every page.
+type pendingEdits struct {
+	keys    []string
+	rows    map[string]*pendingRow
+	order   []*pendingRow
+	inserts []*pendingRow
+}
+
+func newPendingEdits(keys []string) *pendingEdits {
+	return &pendingEdits{keys: keys, rows: make(map[string]*pendingRow)}
+}
+
+// Count returns the number of rows with staged changes.
+func (p *pendingEdits) Count() int {
+	n := len(p.inserts)
+	for _, r := range p.order {
+		if _, ok := r.change(); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// returns the staged change for a row on the page, creating it when create is set.
+func (p *pendingEdits) forRow(row map[string]interface{}, create bool) *pendingRow {
+	id := p.rowID(row)
+	if r, ok := p.rows[id]; ok || !create {
+		return r
+	}
+	key := make([]db.ColumnValue, len(p.keys))
+	for i, k := range p.keys {
+		key[i] = db.ColumnValue{Column: k, Value: row[k]}
+	}
+	r := &pendingRow{key: key, values: make(map[string]interface{})}
+	p.rows[id] = r
+	p.order = append(p.order, r)
+	return r
+}
+
+func (p *pendingEdits) rowID(row map[string]interface{}) string {
+	parts := make([]string, len(p.keys))
+	for i, k := range p.keys {
+		parts[i] = fmt.Sprintf("%T:%v", row[k], row[k])
+	}
+	return strings.Join(parts, "\x1f")
+}
+
+// stages a new row with every column at its default and returns its index
+// among the inserts.
+func (p *pendingEdits) Insert() int {
+	p.inserts = append(p.inserts, &pendingRow{values: make(map[string]interface{})})
+	return len(p.inserts) - 1
+}
+
+// stages a value for a cell of a page row or, past the page, an inserted row.
+func (p *pendingEdits) Set(page db.RowData, index int, column string, value interface{}) {
+	if index >= len(page) {
+		p.inserts[index-len(page)].set(column, value)
+		return
+	}
+	p.forRow(page[index], true).set(column, value)
+}
+
+// marks a page row for deletion, or unmarks it. An inserted row is dropped.
+func (p *pendingEdits) ToggleDelete(page db.RowData, index int) {
+	if index >= len(page) {
+		i := index - len(page)
+		p.inserts = append(p.inserts[:i], p.inserts[i+1:]...)
+		return
+	}
+	r := p.forRow(page[index], true)
+	r.deleted = !r.deleted
+}
+
+// discards the staged changes of one row.
+func (p *pendingEdits) Undo(page db.RowData, index int) {
+	if index >= len(page) {
+		p.ToggleDelete(page, index)
+		return
+	}
+	if r := p.forRow(page[index], false); r != nil {
+		r.values = make(map[string]interface{})
+		r.columns = nil
+		r.deleted = false
+	}
+}
+
+// Statements builds the statements for every staged change in the order the
+// rows were first edited, followed by the inserts.
+func (p *pendingEdits) Statements(client *db.Client, table string) ([]db.Statement, error) {
+	var statements []db.Statement
+	for _, r := range append(append([]*pendingRow{}, p.order...), p.inserts...) {
+		change, ok := r.change()
+		if !ok {
+			continue
+		}
+		stmt, err := client.ChangeStatement(table, change)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+	}
+	return statements, nil
+}
+
+// Apply returns the page with staged values applied and inserted rows
+// appended, and marks for the changes by row index.
+func (p *pendingEdits) Apply(page db.RowData) (db.RowData, GridEdits) {
+	rows := make(db.RowData, 0, len(page)+len(p.inserts))
+	marks := GridEdits{
+		Cells:    make(map[int]map[string]bool),
+		Deleted:  make(map[int]bool),
+		Inserted: make(map[int]bool),
+	}
+
+	addRow := func(row map[string]interface{}, r *pendingRow) {
+		i := len(rows)
+		if r != nil && len(r.columns) > 0 {
+			merged := make(map[string]interface{}, len(row))
+			for k, v := range row {
+				merged[k] = v
+			}
+			marks.Cells[i] = make(map[string]bool)
+			for _, col := range r.columns {
+				merged[col] = r.values[col]
+				marks.Cells[i][col] = true
+			}
+			row = merged
+		}
+		if r != nil && r.deleted {
+			marks.Deleted[i] = true
+		}
+		rows = append(rows, row)
+	}
+
+	for _, row := range page {
+		addRow(row, p.forRow(row, false))
+	}
+	for _, r := range p.inserts {
+		marks.Inserted[len(rows)] = true
+		addRow(make(map[string]interface{}), r)
+	}
+	return rows, marks
+}
+
+// formats a cell value for the cell editor; NULL is shown as nullInput.
+func editText(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return nullInput
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999999-07:00")
+	}
+	return fmt.Sprint(val)
+}
+
+// reads the cell editor's text back as a value.
+func parseEditText(text string) interface{} {
+	if text == nullInput {
+		return nil
+	}
+	return text
+}
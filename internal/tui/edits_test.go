@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eanda22/devhud/internal/db"
+)
+
+func TestPendingEdits(t *testing.T) {
+	page := db.RowData{
+		{"id": int64(1), "name": "apple"},
+		{"id": int64(2), "name": "banana"},
+	}
+	p := newPendingEdits([]string{"id"})
+
+	p.Set(page, 0, "name", "apricot")
+	p.Set(page, 0, "name", nil)
+	p.ToggleDelete(page, 1)
+	p.Set(page, len(page)+p.Insert(), "name", "cherry")
+	if n := p.Count(); n != 3 {
+		t.Fatalf("Count() = %d, want 3", n)
+	}
+
+	rows, marks := p.Apply(page)
+	if len(rows) != 3 || rows[0]["name"] != nil || rows[2]["name"] != "cherry" {
+		t.Errorf("Apply() rows = %v", rows)
+	}
+	if page[0]["name"] != "apple" {
+		t.Error("Apply() modified the page")
+	}
+	if !marks.Cells[0]["name"] || !marks.Deleted[1] || !marks.Inserted[2] {
+		t.Errorf("Apply() marks = %+v", marks)
+	}
+
+	// edits are keyed by primary key, so they follow the row to another page
+	moved := db.RowData{page[1], page[0]}
+	if _, marks := p.Apply(moved); !marks.Deleted[0] || !marks.Cells[1]["name"] {
+		t.Errorf("Apply() on a reordered page marks = %+v", marks)
+	}
+
+	var changes []db.RowChange
+	for _, r := range append(p.order, p.inserts...) {
+		if c, ok := r.change(); ok {
+			changes = append(changes, c)
+		}
+	}
+	want := []db.RowChange{
+		{Kind: db.ChangeUpdate, Key: []db.ColumnValue{{Column: "id", Value: int64(1)}}, Values: []db.ColumnValue{{Column: "name", Value: nil}}},
+		{Kind: db.ChangeDelete, Key: []db.ColumnValue{{Column: "id", Value: int64(2)}}},
+		{Kind: db.ChangeInsert, Values: []db.ColumnValue{{Column: "name", Value: "cherry"}}},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %+v, want %+v", changes, want)
+	}
+
+	p.Undo(page, 0)
+	p.Undo(page, 1)
+	p.Undo(page, 2)
+	if n := p.Count(); n != 0 {
+		t.Errorf("Count() after Undo() = %d, want 0", n)
+	}
+}
+
+func TestEditText(t *testing.T) {
+	for _, val := range []interface{}{nil, "plain", []byte("bytes"), int64(42)} {
+		got := parseEditText(editText(val))
+		want := val
+		switch v := val.(type) {
+		case []byte:
+			want = string(v)
+		case int64:
+			want = "42"
+		}
+		if got != want {
+			t.Errorf("parseEditText(editText(%v)) = %v, want %v", val, got, want)
+		}
+	}
+}
@@ -0,0 +1,717 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/docker"
+	"github.com/eanda22/devhud/internal/env"
+	"github.com/eanda22/devhud/internal/process"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+type EnvView struct {
+	service       *service.Service
+	dockerClient  *docker.Client
+	candidates    []*service.Service
+	vars          map[string]string
+	edited        map[string]string
+	removed       map[string]bool
+	keys          []string
+	selectedIndex int
+	revealed      bool
+	filter        string
+	input         textinput.Model
+	mode          string
+	editKey       string
+	diffWith      *service.Service
+	diff          []env.DiffEntry
+	drift         *env.DriftReport
+	viewport      viewport.Model
+	statusMessage string
+	error         error
+	ready         bool
+	shouldExit    bool
+}
+
+// creates an environment view for a service; candidates are offered for diffing.
+func NewEnvView(svc *service.Service, dockerClient *docker.Client, candidates []*service.Service, width, height int) *EnvView {
+	vp := viewport.New(width-4, height-6)
+	vp.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+
+	ti := textinput.New()
+	ti.CharLimit = 4096
+
+	var others []*service.Service
+	for _, c := range candidates {
+		if c.ID != svc.ID && (isDockerOrCompose(c) || c.PID != 0) {
+			others = append(others, c)
+		}
+	}
+
+	return &EnvView{
+		service:      svc,
+		dockerClient: dockerClient,
+		candidates:   others,
+		edited:       make(map[string]string),
+		removed:      make(map[string]bool),
+		input:        ti,
+		mode:         "list",
+		viewport:     vp,
+	}
+}
+
+func (v *EnvView) Init() tea.Cmd {
+	return v.fetchEnvCmd()
+}
+
+func (v *EnvView) Update(msg tea.Msg) (*EnvView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return v, tea.Quit
+		}
+		switch v.mode {
+		case "search", "edit", "add":
+			return v.updateInput(msg)
+		case "pick":
+			return v.updatePick(msg)
+		case "confirm":
+			return v.updateConfirm(msg)
+		case "diff", "files":
+			if msg.String() == "esc" || msg.String() == "q" {
+				v.mode = "list"
+				v.diff = nil
+				v.drift = nil
+				v.updateViewportContent()
+				return v, nil
+			}
+		default:
+			if handled, keyCmd := v.updateList(msg); handled {
+				return v, keyCmd
+			}
+		}
+
+	case EnvFetchedMsg:
+		v.ready = true
+		if msg.Error != nil {
+			v.error = msg.Error
+			v.viewport.SetContent("Error reading environment: " + msg.Error.Error())
+			return v, nil
+		}
+		v.vars = msg.Vars
+		v.error = nil
+		v.edited = make(map[string]string)
+		v.removed = make(map[string]bool)
+		v.rebuildKeys()
+		v.updateViewportContent()
+		return v, nil
+
+	case EnvDiffFetchedMsg:
+		if msg.Error != nil {
+			v.mode = "list"
+			v.statusMessage = fmt.Sprintf("Diff failed: %v", msg.Error)
+			return v, nil
+		}
+		v.diffWith = msg.Other
+		v.diff = env.Diff(v.effectiveVars(), msg.Vars)
+		v.mode = "diff"
+		v.viewport.GotoTop()
+		v.updateViewportContent()
+		return v, nil
+
+	case DotenvFetchedMsg:
+		if msg.Error != nil {
+			v.statusMessage = fmt.Sprintf("Reading .env files failed: %v", msg.Error)
+			return v, nil
+		}
+		v.drift = msg.Report
+		v.mode = "files"
+		v.viewport.GotoTop()
+		v.updateViewportContent()
+		return v, nil
+
+	case EnvAppliedMsg:
+		if msg.Error != nil {
+			v.statusMessage = fmt.Sprintf("Recreate failed: %v", msg.Error)
+			return v, nil
+		}
+		if len(msg.ContainerID) >= 12 {
+			v.service.ContainerID = msg.ContainerID[:12]
+			v.service.ID = v.service.ContainerID
+		}
+		v.statusMessage = "Container recreated with new environment"
+		if msg.Warning != "" {
+			v.statusMessage += " (" + msg.Warning + ")"
+		}
+		return v, v.fetchEnvCmd()
+
+	case tea.WindowSizeMsg:
+		v.viewport.Width = msg.Width - 4
+		v.viewport.Height = msg.Height - 6
+	}
+
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func (v *EnvView) updateList(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return true, tea.Quit
+	case "esc":
+		if v.filter != "" {
+			v.filter = ""
+			v.rebuildKeys()
+			v.updateViewportContent()
+			return true, nil
+		}
+		v.shouldExit = true
+		return true, nil
+	case "r":
+		return true, v.fetchEnvCmd()
+	case "up", "k":
+		if v.selectedIndex > 0 {
+			v.selectedIndex--
+			v.updateViewportContent()
+		}
+		return true, nil
+	case "down", "j":
+		if v.selectedIndex < len(v.keys)-1 {
+			v.selectedIndex++
+			v.updateViewportContent()
+		}
+		return true, nil
+	case "m":
+		v.revealed = !v.revealed
+		v.updateViewportContent()
+		return true, nil
+	case "/":
+		v.mode = "search"
+		v.input.Prompt = "/"
+		v.input.SetValue(v.filter)
+		v.input.CursorEnd()
+		v.input.Focus()
+		return true, v.input.Cursor.BlinkCmd()
+	case "y":
+		if key := v.selectedKey(); key != "" {
+			v.copyToClipboard(key+"="+v.effectiveVars()[key], "Copied "+key)
+		}
+		return true, nil
+	case "Y":
+		v.copyToClipboard(strings.Join(env.Format(v.effectiveVars()), "\n"), "Copied all variables")
+		return true, nil
+	case "D":
+		if len(v.candidates) == 0 {
+			v.statusMessage = "No other services to compare with"
+			return true, nil
+		}
+		v.mode = "pick"
+		v.selectedIndex = 0
+		v.updateViewportContent()
+		return true, nil
+	case "f":
+		return true, v.fetchDotenvCmd()
+	case "e":
+		key := v.selectedKey()
+		if key == "" || !v.editable() {
+			return true, nil
+		}
+		v.mode = "edit"
+		v.editKey = key
+		v.input.Prompt = key + "="
+		v.input.SetValue(v.effectiveVars()[key])
+		v.input.CursorEnd()
+		v.input.Focus()
+		return true, v.input.Cursor.BlinkCmd()
+	case "a":
+		if !v.editable() {
+			return true, nil
+		}
+		v.mode = "add"
+		v.input.Prompt = "new (KEY=VALUE): "
+		v.input.SetValue("")
+		v.input.Focus()
+		return true, v.input.Cursor.BlinkCmd()
+	case "x":
+		key := v.selectedKey()
+		if key == "" || !v.editable() {
+			return true, nil
+		}
+		if v.removed[key] {
+			delete(v.removed, key)
+		} else {
+			v.removed[key] = true
+		}
+		v.updateViewportContent()
+		return true, nil
+	case "w":
+		if !v.editable() {
+			return true, nil
+		}
+		if !v.dirty() {
+			v.statusMessage = "No changes to apply"
+			return true, nil
+		}
+		v.mode = "confirm"
+		return true, nil
+	case "u":
+		v.edited = make(map[string]string)
+		v.removed = make(map[string]bool)
+		v.rebuildKeys()
+		v.updateViewportContent()
+		v.statusMessage = "Changes discarded"
+		return true, nil
+	}
+	return false, nil
+}
+
+func (v *EnvView) updateInput(msg tea.KeyMsg) (*EnvView, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if v.mode == "search" {
+			v.filter = ""
+			v.rebuildKeys()
+			v.updateViewportContent()
+		}
+		v.mode = "list"
+		v.input.Blur()
+		return v, nil
+	case "enter":
+		value := v.input.Value()
+		switch v.mode {
+		case "edit":
+			if value != v.vars[v.editKey] {
+				v.edited[v.editKey] = value
+			} else {
+				delete(v.edited, v.editKey)
+			}
+			delete(v.removed, v.editKey)
+		case "add":
+			parts := strings.SplitN(value, "=", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+				v.statusMessage = "Expected KEY=VALUE"
+				return v, nil
+			}
+			key := strings.TrimSpace(parts[0])
+			v.edited[key] = parts[1]
+			delete(v.removed, key)
+			v.rebuildKeys()
+		}
+		v.mode = "list"
+		v.input.Blur()
+		v.updateViewportContent()
+		return v, nil
+	}
+
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	if v.mode == "search" {
+		v.filter = v.input.Value()
+		v.selectedIndex = 0
+		v.rebuildKeys()
+		v.updateViewportContent()
+	}
+	return v, cmd
+}
+
+func (v *EnvView) updatePick(msg tea.KeyMsg) (*EnvView, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		v.mode = "list"
+		v.selectedIndex = 0
+		v.updateViewportContent()
+	case "up", "k":
+		if v.selectedIndex > 0 {
+			v.selectedIndex--
+			v.updateViewportContent()
+		}
+	case "down", "j":
+		if v.selectedIndex < len(v.candidates)-1 {
+			v.selectedIndex++
+			v.updateViewportContent()
+		}
+	case "enter":
+		if v.selectedIndex < len(v.candidates) {
+			other := v.candidates[v.selectedIndex]
+			v.selectedIndex = 0
+			return v, v.fetchDiffCmd(other)
+		}
+	}
+	return v, nil
+}
+
+func (v *EnvView) updateConfirm(msg tea.KeyMsg) (*EnvView, tea.Cmd) {
+	v.mode = "list"
+	switch msg.String() {
+	case "y", "Y":
+		v.statusMessage = "Recreating container..."
+		return v, v.applyEnvCmd(env.Format(v.effectiveVars()))
+	default:
+		v.statusMessage = "Recreate cancelled"
+	}
+	return v, nil
+}
+
+func (v *EnvView) View() string {
+	if !v.ready {
+		return "Loading environment..."
+	}
+
+	title := fmt.Sprintf("Environment: %s", v.service.Name)
+	if v.mode == "diff" && v.diffWith != nil {
+		title = fmt.Sprintf("Environment diff: %s ↔ %s", v.service.Name, v.diffWith.Name)
+	} else if v.mode == "pick" {
+		title = fmt.Sprintf("Compare %s with...", v.service.Name)
+	} else if v.mode == "files" && v.drift != nil {
+		title = fmt.Sprintf(".env files: %s (%s)", v.service.Name, v.drift.Project.Dir)
+	}
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render(title)
+
+	var hint string
+	switch v.mode {
+	case "search", "edit", "add":
+		hint = v.input.View() + "  [enter] confirm  [esc] cancel"
+	case "pick":
+		hint = "[↑/↓] select  [enter] compare  [esc] back"
+	case "diff", "files":
+		hint = "[↑/↓] scroll  [esc] back"
+	case "confirm":
+		hint = confirmDeleteStyle.Render(" RECREATE ") +
+			fmt.Sprintf("  Recreate %s with %d change(s)? [y/N]", v.service.Name, v.changeCount())
+	default:
+		hint = "[esc] back  [/] search  [m]ask  [y]ank  [Y] yank all  [D]iff  [f]iles  [r]efresh"
+		if v.editable() {
+			hint += "  [e]dit  [a]dd  [x] remove  [u]ndo  [w]rite"
+		}
+	}
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render(hint)
+	if v.statusMessage != "" && v.mode != "confirm" {
+		footer += "  " + subtleStyle.Render(v.statusMessage)
+	}
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
+}
+
+func (v *EnvView) updateViewportContent() {
+	switch v.mode {
+	case "pick":
+		var lines []string
+		for i, c := range v.candidates {
+			prefix := "  "
+			if i == v.selectedIndex {
+				prefix = "> "
+			}
+			lines = append(lines, fmt.Sprintf("%s%-40s %s", prefix, truncate(c.Name, 40), c.Type))
+		}
+		v.viewport.SetContent(strings.Join(lines, "\n"))
+		ensureLineVisible(&v.viewport, v.selectedIndex)
+		return
+	case "diff":
+		v.viewport.SetContent(v.renderDiff())
+		return
+	case "files":
+		v.viewport.SetContent(v.renderDrift())
+		return
+	}
+
+	if len(v.keys) == 0 {
+		if v.filter != "" {
+			v.viewport.SetContent("No variables match " + v.filter)
+		} else {
+			v.viewport.SetContent("No environment variables")
+		}
+		return
+	}
+
+	effective := v.effectiveVars()
+	keyWidth := 0
+	for _, key := range v.keys {
+		if len(key) > keyWidth {
+			keyWidth = len(key)
+		}
+	}
+	if keyWidth > 40 {
+		keyWidth = 40
+	}
+
+	var lines []string
+	for i, key := range v.keys {
+		prefix := "  "
+		if i == v.selectedIndex {
+			prefix = "> "
+		}
+		value := v.displayValue(key, effective[key])
+		marker := " "
+		if v.removed[key] {
+			marker = "-"
+			value = subtleStyle.Render(value)
+		} else if _, ok := v.edited[key]; ok {
+			marker = "*"
+		}
+		lines = append(lines, fmt.Sprintf("%s%s %-*s %s", prefix, marker, keyWidth, truncate(key, keyWidth), value))
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+	ensureLineVisible(&v.viewport, v.selectedIndex)
+}
+
+func (v *EnvView) renderDiff() string {
+	if len(v.diff) == 0 {
+		return "Both environments are empty"
+	}
+
+	added := lipgloss.NewStyle().Foreground(lipgloss.Color("#2ECC71"))
+	removed := lipgloss.NewStyle().Foreground(lipgloss.Color("#E74C3C"))
+	changed := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500"))
+
+	var lines []string
+	var same int
+	for _, d := range v.diff {
+		left := v.displayValue(d.Key, d.Left)
+		right := v.displayValue(d.Key, d.Right)
+		switch d.Status {
+		case env.DiffLeftOnly:
+			lines = append(lines, removed.Render(fmt.Sprintf("- %s=%s", d.Key, left)))
+		case env.DiffRightOnly:
+			lines = append(lines, added.Render(fmt.Sprintf("+ %s=%s", d.Key, right)))
+		case env.DiffChanged:
+			lines = append(lines, changed.Render(fmt.Sprintf("~ %s: %s → %s", d.Key, left, right)))
+		default:
+			same++
+		}
+	}
+	if len(lines) == 0 {
+		return "Environments are identical"
+	}
+	lines = append(lines, "", subtleStyle.Render(fmt.Sprintf("%d identical variable(s) hidden", same)))
+	return strings.Join(lines, "\n")
+}
+
+func (v *EnvView) renderDrift() string {
+	report := v.drift
+	if len(report.Project.Files) == 0 && report.Project.Example == nil {
+		return "No .env, .env.local or .env.example in " + report.Project.Dir
+	}
+
+	warn := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")).Bold(true)
+	bad := lipgloss.NewStyle().Foreground(lipgloss.Color("#E74C3C"))
+	section := lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true)
+
+	var lines []string
+	if report.Stale {
+		lines = append(lines, warn.Render(fmt.Sprintf(
+			"⚠ .env files changed after %s started — restart it to pick up the new values", v.service.Name)), "")
+	}
+
+	lines = append(lines, section.Render("Files"))
+	for _, f := range report.Project.Files {
+		lines = append(lines, fmt.Sprintf("  %-14s %3d keys  modified %s", f.Name, len(f.Vars), f.ModTime.Format("2006-01-02 15:04:05")))
+	}
+	if report.Project.Example != nil {
+		lines = append(lines, fmt.Sprintf("  %-14s %3d keys", report.Project.Example.Name, len(report.Project.Example.Vars)))
+	}
+	lines = append(lines, "")
+
+	if report.Project.Example != nil {
+		lines = append(lines, section.Render("Missing compared to .env.example"))
+		if len(report.Missing) == 0 {
+			lines = append(lines, subtleStyle.Render("  none"))
+		}
+		for _, key := range report.Missing {
+			lines = append(lines, bad.Render("  "+key))
+		}
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, section.Render(fmt.Sprintf("Drift from running %s", v.service.Type)))
+	if len(report.Drift) == 0 {
+		lines = append(lines, subtleStyle.Render("  running environment matches the files"))
+	}
+	for _, d := range report.Drift {
+		file := v.displayValue(d.Key, d.Left)
+		if d.Status == env.DiffLeftOnly {
+			lines = append(lines, warn.Render(fmt.Sprintf("  %s: file=%s, not set in running environment", d.Key, file)))
+			continue
+		}
+		running := v.displayValue(d.Key, d.Right)
+		lines = append(lines, warn.Render(fmt.Sprintf("  %s: file=%s, running=%s", d.Key, file, running)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (v *EnvView) displayValue(key, value string) string {
+	if !v.revealed && env.IsSecret(key) {
+		return env.Mask(value)
+	}
+	return value
+}
+
+// returns the environment with pending edits and removals applied.
+func (v *EnvView) effectiveVars() map[string]string {
+	result := make(map[string]string, len(v.vars)+len(v.edited))
+	for k, val := range v.vars {
+		result[k] = val
+	}
+	for k, val := range v.edited {
+		result[k] = val
+	}
+	for k := range v.removed {
+		delete(result, k)
+	}
+	return result
+}
+
+func (v *EnvView) rebuildKeys() {
+	all := make(map[string]bool)
+	for k := range v.vars {
+		all[k] = true
+	}
+	for k := range v.edited {
+		all[k] = true
+	}
+
+	filter := strings.ToLower(v.filter)
+	v.keys = v.keys[:0]
+	for k := range all {
+		if filter == "" || strings.Contains(strings.ToLower(k), filter) ||
+			(!env.IsSecret(k) && strings.Contains(strings.ToLower(v.vars[k]), filter)) {
+			v.keys = append(v.keys, k)
+		}
+	}
+	sort.Strings(v.keys)
+	if v.selectedIndex >= len(v.keys) {
+		v.selectedIndex = 0
+	}
+}
+
+func (v *EnvView) selectedKey() string {
+	if v.selectedIndex < len(v.keys) {
+		return v.keys[v.selectedIndex]
+	}
+	return ""
+}
+
+func (v *EnvView) editable() bool {
+	return isDockerOrCompose(v.service) && v.dockerClient != nil
+}
+
+func (v *EnvView) dirty() bool {
+	return v.changeCount() > 0
+}
+
+func (v *EnvView) changeCount() int {
+	return len(v.edited) + len(v.removed)
+}
+
+func (v *EnvView) copyToClipboard(text, message string) {
+	if err := clipboard.WriteAll(text); err != nil {
+		v.statusMessage = fmt.Sprintf("Copy failed: %v", err)
+		return
+	}
+	v.statusMessage = message
+}
+
+// reads the environment of a container or process.
+func loadServiceEnv(dockerClient *docker.Client, svc *service.Service) (map[string]string, error) {
+	if isDockerOrCompose(svc) {
+		if dockerClient == nil {
+			return nil, fmt.Errorf("Docker unavailable")
+		}
+		entries, err := dockerClient.GetEnv(svc.ContainerID)
+		if err != nil {
+			return nil, err
+		}
+		return env.Parse(entries), nil
+	}
+	if svc.PID == 0 {
+		return nil, fmt.Errorf("no PID for %s", svc.Name)
+	}
+	return env.ReadProcess(svc.PID)
+}
+
+// fetches the environment of the viewed service.
+func (v *EnvView) fetchEnvCmd() tea.Cmd {
+	svc := v.service
+	return func() tea.Msg {
+		vars, err := loadServiceEnv(v.dockerClient, svc)
+		return EnvFetchedMsg{Vars: vars, Error: err}
+	}
+}
+
+// fetches the environment of another service for comparison.
+func (v *EnvView) fetchDiffCmd(other *service.Service) tea.Cmd {
+	return func() tea.Msg {
+		vars, err := loadServiceEnv(v.dockerClient, other)
+		return EnvDiffFetchedMsg{Other: other, Vars: vars, Error: err}
+	}
+}
+
+// loads the service's .env files and compares them with its running environment.
+func (v *EnvView) fetchDotenvCmd() tea.Cmd {
+	svc := v.service
+	running := v.vars
+	return func() tea.Msg {
+		var dir string
+		var startedAt time.Time
+		if isDockerOrCompose(svc) && v.dockerClient != nil {
+			if info, err := v.dockerClient.GetProjectInfo(svc.ContainerID); err == nil {
+				dir = info.WorkingDir
+				startedAt = info.StartedAt
+			}
+		} else if svc.PID != 0 {
+			dir, _ = process.WorkingDir(svc.PID)
+			startedAt, _ = process.StartTime(svc.PID)
+		}
+		if dir == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return DotenvFetchedMsg{Error: err}
+			}
+			dir = cwd
+		}
+
+		project, err := env.LoadProject(dir)
+		if err != nil {
+			return DotenvFetchedMsg{Error: err}
+		}
+		return DotenvFetchedMsg{Report: env.Compare(project, running, startedAt)}
+	}
+}
+
+// recreates the container with the edited environment.
+func (v *EnvView) applyEnvCmd(entries []string) tea.Cmd {
+	containerID := v.service.ContainerID
+	return func() tea.Msg {
+		newID, warning, err := v.dockerClient.RecreateWithEnv(containerID, entries)
+		return EnvAppliedMsg{ContainerID: newID, Warning: warning, Error: err}
+	}
+}
+
+// scrolls a viewport so the given content line is on screen.
+func ensureLineVisible(vp *viewport.Model, line int) {
+	visible := vp.Height - vp.Style.GetVerticalFrameSize()
+	if line < vp.YOffset {
+		vp.SetYOffset(line)
+	} else if visible > 0 && line >= vp.YOffset+visible {
+		vp.SetYOffset(line - visible + 1)
+	}
+}
@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/db"
+)
+
+// exportScope is one choice of which rows to export, picked by its key.
+type exportScope struct {
+	key   string
+	label string
+}
+
+// exportRequest is what the user picked in the export prompt.
+type exportRequest struct {
+	scope  string
+	format string
+	path   string
+}
+
+const (
+	exportStepScope = iota
+	exportStepFormat
+	exportStepPath
+	exportStepOverwrite
+)
+
+// exportFormatKeys maps the format keys shown in the prompt to db formats.
+var exportFormatKeys = map[string]string{
+	"c": db.FormatCSV,
+	"n": db.FormatNDJSON,
+	"s": db.FormatSQL,
+}
+
+// exportPrompt asks for the rows to export, the format and the output file in
+// the footer of a view. The scope step is skipped when there is one choice, and
+// an existing file is only replaced after confirmation. It also tracks the
+// running export so it can be cancelled.
+type exportPrompt struct {
+	active bool
+	step   int
+	name   string
+	scopes []exportScope
+	scope  string
+	format string
+	path   textinput.Model
+	cancel context.CancelFunc
+}
+
+func newExportPrompt() exportPrompt {
+	ti := textinput.New()
+	ti.Prompt = "file: "
+	ti.CharLimit = 1024
+	return exportPrompt{path: ti}
+}
+
+// opens the prompt; name is the default file name without an extension.
+func (p *exportPrompt) Start(name string, scopes ...exportScope) {
+	p.active = true
+	p.name = name
+	p.scopes = scopes
+	p.scope = ""
+	p.step = exportStepScope
+	if len(scopes) == 1 {
+		p.scope = scopes[0].key
+		p.step = exportStepFormat
+	}
+}
+
+func (p *exportPrompt) Active() bool {
+	return p.active
+}
+
+// handles a key while the prompt is open. It returns the request once a file
+// name is confirmed; esc closes the prompt without one.
+func (p *exportPrompt) Update(msg tea.KeyMsg) (*exportRequest, tea.Cmd) {
+	if msg.String() == "esc" {
+		p.active = false
+		p.path.Blur()
+		return nil, nil
+	}
+
+	switch p.step {
+	case exportStepScope:
+		for _, s := range p.scopes {
+			if msg.String() == s.key {
+				p.scope = s.key
+				p.step = exportStepFormat
+			}
+		}
+	case exportStepFormat:
+		if format, ok := exportFormatKeys[msg.String()]; ok {
+			p.format = format
+			p.step = exportStepPath
+			p.path.SetValue("./" + p.name + "." + format)
+			p.path.CursorEnd()
+			return nil, p.path.Focus()
+		}
+	case exportStepPath:
+		if msg.String() == "enter" {
+			path := strings.TrimSpace(p.path.Value())
+			if path == "" {
+				return nil, nil
+			}
+			if _, err := os.Stat(path); err == nil {
+				p.step = exportStepOverwrite
+				p.path.Blur()
+				return nil, nil
+			}
+			return p.request(), nil
+		}
+		var cmd tea.Cmd
+		p.path, cmd = p.path.Update(msg)
+		return nil, cmd
+	case exportStepOverwrite:
+		if msg.String() == "y" || msg.String() == "Y" {
+			return p.request(), nil
+		}
+		p.step = exportStepPath
+		return nil, p.path.Focus()
+	}
+	return nil, nil
+}
+
+func (p *exportPrompt) request() *exportRequest {
+	p.active = false
+	p.path.Blur()
+	return &exportRequest{scope: p.scope, format: p.format, path: strings.TrimSpace(p.path.Value())}
+}
+
+// starts tracking an export. The context is cancelled by Stop.
+func (p *exportPrompt) Begin() context.Context {
+	p.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	return ctx
+}
+
+// reports whether an export started by Begin has not finished.
+func (p *exportPrompt) Running() bool {
+	return p.cancel != nil
+}
+
+// cancels the running export, if any; called too once it has finished.
+func (p *exportPrompt) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// renders the prompt for the footer.
+func (p *exportPrompt) View() string {
+	switch p.step {
+	case exportStepScope:
+		var choices []string
+		for _, s := range p.scopes {
+			choices = append(choices, fmt.Sprintf("[%s] %s", s.key, s.label))
+		}
+		return "Export " + strings.Join(choices, "  ") + "  [esc] cancel"
+	case exportStepFormat:
+		return "Export as [c]sv  [n]djson  [s]ql inserts  [esc] cancel"
+	case exportStepOverwrite:
+		return confirmDeleteStyle.Render(" OVERWRITE ") + fmt.Sprintf("  %s exists. Replace it? [y/N]", strings.TrimSpace(p.path.Value()))
+	}
+	return p.path.View() + "  [enter] export  [esc] cancel"
+}
+
+// writes an export to a file in the background. The export goes to a temporary
+// file next to path that replaces it once complete, so a failed or cancelled
+// export leaves an existing file untouched.
+func exportCmd(ctx context.Context, path string, write func(ctx context.Context, w io.Writer) (int, error)) tea.Cmd {
+	return func() tea.Msg {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		rows, err := db.ExportFile(path, func(w io.Writer) (int, error) {
+			return write(ctx, w)
+		})
+		if err != nil {
+			return ExportDoneMsg{Path: path, Error: err}
+		}
+		return ExportDoneMsg{Path: path, Rows: rows}
+	}
+}
+
+// describes a finished export for a status line.
+func exportStatus(msg ExportDoneMsg) string {
+	if errors.Is(msg.Error, context.Canceled) {
+		return "Export cancelled"
+	}
+	if msg.Error != nil {
+		return fmt.Sprintf("Export failed: %v", msg.Error)
+	}
+	return fmt.Sprintf("Exported %d row(s) to %s", msg.Rows, msg.Path)
+}
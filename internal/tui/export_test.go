@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExportPromptConfirmsOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.csv")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := newExportPrompt()
+	p.Start("users", exportScope{key: "p", label: "current page"})
+	p.Update(runeKey('c'))
+	p.path.SetValue(path)
+
+	if req, _ := p.Update(tea.KeyMsg{Type: tea.KeyEnter}); req != nil || p.step != exportStepOverwrite {
+		t.Fatalf("enter on an existing file = %+v, step %d, want the overwrite prompt", req, p.step)
+	}
+	if req, _ := p.Update(runeKey('n')); req != nil || p.step != exportStepPath {
+		t.Fatalf("declining = %+v, step %d, want back at the file name", req, p.step)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	req, _ := p.Update(runeKey('y'))
+	if req == nil || req.path != path || req.format != "csv" {
+		t.Errorf("confirming = %+v, want a csv export to %s", req, path)
+	}
+}
@@ -0,0 +1,465 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	gridMaxColumnWidth = 40
+	gridSeparator      = " │ "
+)
+
+// numericTypes are right-aligned in the grid. Sizes and modifiers such as
+// "(10,2)" or "unsigned" are stripped before lookup.
+var numericTypes = map[string]bool{
+	"int": true, "int2": true, "int4": true, "int8": true, "integer": true,
+	"tinyint": true, "smallint": true, "mediumint": true, "bigint": true,
+	"numeric": true, "decimal": true, "real": true, "money": true,
+	"float": true, "float4": true, "float8": true, "double": true, "double precision": true,
+	"serial": true, "smallserial": true, "bigserial": true,
+}
+
+var (
+	gridBoxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#7D56F4")).
+			Padding(0, 1)
+
+	gridHeaderStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7D56F4")).
+			Bold(true)
+
+	gridCursorStyle = lipgloss.NewStyle().
+			Background(lipgloss.Color("#7D56F4")).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Bold(true)
+
+	gridNullStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241")).
+			Italic(true)
+
+	gridEditedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFB86C")).
+			Bold(true)
+
+	gridDeletedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FF5555")).
+				Strikethrough(true)
+)
+
+// GridEdits marks staged changes by row index. Unset cells of inserted rows
+// show DEFAULT.
+type GridEdits struct {
+	Cells    map[int]map[string]bool
+	Deleted  map[int]bool
+	Inserted map[int]bool
+}
+
+// Grid renders rows as a table with a pinned header, a cell cursor and
+// horizontal scrolling. Enter opens a detail pane with the full cell value.
+type Grid struct {
+	columns   []db.ColumnInfo
+	rows      db.RowData
+	cells     [][]string
+	widths    []int
+	marks     map[string]string
+	edits     GridEdits
+	row       int
+	col       int
+	rowOffset int
+	colOffset int
+	width     int
+	height    int
+	detail    viewport.Model
+	showing   bool
+}
+
+// creates an empty grid that fills a width x height box, border included.
+func NewGrid(width, height int) *Grid {
+	g := &Grid{detail: viewport.New(width, height)}
+	g.detail.Style = gridBoxStyle
+	g.SetSize(width, height)
+	return g
+}
+
+// replaces the grid's data and moves the cursor to the first row. The cursor
+// stays in its column when the columns are unchanged, e.g. on the next page.
+func (g *Grid) SetData(columns []db.ColumnInfo, rows db.RowData) {
+	if !sameColumns(g.columns, columns) {
+		g.col, g.colOffset = 0, 0
+	}
+	g.columns = columns
+	g.rows = rows
+	g.row, g.rowOffset = 0, 0
+	g.showing = false
+	g.edits = GridEdits{}
+
+	g.cells = make([][]string, len(rows))
+	for r, row := range rows {
+		g.cells[r] = make([]string, len(columns))
+		for c, col := range columns {
+			g.cells[r][c] = formatCell(row[col.Name], col.Type)
+		}
+	}
+	g.widths = columnWidths(columns, g.cells)
+	for i, col := range columns {
+		g.widths[i] = min(max(g.widths[i], runewidth.StringWidth(g.header(col.Name))), gridMaxColumnWidth)
+	}
+	g.scrollToCursor()
+}
+
+// sets short markers shown after column names, e.g. a sort arrow. Call before SetData.
+func (g *Grid) SetMarks(marks map[string]string) {
+	g.marks = marks
+}
+
+// marks staged edits. Call after SetData, which clears them.
+func (g *Grid) SetEdits(edits GridEdits) {
+	g.edits = edits
+}
+
+// returns the index of the row under the cursor, or -1 when there are no rows.
+func (g *Grid) Row() int {
+	if len(g.rows) == 0 {
+		return -1
+	}
+	return g.row
+}
+
+// moves the cursor to a row, e.g. to keep it in place across SetData.
+func (g *Grid) SelectRow(row int) {
+	g.row = clamp(row, 0, len(g.rows)-1)
+	g.scrollToCursor()
+}
+
+// returns the column under the cursor.
+func (g *Grid) Column() (db.ColumnInfo, bool) {
+	if len(g.columns) == 0 {
+		return db.ColumnInfo{}, false
+	}
+	return g.columns[g.col], true
+}
+
+func (g *Grid) header(name string) string {
+	if mark := g.marks[name]; mark != "" {
+		return name + " " + mark
+	}
+	return name
+}
+
+func sameColumns(a, b []db.ColumnInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Grid) SetSize(width, height int) {
+	g.width = width - gridBoxStyle.GetHorizontalFrameSize()
+	g.height = height - gridBoxStyle.GetVerticalFrameSize()
+	g.detail.Width = width
+	g.detail.Height = height
+	g.scrollToCursor()
+}
+
+// reports whether the detail pane is open; it then takes all keys.
+func (g *Grid) DetailOpen() bool {
+	return g.showing
+}
+
+// handles navigation keys and reports whether the key was used.
+func (g *Grid) Update(msg tea.KeyMsg) bool {
+	if g.showing {
+		switch msg.String() {
+		case "esc", "enter", "q":
+			g.showing = false
+		default:
+			g.detail, _ = g.detail.Update(msg)
+		}
+		return true
+	}
+	if len(g.columns) == 0 {
+		return false
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		g.row--
+	case "down", "j":
+		g.row++
+	case "left", "h":
+		g.col--
+	case "right", "l":
+		g.col++
+	case "pgup", "ctrl+u":
+		g.row -= g.bodyHeight()
+	case "pgdown", "ctrl+d":
+		g.row += g.bodyHeight()
+	case "home", "g":
+		g.row = 0
+	case "end", "G":
+		g.row = len(g.rows) - 1
+	case "0":
+		g.col = 0
+	case "$":
+		g.col = len(g.columns) - 1
+	case "enter":
+		if len(g.rows) == 0 {
+			return false
+		}
+		g.openDetail()
+	default:
+		return false
+	}
+	g.row = clamp(g.row, 0, len(g.rows)-1)
+	g.col = clamp(g.col, 0, len(g.columns)-1)
+	g.scrollToCursor()
+	return true
+}
+
+// describes the cursor position, e.g. "row 3/100  col 2/7 email".
+func (g *Grid) Position() string {
+	if len(g.columns) == 0 {
+		return ""
+	}
+	col := fmt.Sprintf("col %d/%d %s", g.col+1, len(g.columns), g.columns[g.col].Name)
+	if len(g.rows) == 0 {
+		return col
+	}
+	return fmt.Sprintf("row %d/%d  %s", g.row+1, len(g.rows), col)
+}
+
+func (g *Grid) openDetail() {
+	col := g.columns[g.col]
+	title := col.Name
+	if col.Type != "" {
+		title += " (" + col.Type + ")"
+	}
+	content := gridHeaderStyle.Render(title) + "\n\n" + detailValue(g.rows[g.row][col.Name])
+	g.detail.SetContent(content)
+	g.detail.GotoTop()
+	g.showing = true
+}
+
+// rows available below the header and its rule.
+func (g *Grid) bodyHeight() int {
+	return max(g.height-2, 1)
+}
+
+func (g *Grid) scrollToCursor() {
+	body := g.bodyHeight()
+	if g.row < g.rowOffset {
+		g.rowOffset = g.row
+	} else if g.row >= g.rowOffset+body {
+		g.rowOffset = g.row - body + 1
+	}
+
+	if g.col < g.colOffset {
+		g.colOffset = g.col
+	}
+	for g.colOffset < g.col && !g.fits(g.colOffset, g.col) {
+		g.colOffset++
+	}
+}
+
+// reports whether columns first through last fit side by side.
+func (g *Grid) fits(first, last int) bool {
+	total := 0
+	for i := first; i <= last; i++ {
+		if i > first {
+			total += runewidth.StringWidth(gridSeparator)
+		}
+		total += g.widths[i]
+	}
+	return total <= g.width
+}
+
+// returns the visible column indexes from colOffset and their display widths.
+// The last column is clipped to the remaining space.
+func (g *Grid) visibleColumns() ([]int, []int) {
+	var indexes, widths []int
+	remaining := g.width
+	for i := g.colOffset; i < len(g.columns) && remaining > 0; i++ {
+		if len(indexes) > 0 {
+			remaining -= runewidth.StringWidth(gridSeparator)
+			if remaining <= 0 {
+				break
+			}
+		}
+		w := min(g.widths[i], remaining)
+		indexes = append(indexes, i)
+		widths = append(widths, w)
+		remaining -= w
+	}
+	return indexes, widths
+}
+
+func (g *Grid) View() string {
+	if g.showing {
+		return g.detail.View()
+	}
+
+	indexes, widths := g.visibleColumns()
+	var lines []string
+
+	var header, rule []string
+	for n, i := range indexes {
+		header = append(header, gridHeaderStyle.Render(pad(g.header(g.columns[i].Name), widths[n], false)))
+		rule = append(rule, strings.Repeat("─", widths[n]))
+	}
+	lines = append(lines, strings.Join(header, gridSeparator))
+	lines = append(lines, subtleStyle.Render(strings.Join(rule, "─┼─")))
+
+	if len(g.rows) == 0 {
+		lines = append(lines, subtleStyle.Render("No rows"))
+	}
+	end := min(g.rowOffset+g.bodyHeight(), len(g.rows))
+	for r := g.rowOffset; r < end; r++ {
+		var cells []string
+		for n, i := range indexes {
+			cells = append(cells, g.renderCell(r, i, widths[n]))
+		}
+		lines = append(lines, strings.Join(cells, gridSeparator))
+	}
+
+	body := lipgloss.NewStyle().
+		Width(g.width).
+		Height(g.height).
+		MaxHeight(g.height).
+		Render(strings.Join(lines, "\n"))
+	return gridBoxStyle.Render(body)
+}
+
+func (g *Grid) renderCell(row, col, width int) string {
+	name := g.columns[col].Name
+	edited := g.edits.Cells[row][name]
+	cell := g.cells[row][col]
+	if g.edits.Inserted[row] && !edited {
+		cell = "DEFAULT"
+	}
+	text := pad(cell, width, isNumericType(g.columns[col].Type))
+	switch {
+	case row == g.row && col == g.col:
+		return gridCursorStyle.Render(text)
+	case g.edits.Deleted[row]:
+		return gridDeletedStyle.Render(text)
+	case edited:
+		return gridEditedStyle.Render(text)
+	case g.edits.Inserted[row] || g.rows[row][name] == nil:
+		return gridNullStyle.Render(text)
+	}
+	return text
+}
+
+// truncates or pads text to exactly width cells, right-aligned for numbers.
+func pad(text string, width int, right bool) string {
+	text = runewidth.Truncate(text, width, "…")
+	if right {
+		return runewidth.FillLeft(text, width)
+	}
+	return runewidth.FillRight(text, width)
+}
+
+// sizes each column to its widest cell or header, up to gridMaxColumnWidth.
+func columnWidths(columns []db.ColumnInfo, cells [][]string) []int {
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		widths[i] = runewidth.StringWidth(col.Name)
+	}
+	for _, row := range cells {
+		for i, cell := range row {
+			widths[i] = max(widths[i], runewidth.StringWidth(cell))
+		}
+	}
+	for i := range widths {
+		widths[i] = min(widths[i], gridMaxColumnWidth)
+	}
+	return widths
+}
+
+// formats a value for a single grid line.
+func formatCell(val interface{}, colType string) string {
+	var s string
+	switch v := val.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		s = string(v)
+	case time.Time:
+		s = formatTime(v, colType)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		s = fmt.Sprint(v)
+	}
+	return strings.NewReplacer("\r\n", "↵", "\n", "↵", "\t", " ").Replace(s)
+}
+
+func formatTime(t time.Time, colType string) string {
+	switch {
+	case colType == "date":
+		return t.Format("2006-01-02")
+	case strings.HasPrefix(colType, "time") && !strings.HasPrefix(colType, "timestamp"):
+		return t.Format("15:04:05")
+	case t.Location() == time.UTC:
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return t.Format("2006-01-02 15:04:05 -07:00")
+}
+
+// returns the full value for the detail pane, with JSON pretty-printed.
+func detailValue(val interface{}) string {
+	var s string
+	switch v := val.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		s = string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(trimmed), "", "  "); err == nil {
+			return buf.String()
+		}
+	}
+	return s
+}
+
+// reports whether a column holds numbers, which are right-aligned.
+func isNumericType(colType string) bool {
+	t := strings.ToLower(colType)
+	if i := strings.IndexByte(t, '('); i >= 0 {
+		t = t[:i]
+	}
+	t = strings.TrimPrefix(t, "unsigned ")
+	t = strings.TrimSuffix(t, " unsigned")
+	return numericTypes[strings.TrimSpace(t)]
+}
+
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/db"
+)
+
+func TestFormatCell(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 14, 30, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		val     interface{}
+		colType string
+		want    string
+	}{
+		{name: "null", val: nil, want: "NULL"},
+		{name: "bytes", val: []byte("abc"), want: "abc"},
+		{name: "float", val: 1.50, colType: "numeric", want: "1.5"},
+		{name: "int", val: int64(42), colType: "bigint", want: "42"},
+		{name: "newlines", val: "a\nb\tc", want: "a↵b c"},
+		{name: "timestamp", val: ts, colType: "timestamp", want: "2024-03-01 14:30:05"},
+		{name: "date", val: ts, colType: "date", want: "2024-03-01"},
+		{name: "time", val: ts, colType: "time without time zone", want: "14:30:05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCell(tt.val, tt.colType); got != tt.want {
+				t.Errorf("formatCell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetailValue(t *testing.T) {
+	tests := []struct {
+		name string
+		val  interface{}
+		want string
+	}{
+		{name: "null", val: nil, want: "NULL"},
+		{name: "plain", val: "hello\nworld", want: "hello\nworld"},
+		{name: "json object", val: []byte(`{"a":1,"b":[true]}`), want: "{\n  \"a\": 1,\n  \"b\": [\n    true\n  ]\n}"},
+		{name: "invalid json", val: "{not json", want: "{not json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detailValue(tt.val); got != tt.want {
+				t.Errorf("detailValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNumericType(t *testing.T) {
+	tests := []struct {
+		colType string
+		want    bool
+	}{
+		{"integer", true},
+		{"int4", true},
+		{"BIGINT", true},
+		{"unsigned int", true},
+		{"decimal(10,2)", true},
+		{"double precision", true},
+		{"interval", false},
+		{"text", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.colType, func(t *testing.T) {
+			if got := isNumericType(tt.colType); got != tt.want {
+				t.Errorf("isNumericType(%q) = %v, want %v", tt.colType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	columns := []db.ColumnInfo{{Name: "id"}, {Name: "body"}}
+	cells := [][]string{{"1", strings.Repeat("x", 100)}, {"12345", "y"}}
+
+	got := columnWidths(columns, cells)
+	want := []int{5, gridMaxColumnWidth}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("width of column %d = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGridScrollsToCursor(t *testing.T) {
+	var columns []db.ColumnInfo
+	row := map[string]interface{}{}
+	for _, name := range []string{"aaaaaaaaaa", "bbbbbbbbbb", "cccccccccc", "dddddddddd"} {
+		columns = append(columns, db.ColumnInfo{Name: name})
+		row[name] = "v"
+	}
+	rows := db.RowData{row, row, row, row, row}
+
+	// 24 columns of content: two 10-wide columns and a separator fit, three do not.
+	g := NewGrid(28, 6)
+	g.SetData(columns, rows)
+
+	right := tea.KeyMsg{Type: tea.KeyRight}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	for range 3 {
+		g.Update(right)
+		g.Update(down)
+	}
+
+	if g.col != 3 || g.colOffset != 2 {
+		t.Errorf("col = %d, colOffset = %d, want 3 and 2", g.col, g.colOffset)
+	}
+	// 4 content rows leave 2 for data below the header and rule.
+	if g.row != 3 || g.rowOffset != 2 {
+		t.Errorf("row = %d, rowOffset = %d, want 3 and 2", g.row, g.rowOffset)
+	}
+
+	g.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !g.DetailOpen() {
+		t.Fatal("enter did not open the detail pane")
+	}
+	g.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if g.DetailOpen() {
+		t.Error("esc did not close the detail pane")
+	}
+}
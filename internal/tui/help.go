@@ -78,10 +78,10 @@ func helpContent() string {
 				{"k / ↑", "Move up"},
 				{"h / ←", "Focus sidebar"},
 				{"l / → / Enter", "Focus main list"},
-				{"1 / 2", "Jump to Containers / Processes"},
+				{"1 - 5", "Jump to Containers / Processes / Images / Volumes / Networks"},
 				{"G", "Jump to last item"},
 				{"gg", "Jump to first item"},
-				{"Tab", "Toggle detail panel"},
+				{"Tab", "Toggle detail panel (resource sparklines)"},
 			},
 		},
 		{
@@ -93,6 +93,134 @@ func helpContent() string {
 				{"l", "View logs"},
 				{"d", "Delete (with confirm)"},
 				{"i", "Inspect JSON"},
+				{"e", "Environment variables"},
+				{"m", "Metrics history charts"},
+				{"b", "Browse database"},
+			},
+		},
+		{
+			title: "Images",
+			keys: [][2]string{
+				{"p", "Pull the tag again"},
+				{"d", "Remove the tag or dangling image (with confirm)"},
+				{"P", "Prune dangling images (with confirm)"},
+				{"r", "Refresh"},
+			},
+		},
+		{
+			title: "Volumes",
+			keys: [][2]string{
+				{"d", "Remove the volume and its data (with confirm)"},
+				{"P", "Prune volumes no container mounts (with confirm)"},
+				{"r", "Refresh"},
+			},
+		},
+		{
+			title: "Docker Cleanup",
+			keys: [][2]string{
+				{"C", "Open the cleanup (dashboard)"},
+				{"Space / x", "Tick stopped containers, dangling images, unused volumes, build cache"},
+				{"Enter", "Prune the ticked kinds (with confirm), then show the space reclaimed"},
+				{"r", "Read disk usage again"},
+			},
+		},
+		{
+			title: "Container Shell",
+			keys: [][2]string{
+				{"Open Shell", "Open bash, zsh, ash or sh, whichever the container has (action menu)"},
+				{"Open Shell As...", "Choose the shell, user and working directory first"},
+				{"Exec: <name>", "Run a command configured for the image, e.g. psql"},
+				{"Tab / Enter", "Next field / open the shell (shell form)"},
+			},
+		},
+		{
+			title: "Environment View",
+			keys: [][2]string{
+				{"/", "Search keys and values"},
+				{"m", "Show / mask secret values"},
+				{"y / Y", "Copy variable / all variables"},
+				{"D", "Diff against another service"},
+				{"f", "Compare with .env files (missing keys, drift)"},
+				{"e / a / x", "Edit / add / remove (containers)"},
+				{"u", "Discard pending changes"},
+				{"w", "Recreate container with changes"},
+			},
+		},
+		{
+			title: "Database Browser",
+			keys: [][2]string{
+				{"Enter", "Open table / collection"},
+				{"↑ ↓ ← →", "Move the cell cursor (columns scroll horizontally)"},
+				{"Enter", "Show full cell value (JSON pretty-printed)"},
+				{"n / p", "Next / previous page"},
+				{"/", "Filter the current column (= 5, > 5, like %a%, null, 1..9)"},
+				{"/", "Filter documents with a JSON query (MongoDB)"},
+				{"s", "Sort by the current column (asc, desc, off)"},
+				{"x", "Export the page or whole table (CSV, NDJSON, INSERTs)"},
+				{"e", "Edit the current cell (\\N sets NULL)"},
+				{"a / D", "Add a row / mark the row for deletion"},
+				{"u / U", "Undo the row's changes / discard all changes"},
+				{"w", "Preview the SQL, then commit in one transaction"},
+				{"Esc", "Clear filters"},
+				{"s", "Open the SQL console (table list)"},
+				{"i", "Inspect table schema (table list)"},
+				{"d", "Switch database / schema (table list)"},
+				{"a", "Show sessions, queries and locks (table list)"},
+				{"c", "Edit host, port, user, password, database, SSL mode"},
+			},
+		},
+		{
+			title: "Schema Inspector",
+			keys: [][2]string{
+				{"Tab / Shift+Tab", "Select foreign key"},
+				{"Enter", "Open the referenced table's schema"},
+				{"Esc", "Back to the previous table"},
+				{"y", "Copy DDL"},
+			},
+		},
+		{
+			title: "Database Activity",
+			keys: [][2]string{
+				{"c", "Cancel the session's query (with confirm)"},
+				{"T", "Terminate the session (with confirm)"},
+				{"b", "Jump to the session blocking this one"},
+				{"i", "Show / hide idle sessions"},
+				{"p", "Pause / resume refreshing every 2s"},
+			},
+		},
+		{
+			title: "Database Snapshots",
+			keys: [][2]string{
+				{"n", "Take a snapshot (named after the time by default)"},
+				{"Enter", "Restore the selected snapshot (with confirm)"},
+				{"D", "Delete the selected snapshot (with confirm)"},
+			},
+		},
+		{
+			title: "SQL Console",
+			keys: [][2]string{
+				{"Ctrl+R", "Run query (esc cancels a running query)"},
+				{"Ctrl+P / Ctrl+N", "Previous / next query from history"},
+				{"Ctrl+O", "Toggle read-only mode (writes need confirmation)"},
+				{"Tab", "Switch between editor and results"},
+				{"Ctrl+X", "Export results (CSV, NDJSON, INSERTs)"},
+				{"Ctrl+L", "Clear editor"},
+			},
+		},
+		{
+			title: "Redis Browser",
+			keys: [][2]string{
+				{"/", "SCAN keys by MATCH pattern"},
+				{"Enter", "View key value"},
+				{"r", "Rescan keys / reload value"},
+			},
+		},
+		{
+			title: "Alerts",
+			keys: [][2]string{
+				{"↑ / ↓", "Select alert (shows last log lines)"},
+				{"x", "Dismiss alert"},
+				{"X", "Dismiss all alerts"},
 			},
 		},
 		{
@@ -101,6 +229,7 @@ func helpContent() string {
 				{"/", "Enter SEARCH mode"},
 				{":", "Open command bar"},
 				{"?", "Open this help overlay"},
+				{"!", "Open crash alerts"},
 				{"Esc", "Return to NORMAL / clear filter"},
 			},
 		},
@@ -118,9 +247,13 @@ func helpContent() string {
 			title: "Command Grammar",
 			keys: [][2]string{
 				{"<verb> <name>", "Primary syntax (e.g. stop nginx)"},
-				{"s / r / l / d / i / b", "Single-letter verb aliases"},
+				{"s / r / l / d / i / b / e / m", "Single-letter verb aliases"},
 				{"containers <action> <name>", "Category syntax (e.g. c stop api)"},
-				{"c / p / db", "Short aliases for categories"},
+				{"c / p / db / img / vol / net", "Short aliases for categories"},
+				{"images pull <image>", "Pull an image (also remove, prune)"},
+				{"volumes remove <volume>", "Remove a volume (also prune)"},
+				{"connect <name>", "Browse a saved connection from config.json"},
+				{"cleanup", "Open the Docker disk cleanup"},
 				{"help", "Open help overlay"},
 				{"quit / q", "Quit devhud"},
 				{"", "Completions are context-aware per verb"},
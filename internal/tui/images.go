@@ -0,0 +1,372 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/docker"
+)
+
+// imageRow is one line of the images list: an image under one of its tags, or
+// a dangling image, which has no tag.
+type imageRow struct {
+	image docker.Image
+	tag   string
+}
+
+// ref returns the name the row's image is pulled and removed by.
+func (r imageRow) ref() string {
+	if r.tag == "" {
+		return shortImageID(r.image.ID)
+	}
+	return r.tag
+}
+
+// expands images into one row per tag.
+func imageRows(images []docker.Image) []imageRow {
+	var rows []imageRow
+	for _, img := range images {
+		if img.Dangling() {
+			rows = append(rows, imageRow{image: img})
+			continue
+		}
+		for _, tag := range img.Tags {
+			rows = append(rows, imageRow{image: img, tag: tag})
+		}
+	}
+	return rows
+}
+
+// ImagesPanel lists local images with the containers using them, and pulls,
+// removes and prunes them. Removing and pruning ask for confirmation first.
+type ImagesPanel struct {
+	dockerClient  *docker.Client
+	images        []docker.Image
+	rows          []imageRow
+	selectedIndex int
+	loaded        bool
+	error         error
+	// mode is "list", or "remove" or "prune" while confirming.
+	mode   string
+	target string
+	// busy names the image being pulled or removed.
+	busy          string
+	statusMessage string
+}
+
+func NewImagesPanel(dockerClient *docker.Client) *ImagesPanel {
+	return &ImagesPanel{dockerClient: dockerClient, mode: "list"}
+}
+
+func (p *ImagesPanel) Refresh() tea.Cmd {
+	return func() tea.Msg {
+		if p.dockerClient == nil {
+			return ImagesListedMsg{Error: fmt.Errorf("docker unavailable")}
+		}
+		images, err := p.dockerClient.ListImages()
+		return ImagesListedMsg{Images: images, Error: err}
+	}
+}
+
+func (p *ImagesPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case ImagesListedMsg:
+		p.loaded = true
+		p.error = msg.Error
+		if msg.Error != nil {
+			return nil
+		}
+		selected := ""
+		if p.selectedIndex < len(p.rows) {
+			selected = p.rows[p.selectedIndex].ref()
+		}
+		p.images = msg.Images
+		p.rows = imageRows(msg.Images)
+		p.selectedIndex = moveSelection(p.selectedIndex, 0, len(p.rows))
+		for i, row := range p.rows {
+			if row.ref() == selected {
+				p.selectedIndex = i
+			}
+		}
+		return nil
+
+	case ImageActionMsg:
+		p.busy = ""
+		if msg.Error != nil {
+			p.statusMessage = fmt.Sprintf("%s failed: %v", msg.Action, msg.Error)
+		} else {
+			p.statusMessage = msg.Message
+		}
+		return p.Refresh()
+
+	case tea.KeyMsg:
+		if p.mode != "list" {
+			return p.updateConfirm(msg)
+		}
+		return p.updateList(msg)
+	}
+	return nil
+}
+
+func (p *ImagesPanel) updateList(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		p.selectedIndex = moveSelection(p.selectedIndex, -1, len(p.rows))
+	case "down", "j":
+		p.selectedIndex = moveSelection(p.selectedIndex, 1, len(p.rows))
+	case "g":
+		p.selectedIndex = 0
+	case "G":
+		p.selectedIndex = moveSelection(len(p.rows), 0, len(p.rows))
+	case "r":
+		p.statusMessage = ""
+		return p.Refresh()
+	case "p":
+		row, ok := p.selected()
+		if !ok {
+			return nil
+		}
+		if row.tag == "" {
+			p.statusMessage = "dangling images have no tag to pull"
+			return nil
+		}
+		return p.Pull(row.tag)
+	case "d":
+		if row, ok := p.selected(); ok {
+			p.ConfirmRemove(row.ref())
+		}
+	case "P":
+		p.ConfirmPrune()
+	}
+	return nil
+}
+
+func (p *ImagesPanel) updateConfirm(msg tea.KeyMsg) tea.Cmd {
+	mode, target := p.mode, p.target
+	p.mode, p.target = "list", ""
+	if msg.String() != "y" && msg.String() != "Y" {
+		p.statusMessage = "Cancelled"
+		return nil
+	}
+	if mode == "prune" {
+		return p.pruneCmd()
+	}
+	return p.removeCmd(target)
+}
+
+func (p *ImagesPanel) selected() (imageRow, bool) {
+	if p.selectedIndex >= len(p.rows) {
+		return imageRow{}, false
+	}
+	return p.rows[p.selectedIndex], true
+}
+
+// pulls ref, or pulls it again to update the tag.
+func (p *ImagesPanel) Pull(ref string) tea.Cmd {
+	if p.busy != "" {
+		p.statusMessage = "busy with " + p.busy
+		return nil
+	}
+	p.busy = ref
+	p.statusMessage = "Pulling " + ref + "..."
+	return func() tea.Msg {
+		if p.dockerClient == nil {
+			return ImageActionMsg{Action: "Pull", Error: fmt.Errorf("docker unavailable")}
+		}
+		status, err := p.dockerClient.PullImage(ref)
+		if status == "" {
+			status = "Pulled " + ref
+		}
+		return ImageActionMsg{Action: "Pull", Message: status, Error: err}
+	}
+}
+
+// asks to remove the image named by ref, a tag or an ID.
+func (p *ImagesPanel) ConfirmRemove(ref string) {
+	p.mode = "remove"
+	p.target = ref
+}
+
+// asks to prune dangling images, unless there are none to prune.
+func (p *ImagesPanel) ConfirmPrune() {
+	if p.loaded && p.error == nil {
+		if count, _ := p.prunable(); count == 0 {
+			p.statusMessage = "No dangling images to prune"
+			return
+		}
+	}
+	p.mode = "prune"
+}
+
+// counts the dangling images a prune deletes and their size. Dangling images
+// used by a container are kept.
+func (p *ImagesPanel) prunable() (int, int64) {
+	var count int
+	var size int64
+	for _, img := range p.images {
+		if img.Dangling() && len(img.Containers) == 0 {
+			count++
+			size += img.Size
+		}
+	}
+	return count, size
+}
+
+func (p *ImagesPanel) removeCmd(ref string) tea.Cmd {
+	if p.busy != "" {
+		p.statusMessage = "busy with " + p.busy
+		return nil
+	}
+	p.busy = ref
+	p.statusMessage = "Removing " + ref + "..."
+	return func() tea.Msg {
+		if p.dockerClient == nil {
+			return ImageActionMsg{Action: "Remove", Error: fmt.Errorf("docker unavailable")}
+		}
+		if err := p.dockerClient.RemoveImage(ref); err != nil {
+			return ImageActionMsg{Action: "Remove", Error: err}
+		}
+		return ImageActionMsg{Action: "Remove", Message: "Removed " + ref}
+	}
+}
+
+func (p *ImagesPanel) pruneCmd() tea.Cmd {
+	if p.busy != "" {
+		p.statusMessage = "busy with " + p.busy
+		return nil
+	}
+	p.busy = "prune"
+	p.statusMessage = "Pruning dangling images..."
+	return func() tea.Msg {
+		if p.dockerClient == nil {
+			return ImageActionMsg{Action: "Prune", Error: fmt.Errorf("docker unavailable")}
+		}
+		reclaimed, err := p.dockerClient.PruneDanglingImages()
+		if err != nil {
+			return ImageActionMsg{Action: "Prune", Error: err}
+		}
+		return ImageActionMsg{Action: "Prune", Message: fmt.Sprintf("Pruned dangling images, reclaimed %s", formatBytes(int64(reclaimed)))}
+	}
+}
+
+// Refs returns the tags and dangling image IDs, for completion.
+func (p *ImagesPanel) Refs() []string {
+	refs := make([]string, len(p.rows))
+	for i, row := range p.rows {
+		refs[i] = row.ref()
+	}
+	return refs
+}
+
+func (p *ImagesPanel) Rows(focused bool) []string {
+	header := fmt.Sprintf("%-44s %-12s %-10s %-10s %s\n", "REPOSITORY:TAG", "IMAGE ID", "SIZE", "CREATED", "CONTAINERS")
+	rows := []string{header}
+	switch {
+	case p.error != nil:
+		return append(rows, fmt.Sprintf("Error: %v\n", p.error))
+	case !p.loaded:
+		return append(rows, subtleStyle.Render("Loading images...")+"\n")
+	case len(p.rows) == 0:
+		return append(rows, subtleStyle.Render("No local images")+"\n")
+	}
+
+	for i, r := range p.rows {
+		name := r.tag
+		if name == "" {
+			name = "<none>:<none>"
+		}
+		containers := "-"
+		if len(r.image.Containers) > 0 {
+			containers = strings.Join(r.image.Containers, ", ")
+		}
+		line := fmt.Sprintf("%-44s %-12s %-10s %-10s %s",
+			truncate(name, 43),
+			shortImageID(r.image.ID),
+			formatBytes(r.image.Size),
+			formatAge(time.Since(r.image.Created)),
+			truncate(containers, 40),
+		)
+		switch {
+		case p.busy == r.ref():
+			line = operatingRowStyle.Render(line)
+		case i == p.selectedIndex && focused:
+			line = selectedRowStyle.Render(line)
+		case r.tag == "":
+			line = subtleStyle.Render(line)
+		}
+		rows = append(rows, line+"\n")
+	}
+	return rows
+}
+
+func (p *ImagesPanel) Selected() int {
+	return p.selectedIndex
+}
+
+func (p *ImagesPanel) Hints() string {
+	return "[j/k] Nav  [p]ull  [d]el  [P]rune dangling  [r]efresh  [h] Back  [:] Cmd"
+}
+
+func (p *ImagesPanel) Prompt() string {
+	switch p.mode {
+	case "remove":
+		question := fmt.Sprintf("Remove %s?", p.target)
+		for _, row := range p.rows {
+			if row.ref() == p.target && len(row.image.Containers) > 0 {
+				question = fmt.Sprintf("Remove %s (used by %s)?", p.target, strings.Join(row.image.Containers, ", "))
+			}
+		}
+		return confirmDeleteStyle.Render(" REMOVE ") + "  " + question + " [y/N]"
+	case "prune":
+		if !p.loaded || p.error != nil {
+			return confirmDeleteStyle.Render(" PRUNE ") + "  Delete all unused dangling images? [y/N]"
+		}
+		count, size := p.prunable()
+		return confirmDeleteStyle.Render(" PRUNE ") + fmt.Sprintf("  Delete %d dangling images (%s)? [y/N]", count, formatBytes(size))
+	}
+	return ""
+}
+
+func (p *ImagesPanel) Status() string {
+	return p.statusMessage
+}
+
+func (p *ImagesPanel) Summary() string {
+	if !p.loaded || p.error != nil {
+		return ""
+	}
+	var size int64
+	for _, img := range p.images {
+		size += img.Size
+	}
+	return fmt.Sprintf("Images: %d, %s", len(p.images), formatBytes(size))
+}
+
+// shortens an image ID to the 12 hex digits docker shows.
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// formats how long ago something was created, e.g. "3d ago".
+func formatAge(d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 2*day:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 60*day:
+		return fmt.Sprintf("%dd ago", int(d/day))
+	case d < 730*day:
+		return fmt.Sprintf("%dmo ago", int(d/(30*day)))
+	}
+	return fmt.Sprintf("%dy ago", int(d/(365*day)))
+}
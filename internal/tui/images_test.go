@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/docker"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestImageRows(t *testing.T) {
+	images := []docker.Image{
+		{ID: "sha256:aaaaaaaaaaaaaaaa", Tags: []string{"postgres:16", "postgres:latest"}},
+		{ID: "sha256:bbbbbbbbbbbbbbbb"},
+	}
+	var refs []string
+	for _, row := range imageRows(images) {
+		refs = append(refs, row.ref())
+	}
+	want := []string{"postgres:16", "postgres:latest", "bbbbbbbbbbbb"}
+	if !stringSliceEqual(refs, want) {
+		t.Errorf("imageRows() refs = %v, want %v", refs, want)
+	}
+}
+
+func TestImagesPanelPrune(t *testing.T) {
+	p := NewImagesPanel(nil)
+	p.Update(ImagesListedMsg{Images: []docker.Image{
+		{ID: "sha256:1", Tags: []string{"app:dev"}, Size: 100},
+		{ID: "sha256:2", Size: 20},
+		{ID: "sha256:3", Size: 30, Containers: []string{"old-app"}},
+	}})
+
+	if count, size := p.prunable(); count != 1 || size != 20 {
+		t.Errorf("prunable() = %d, %d, want 1, 20", count, size)
+	}
+	p.ConfirmPrune()
+	if prompt := p.Prompt(); !strings.Contains(prompt, "Delete 1 dangling images (20 B)") {
+		t.Errorf("Prompt() = %q", prompt)
+	}
+	if cmd := p.Update(runeKey('n')); cmd != nil || p.Prompt() != "" {
+		t.Errorf("n should cancel the prune")
+	}
+
+	p.Update(ImagesListedMsg{Images: []docker.Image{{ID: "sha256:1", Tags: []string{"app:dev"}}}})
+	p.ConfirmPrune()
+	if p.Prompt() != "" {
+		t.Errorf("prune confirmed with no dangling images")
+	}
+}
+
+func TestImagesPanelKeepsSelection(t *testing.T) {
+	p := NewImagesPanel(nil)
+	p.Update(ImagesListedMsg{Images: []docker.Image{
+		{ID: "sha256:1", Tags: []string{"a:1"}},
+		{ID: "sha256:2", Tags: []string{"b:1"}},
+	}})
+	p.Update(runeKey('j'))
+	p.Update(ImagesListedMsg{Images: []docker.Image{
+		{ID: "sha256:0", Tags: []string{"new:1"}},
+		{ID: "sha256:1", Tags: []string{"a:1"}},
+		{ID: "sha256:2", Tags: []string{"b:1"}},
+	}})
+	if row, _ := p.selected(); row.ref() != "b:1" {
+		t.Errorf("selected %q after refresh, want b:1", row.ref())
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{30 * time.Hour, "30h ago"},
+		{10 * 24 * time.Hour, "10d ago"},
+		{90 * 24 * time.Hour, "3mo ago"},
+		{800 * 24 * time.Hour, "2y ago"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(tt.d); got != tt.want {
+			t.Errorf("formatAge(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
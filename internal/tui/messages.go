@@ -1,6 +1,15 @@
 package tui
 
-import "github.com/eanda22/devhud/internal/db"
+import (
+	"time"
+
+	"github.com/eanda22/devhud/internal/alert"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/docker"
+	"github.com/eanda22/devhud/internal/env"
+	"github.com/eanda22/devhud/internal/service"
+	"github.com/eanda22/devhud/internal/snapshot"
+)
 
 type OperationCompleteMsg struct {
 	Success bool
@@ -14,12 +23,167 @@ type LogsFetchedMsg struct {
 
 type TablesFetchedMsg struct {
 	Tables []db.TableInfo
-	Client *db.Client
+	Client db.Browser
+	// Config is the connection that was tried, set whenever discovery succeeded.
+	Config *db.ConnectionConfig
 	Error  error
 }
 
 type TableDataFetchedMsg struct {
 	Columns []db.ColumnInfo
 	Rows    db.RowData
+	Keys    []string
+	Total   int
+	Error   error
+}
+
+type EnvFetchedMsg struct {
+	Vars  map[string]string
+	Error error
+}
+
+type EnvDiffFetchedMsg struct {
+	Other *service.Service
+	Vars  map[string]string
+	Error error
+}
+
+type DotenvFetchedMsg struct {
+	Report *env.DriftReport
+	Error  error
+}
+
+type EnvAppliedMsg struct {
+	ContainerID string
+	// Warning reports a leftover old container after a successful recreate.
+	Warning string
+	Error   error
+}
+
+type AlertEnrichedMsg struct {
+	Alert alert.Alert
+	Error error
+}
+
+type RedisConnectedMsg struct {
+	Client *db.RedisClient
+	Error  error
+}
+
+type RedisKeysFetchedMsg struct {
+	Keys  []db.KeyInfo
+	Error error
+}
+
+type RedisValueFetchedMsg struct {
+	Key   string
+	Value *db.RedisValue
+	Error error
+}
+
+type QueryResultMsg struct {
+	RunID  int
+	Result *db.QueryResult
+	Error  error
+}
+
+type SchemaFetchedMsg struct {
+	Table  string
+	Schema *db.TableSchema
+	Error  error
+}
+
+type NamespacesFetchedMsg struct {
+	Namespaces []db.Namespace
+	Error      error
+}
+
+type TableCountMsg struct {
+	Gen     int
+	Result  db.TableCount
+	Done    bool
+	Results <-chan db.TableCount
+}
+
+type ExportDoneMsg struct {
+	Path  string
+	Rows  int
+	Error error
+}
+
+type SnapshotsListedMsg struct {
+	Snapshots []snapshot.Snapshot
+	Error     error
+}
+
+type SnapshotDoneMsg struct {
+	Action   string
+	Name     string
+	Duration time.Duration
+	Error    error
+}
+
+type EditsAppliedMsg struct {
+	Count int
+	Error error
+}
+
+type SessionsFetchedMsg struct {
+	Gen       int
+	Sessions  []db.Session
+	Scheduled bool
+	Error     error
+}
+
+type ActivityTickMsg struct {
+	Gen int
+}
+
+type SessionSignalledMsg struct {
+	ID        int64
+	Terminate bool
+	Error     error
+}
+
+type ImagesListedMsg struct {
+	Images []docker.Image
+	Error  error
+}
+
+type ImageActionMsg struct {
+	Action  string
+	Message string
+	Error   error
+}
+
+type VolumesListedMsg struct {
+	Volumes []docker.Volume
+	Error   error
+}
+
+type VolumeActionMsg struct {
+	Action  string
+	Message string
 	Error   error
 }
+
+type NetworksListedMsg struct {
+	Networks []docker.Network
+	Error    error
+}
+
+type CleanupLoadedMsg struct {
+	Cleanup *docker.Cleanup
+	Error   error
+}
+
+type CleanupDoneMsg struct {
+	Results []PruneResult
+}
+
+type ShellDefaultsMsg struct {
+	Shell      string
+	User       string
+	WorkingDir string
+	Error      error
+}
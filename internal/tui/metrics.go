@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/metrics"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+const chartHeight = 6
+
+// MetricsView charts the recorded history of one service. It re-renders from the
+// shared history on every refresh, so it stays live while open.
+type MetricsView struct {
+	service    *service.Service
+	history    *metrics.History
+	width      int
+	height     int
+	shouldExit bool
+}
+
+// creates a metrics view for a service.
+func NewMetricsView(svc *service.Service, history *metrics.History, width, height int) *MetricsView {
+	return &MetricsView{
+		service: svc,
+		history: history,
+		width:   width,
+		height:  height,
+	}
+}
+
+func (v *MetricsView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *MetricsView) Update(msg tea.Msg) (*MetricsView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return v, tea.Quit
+		case "esc":
+			v.shouldExit = true
+			return v, nil
+		}
+	case tea.WindowSizeMsg:
+		v.width = msg.Width
+		v.height = msg.Height
+	}
+	return v, nil
+}
+
+func (v *MetricsView) View() string {
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render(fmt.Sprintf("Metrics: %s", v.service.Name))
+
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render("[esc] back")
+
+	series := v.history.Get(v.service.ID)
+	var body string
+	if series == nil {
+		body = "No samples recorded yet"
+	} else {
+		body = v.renderSeries(series)
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1).
+		Width(v.width - 4).
+		Height(v.height - 6).
+		Render(body)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, box, footer)
+}
+
+func (v *MetricsView) renderSeries(series *metrics.Series) string {
+	chartWidth := v.width - 20
+	if chartWidth < 10 {
+		chartWidth = 10
+	}
+	section := lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true)
+
+	var lines []string
+	if series.CPU.Len() > 0 {
+		values := metrics.Values(series.CPU)
+		last, _ := series.CPU.Last()
+		lines = append(lines, section.Render(fmt.Sprintf("CPU  %.1f%%  (%s)", last.Value, windowLabel(series.CPU, chartWidth))))
+		lines = append(lines, labeledChart(values, chartWidth, func(f float64) string { return fmt.Sprintf("%.1f%%", f) })...)
+		lines = append(lines, "")
+
+		mem := metrics.Values(series.Memory)
+		lastMem, _ := series.Memory.Last()
+		lines = append(lines, section.Render(fmt.Sprintf("Memory  %s  (%s)", formatBytes(int64(lastMem.Value)), windowLabel(series.Memory, chartWidth))))
+		lines = append(lines, labeledChart(mem, chartWidth, func(f float64) string { return formatBytes(int64(f)) })...)
+		lines = append(lines, "")
+	} else {
+		lines = append(lines, subtleStyle.Render("No resource samples (service not running)"), "")
+	}
+
+	restarts := metrics.Values(series.Restarts)
+	if len(restarts) > 0 {
+		current := restarts[len(restarts)-1]
+		delta := current - restarts[0]
+		lines = append(lines, section.Render(fmt.Sprintf("Restarts  %d  (+%d in window)", int(current), int(delta))))
+		lines = append(lines, "          "+sparkStyle.Render(sparkline(restarts, chartWidth, 0)), "")
+	}
+
+	lines = append(lines, section.Render("Status transitions"))
+	transitions := series.Transitions.Items()
+	if len(transitions) == 0 {
+		lines = append(lines, subtleStyle.Render("  none recorded"))
+	}
+	if len(transitions) > 5 {
+		transitions = transitions[len(transitions)-5:]
+	}
+	for _, t := range transitions {
+		lines = append(lines, fmt.Sprintf("  %s  %s → %s", t.At.Format("15:04:05"), t.From, t.To))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renders a chart with the max value labeled on the top row and zero on the bottom row.
+func labeledChart(values []float64, width int, format func(float64) string) []string {
+	var max float64
+	for _, val := range values {
+		if val > max {
+			max = val
+		}
+	}
+
+	rows := chart(values, width, chartHeight)
+	for i, row := range rows {
+		label := ""
+		switch i {
+		case 0:
+			label = format(max)
+		case len(rows) - 1:
+			label = format(0)
+		}
+		rows[i] = fmt.Sprintf("%9s ", label) + sparkStyle.Render(row)
+	}
+	return rows
+}
+
+// describes the time span covered by the points visible in a chart.
+func windowLabel(r *metrics.Ring[metrics.Point], width int) string {
+	points := r.Items()
+	if len(points) > width {
+		points = points[len(points)-width:]
+	}
+	if len(points) < 2 {
+		return "collecting"
+	}
+	span := points[len(points)-1].At.Sub(points[0].At).Round(time.Second)
+	return "last " + span.String()
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/docker"
+)
+
+// NetworksPanel lists networks with their driver and subnets, each followed by
+// the containers connected to it and their addresses.
+type NetworksPanel struct {
+	dockerClient  *docker.Client
+	networks      []docker.Network
+	selectedIndex int
+	loaded        bool
+	error         error
+}
+
+func NewNetworksPanel(dockerClient *docker.Client) *NetworksPanel {
+	return &NetworksPanel{dockerClient: dockerClient}
+}
+
+func (p *NetworksPanel) Refresh() tea.Cmd {
+	return func() tea.Msg {
+		if p.dockerClient == nil {
+			return NetworksListedMsg{Error: fmt.Errorf("docker unavailable")}
+		}
+		networks, err := p.dockerClient.ListNetworks()
+		return NetworksListedMsg{Networks: networks, Error: err}
+	}
+}
+
+func (p *NetworksPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case NetworksListedMsg:
+		p.loaded = true
+		p.error = msg.Error
+		if msg.Error == nil {
+			p.networks = msg.Networks
+			p.selectedIndex = moveSelection(p.selectedIndex, 0, len(p.networks))
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			p.selectedIndex = moveSelection(p.selectedIndex, -1, len(p.networks))
+		case "down", "j":
+			p.selectedIndex = moveSelection(p.selectedIndex, 1, len(p.networks))
+		case "g":
+			p.selectedIndex = 0
+		case "G":
+			p.selectedIndex = moveSelection(len(p.networks), 0, len(p.networks))
+		case "r":
+			return p.Refresh()
+		}
+	}
+	return nil
+}
+
+func (p *NetworksPanel) Rows(focused bool) []string {
+	header := fmt.Sprintf("%-32s %-10s %-22s %s\n", "NETWORK", "DRIVER", "SUBNET", "CONTAINERS")
+	rows := []string{header}
+	switch {
+	case p.error != nil:
+		return append(rows, fmt.Sprintf("Error: %v\n", p.error))
+	case !p.loaded:
+		return append(rows, subtleStyle.Render("Loading networks...")+"\n")
+	}
+
+	for i, n := range p.networks {
+		subnet := "-"
+		if len(n.Subnets) > 0 {
+			subnet = strings.Join(n.Subnets, ", ")
+		}
+		containers := strconv.Itoa(len(n.Endpoints))
+		if n.InspectErr != nil {
+			containers = "? (inspect failed)"
+		}
+		line := fmt.Sprintf("%-32s %-10s %-22s %s",
+			truncate(n.Name, 31),
+			n.Driver,
+			truncate(subnet, 21),
+			containers,
+		)
+		if i == p.selectedIndex && focused {
+			line = selectedRowStyle.Render(line)
+		}
+		rows = append(rows, line+"\n")
+
+		for _, ep := range n.Endpoints {
+			addr := ep.IPv4
+			if ep.IPv6 != "" {
+				addr = strings.TrimSpace(addr + " " + ep.IPv6)
+			}
+			rows = append(rows, subtleStyle.Render(fmt.Sprintf("  └ %-29s %s", truncate(ep.Container, 29), addr))+"\n")
+		}
+	}
+	return rows
+}
+
+// returns the line of the selected network, counting the containers listed
+// under the networks before it.
+func (p *NetworksPanel) Selected() int {
+	line := 0
+	for i := 0; i < p.selectedIndex && i < len(p.networks); i++ {
+		line += 1 + len(p.networks[i].Endpoints)
+	}
+	return line
+}
+
+func (p *NetworksPanel) Hints() string {
+	return "[j/k] Nav  [r]efresh  [h] Back  [:] Cmd"
+}
+
+func (p *NetworksPanel) Prompt() string {
+	return ""
+}
+
+func (p *NetworksPanel) Status() string {
+	return ""
+}
+
+func (p *NetworksPanel) Summary() string {
+	if !p.loaded || p.error != nil {
+		return ""
+	}
+	return fmt.Sprintf("Networks: %d", len(p.networks))
+}
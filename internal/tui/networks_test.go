@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eanda22/devhud/internal/docker"
+)
+
+func TestNetworksPanelSelectedLine(t *testing.T) {
+	p := NewNetworksPanel(nil)
+	p.Update(NetworksListedMsg{Networks: []docker.Network{
+		{Name: "bridge"},
+		{Name: "shop_default", Endpoints: []docker.Endpoint{{Container: "db"}, {Container: "web"}}},
+		{Name: "host", InspectErr: errors.New("no such network")},
+	}})
+
+	p.Update(runeKey('G'))
+	if got := p.Selected(); got != 4 {
+		t.Errorf("Selected() = %d, want 4", got)
+	}
+	rows := p.Rows(true)
+	if len(rows) != 6 {
+		t.Fatalf("Rows() has %d lines, want a header, 3 networks and 2 containers", len(rows))
+	}
+	if !strings.Contains(rows[5], "inspect failed") {
+		t.Errorf("Rows()[5] = %q, want the failed inspect marked", rows[5])
+	}
+}
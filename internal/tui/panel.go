@@ -0,0 +1,62 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// resourcePanel lists Docker objects other than containers in the dashboard's
+// main panel when its sidebar category is selected. Panels load and act on
+// their objects themselves; the app routes keys and the panel's messages to
+// them.
+type resourcePanel interface {
+	// Refresh reloads the list.
+	Refresh() tea.Cmd
+	// Update handles a key while the main list has focus, or a message the
+	// panel's commands returned.
+	Update(msg tea.Msg) tea.Cmd
+	// Rows returns the header line followed by one line per object, each
+	// ending in a newline. The selected line is highlighted when focused.
+	Rows(focused bool) []string
+	Selected() int
+	// Hints lists the panel's keys for the status line.
+	Hints() string
+	// Prompt is the confirmation shown in place of the status line, or "".
+	// While it is set the panel takes every key.
+	Prompt() string
+	// Status reports the last action, or "".
+	Status() string
+	// Summary is shown at the bottom of the sidebar, or "".
+	Summary() string
+}
+
+// returns the panel for the selected category, or nil when the category lists
+// services or a search is showing matching services instead.
+func (a *App) activePanel() resourcePanel {
+	if a.searchFilter != "" || a.activeCatIndex >= len(a.categories) {
+		return nil
+	}
+	return a.panels[a.categories[a.activeCatIndex]]
+}
+
+// switches the main list to category i and loads what it shows.
+func (a *App) selectCategory(i int) tea.Cmd {
+	a.activeCatIndex = i
+	a.selectedIndex = 0
+	if i == 0 {
+		return a.fetchDiskUsageCmd()
+	}
+	if panel := a.panels[a.categories[i]]; panel != nil {
+		return panel.Refresh()
+	}
+	return nil
+}
+
+// moves a list selection by delta within n items.
+func moveSelection(selected, delta, n int) int {
+	selected += delta
+	if selected >= n {
+		selected = n - 1
+	}
+	if selected < 0 {
+		selected = 0
+	}
+	return selected
+}
@@ -0,0 +1,392 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/history"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+const (
+	queryTimeout      = 30 * time.Second
+	queryHistoryLimit = 200
+	queryEditorHeight = 6
+)
+
+// QueryView is a SQL console for one database. Queries run with a timeout and
+// can be cancelled; in read-only mode, statements that may write need confirmation.
+// Statements share one connection, so transactions span statements.
+type QueryView struct {
+	service      *service.Service
+	client       *db.Client
+	session      *db.QuerySession
+	editor       textarea.Model
+	viewport     viewport.Model
+	grid         *Grid
+	showGrid     bool
+	history      *history.History
+	histIndex    int
+	readOnly     bool
+	confirming   bool
+	running      bool
+	runID        int
+	cancel       context.CancelFunc
+	result       *db.QueryResult
+	statement    string
+	export       exportPrompt
+	focusResults bool
+	status       string
+	shouldExit   bool
+}
+
+// creates a query console for a connected database.
+func NewQueryView(svc *service.Service, client *db.Client, width, height int) *QueryView {
+	editor := textarea.New()
+	editor.Placeholder = "SELECT * FROM users WHERE ..."
+	editor.CharLimit = 0
+	editor.SetWidth(width - 4)
+	editor.SetHeight(queryEditorHeight)
+	editor.Focus()
+
+	vp := viewport.New(width-4, resultsHeight(height))
+	vp.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+
+	v := &QueryView{
+		service:  svc,
+		client:   client,
+		session:  client.NewQuerySession(),
+		editor:   editor,
+		viewport: vp,
+		grid:     NewGrid(width-4, resultsHeight(height)),
+		readOnly: true,
+		export:   newExportPrompt(),
+	}
+
+	h, err := loadQueryHistory(svc)
+	if err != nil {
+		v.status = fmt.Sprintf("History unavailable: %v", err)
+	}
+	v.history = h
+	v.histIndex = len(h.Entries())
+	return v
+}
+
+// leaves room for the header, editor and footer.
+func resultsHeight(height int) int {
+	return height - queryEditorHeight - 7
+}
+
+// loads the query history for a database from the data dir.
+func loadQueryHistory(svc *service.Service) (*history.History, error) {
+	key := svc.DBType + "-" + svc.Name
+	if svc.DBPath != "" {
+		key = svc.DBType + "-" + svc.DBPath
+	}
+	dir, err := config.DataDir()
+	if err != nil {
+		return &history.History{}, err
+	}
+	return history.Load(filepath.Join(dir, "history", history.FileName(key)), queryHistoryLimit)
+}
+
+func (v *QueryView) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (v *QueryView) Update(msg tea.Msg) (*QueryView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			v.cancelQuery()
+			return v, tea.Quit
+		}
+		if v.export.Running() && msg.String() == "esc" {
+			v.export.Stop()
+			return v, nil
+		}
+		if v.export.Active() {
+			req, cmd := v.export.Update(msg)
+			if req != nil {
+				return v, v.exportCmd(*req)
+			}
+			return v, cmd
+		}
+		if v.focusResults && v.showGrid && v.grid.DetailOpen() {
+			v.grid.Update(msg)
+			return v, nil
+		}
+		if v.confirming {
+			v.confirming = false
+			if msg.String() == "y" || msg.String() == "Y" {
+				return v, v.runQuery()
+			}
+			v.status = "Write cancelled"
+			return v, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			if v.running {
+				v.cancelQuery()
+				v.status = "Cancelling..."
+				return v, nil
+			}
+			v.shouldExit = true
+			return v, nil
+		case "ctrl+r":
+			return v, v.submit()
+		case "ctrl+o":
+			v.readOnly = !v.readOnly
+			return v, nil
+		case "ctrl+x":
+			v.startExport()
+			return v, nil
+		case "ctrl+p":
+			v.recall(-1)
+			return v, nil
+		case "ctrl+n":
+			v.recall(1)
+			return v, nil
+		case "ctrl+l":
+			v.editor.Reset()
+			v.histIndex = len(v.history.Entries())
+			return v, nil
+		case "tab":
+			v.focusResults = !v.focusResults
+			if v.focusResults {
+				v.editor.Blur()
+				return v, nil
+			}
+			return v, v.editor.Focus()
+		}
+
+		var cmd tea.Cmd
+		if v.focusResults && v.showGrid {
+			v.grid.Update(msg)
+		} else if v.focusResults {
+			v.viewport, cmd = v.viewport.Update(msg)
+		} else {
+			v.editor, cmd = v.editor.Update(msg)
+		}
+		return v, cmd
+
+	case QueryResultMsg:
+		if msg.RunID != v.runID {
+			return v, nil
+		}
+		v.running = false
+		v.cancel = nil
+		v.showResult(msg)
+		return v, nil
+
+	case ExportDoneMsg:
+		v.export.Stop()
+		v.status = exportStatus(msg)
+		return v, nil
+
+	case tea.WindowSizeMsg:
+		v.editor.SetWidth(msg.Width - 4)
+		v.viewport.Width = msg.Width - 4
+		v.viewport.Height = resultsHeight(msg.Height)
+		v.grid.SetSize(msg.Width-4, resultsHeight(msg.Height))
+		return v, nil
+	}
+
+	var cmd tea.Cmd
+	v.editor, cmd = v.editor.Update(msg)
+	return v, cmd
+}
+
+// runs the editor's statement, asking first if it may write in read-only mode.
+func (v *QueryView) submit() tea.Cmd {
+	statement := strings.TrimSpace(v.editor.Value())
+	if statement == "" || v.running {
+		return nil
+	}
+	if v.readOnly && db.IsWrite(statement) {
+		v.confirming = true
+		return nil
+	}
+	return v.runQuery()
+}
+
+func (v *QueryView) runQuery() tea.Cmd {
+	statement := strings.TrimSpace(v.editor.Value())
+	if err := v.history.Add(statement); err != nil {
+		v.status = fmt.Sprintf("Saving history failed: %v", err)
+	} else {
+		v.status = ""
+	}
+	v.histIndex = len(v.history.Entries())
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	v.runID++
+	v.running = true
+	v.cancel = cancel
+	v.statement = statement
+
+	runID, session := v.runID, v.session
+	return func() tea.Msg {
+		defer cancel()
+		result, err := session.Query(ctx, statement)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("query timed out after %s", queryTimeout)
+		} else if errors.Is(ctx.Err(), context.Canceled) {
+			err = errors.New("query cancelled")
+		}
+		return QueryResultMsg{RunID: runID, Result: result, Error: err}
+	}
+}
+
+// opens the export prompt for the last result. A truncated result can be
+// exported in full by running the statement again, unless it may write.
+func (v *QueryView) startExport() {
+	if v.running || v.export.Running() || v.result == nil || !v.result.HasRows {
+		return
+	}
+	scopes := []exportScope{{key: "s", label: "rows shown"}}
+	if v.result.Truncated && !db.IsWrite(v.statement) {
+		scopes = append(scopes, exportScope{key: "a", label: "all rows (runs the query again)"})
+	}
+	v.export.Start("query-"+v.service.Name, scopes...)
+}
+
+func (v *QueryView) exportCmd(req exportRequest) tea.Cmd {
+	client, session, statement, result := v.client, v.session, v.statement, v.result
+	v.status = "Exporting... [esc] cancel"
+	if req.scope == "a" {
+		// on the console's connection, so an open transaction's rows are seen
+		return exportCmd(v.export.Begin(), req.path, func(ctx context.Context, w io.Writer) (int, error) {
+			return session.ExportQuery(ctx, statement, req.format, w)
+		})
+	}
+	return exportCmd(v.export.Begin(), req.path, func(_ context.Context, w io.Writer) (int, error) {
+		return client.ExportRows(result.Columns, result.Rows, "", req.format, w)
+	})
+}
+
+func (v *QueryView) cancelQuery() {
+	if v.cancel != nil {
+		v.cancel()
+	}
+}
+
+// cancels a running query and releases the console's connection, rolling back
+// a transaction left open. Closing waits for the cancelled query, so it runs
+// in the background.
+func (v *QueryView) Close() {
+	v.cancelQuery()
+	v.export.Stop()
+	go v.session.Close()
+}
+
+// steps through history; moving past the newest entry clears the editor.
+func (v *QueryView) recall(delta int) {
+	entries := v.history.Entries()
+	next := v.histIndex + delta
+	if next < 0 || next > len(entries) {
+		return
+	}
+	v.histIndex = next
+	if next == len(entries) {
+		v.editor.Reset()
+		return
+	}
+	v.editor.SetValue(entries[next])
+}
+
+func (v *QueryView) showResult(msg QueryResultMsg) {
+	v.viewport.GotoTop()
+	v.showGrid = false
+	if msg.Error != nil {
+		v.result = nil
+		v.viewport.SetContent("Error: " + msg.Error.Error())
+		return
+	}
+
+	v.result = msg.Result
+	if !msg.Result.HasRows {
+		v.viewport.SetContent(fmt.Sprintf("%d row(s) affected", msg.Result.RowsAffected))
+		return
+	}
+	if len(msg.Result.Rows) == 0 {
+		v.viewport.SetContent("No rows returned")
+		return
+	}
+	v.grid.SetData(msg.Result.Columns, msg.Result.Rows)
+	v.showGrid = true
+}
+
+func (v *QueryView) View() string {
+	title := fmt.Sprintf("SQL: %s", v.service.Name)
+	if v.readOnly {
+		title += "  " + subtleStyle.Render("[read-only]")
+	} else {
+		title += "  " + confirmDeleteStyle.Render(" WRITES ENABLED ")
+	}
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render(title)
+
+	var summary string
+	switch {
+	case v.running:
+		summary = "Running... [esc] cancel"
+	case v.result != nil && v.result.HasRows:
+		summary = fmt.Sprintf("%d row(s) in %s", len(v.result.Rows), v.result.Duration.Round(time.Millisecond))
+		if v.result.Truncated {
+			summary += fmt.Sprintf(" (first %d shown)", db.QueryRowLimit)
+		}
+		if v.showGrid && v.focusResults {
+			summary += "  " + v.grid.Position()
+		}
+	case v.result != nil:
+		summary = fmt.Sprintf("done in %s", v.result.Duration.Round(time.Millisecond))
+	}
+
+	var hint string
+	if v.confirming {
+		hint = confirmDeleteStyle.Render(" WRITE ") + "  This statement may modify data. Run it? [y/N]"
+	} else {
+		hint = "[ctrl+r] run  [ctrl+p/n] history  [tab] editor/results  [ctrl+o] read-only  [ctrl+x] export  [ctrl+l] clear  [esc] back"
+	}
+	if v.focusResults && v.showGrid && !v.confirming {
+		hint = "[↑/↓/←/→] move  [enter] full value  [tab] editor  [esc] back"
+		if v.grid.DetailOpen() {
+			hint = "[↑/↓] scroll  [esc] close"
+		}
+	}
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render(hint)
+	if v.export.Active() {
+		footer = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241")).
+			Render(v.export.View())
+	} else if v.status != "" && !v.confirming {
+		footer += "  " + subtleStyle.Render(v.status)
+	}
+
+	results := v.viewport.View()
+	if v.showGrid {
+		results = v.grid.View()
+	}
+	return fmt.Sprintf("%s\n\n%s\n%s\n%s\n\n%s",
+		header, v.editor.View(), subtleStyle.Render(summary), results, footer)
+}
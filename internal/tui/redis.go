@@ -0,0 +1,417 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/docker"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+// redisKeyLimit caps how many keys a single scan loads.
+const redisKeyLimit = 1000
+
+// RedisView browses the keys of a redis container. It lists keys with their
+// type, TTL and memory, and shows the selected key's value.
+type RedisView struct {
+	service       *service.Service
+	dockerClient  *docker.Client
+	client        *db.RedisClient
+	keys          []db.KeyInfo
+	value         *db.RedisValue
+	valueKey      string
+	pattern       string
+	input         textinput.Model
+	mode          string
+	selectedIndex int
+	viewport      viewport.Model
+	error         error
+	ready         bool
+	shouldExit    bool
+	// connection is the saved connection browsed instead of a container.
+	connection *config.Connection
+}
+
+// creates a redis key browser for a service.
+func NewRedisView(svc *service.Service, dockerClient *docker.Client, width, height int) *RedisView {
+	vp := viewport.New(width-4, height-6)
+	vp.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+
+	ti := textinput.New()
+	ti.Prompt = "MATCH "
+	ti.Placeholder = "user:*"
+	ti.CharLimit = 256
+
+	return &RedisView{
+		service:      svc,
+		dockerClient: dockerClient,
+		input:        ti,
+		mode:         "keys",
+		viewport:     vp,
+	}
+}
+
+func (v *RedisView) Init() tea.Cmd {
+	return v.connectCmd()
+}
+
+// closes the redis connection.
+func (v *RedisView) Close() {
+	if v.client != nil {
+		v.client.Close()
+	}
+}
+
+func (v *RedisView) Update(msg tea.Msg) (*RedisView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return v, tea.Quit
+		}
+		switch v.mode {
+		case "filter":
+			return v.updateFilter(msg)
+		case "value":
+			switch msg.String() {
+			case "q":
+				return v, tea.Quit
+			case "esc":
+				v.mode = "keys"
+				v.value = nil
+				v.viewport.GotoTop()
+				v.updateViewportContent()
+				return v, nil
+			case "r":
+				return v, v.fetchValueCmd(v.valueKey)
+			}
+		default:
+			switch msg.String() {
+			case "q":
+				return v, tea.Quit
+			case "esc":
+				if v.pattern != "" {
+					v.pattern = ""
+					v.input.SetValue("")
+					return v, v.fetchKeysCmd()
+				}
+				v.shouldExit = true
+				return v, nil
+			case "r":
+				return v, v.fetchKeysCmd()
+			case "/":
+				v.mode = "filter"
+				v.input.SetValue(v.pattern)
+				v.input.CursorEnd()
+				return v, v.input.Focus()
+			case "up", "k":
+				if v.selectedIndex > 0 {
+					v.selectedIndex--
+					v.updateViewportContent()
+				}
+				return v, nil
+			case "down", "j":
+				if v.selectedIndex < len(v.keys)-1 {
+					v.selectedIndex++
+					v.updateViewportContent()
+				}
+				return v, nil
+			case "enter":
+				if v.selectedIndex < len(v.keys) {
+					return v, v.fetchValueCmd(v.keys[v.selectedIndex].Name)
+				}
+				return v, nil
+			}
+		}
+
+	case RedisConnectedMsg:
+		if msg.Error != nil {
+			v.ready = true
+			v.error = msg.Error
+			v.viewport.SetContent("Error connecting to redis: " + msg.Error.Error())
+			return v, nil
+		}
+		if v.client != nil {
+			// left over from an earlier browser
+			msg.Client.Close()
+			return v, nil
+		}
+		v.client = msg.Client
+		return v, v.fetchKeysCmd()
+
+	case RedisKeysFetchedMsg:
+		v.ready = true
+		if msg.Error != nil {
+			v.error = msg.Error
+			v.viewport.SetContent("Error scanning keys: " + msg.Error.Error())
+			return v, nil
+		}
+		v.error = nil
+		v.keys = msg.Keys
+		if v.selectedIndex >= len(v.keys) {
+			v.selectedIndex = max(len(v.keys)-1, 0)
+		}
+		v.updateViewportContent()
+		return v, nil
+
+	case RedisValueFetchedMsg:
+		if msg.Error != nil {
+			v.error = msg.Error
+			v.viewport.SetContent(fmt.Sprintf("Error reading %s: %v", msg.Key, msg.Error))
+			v.mode = "value"
+			v.valueKey = msg.Key
+			v.value = nil
+			return v, nil
+		}
+		v.error = nil
+		v.mode = "value"
+		v.valueKey = msg.Key
+		v.value = msg.Value
+		v.viewport.GotoTop()
+		v.updateViewportContent()
+		return v, nil
+
+	case tea.WindowSizeMsg:
+		v.viewport.Width = msg.Width - 4
+		v.viewport.Height = msg.Height - 6
+	}
+
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func (v *RedisView) updateFilter(msg tea.KeyMsg) (*RedisView, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.mode = "keys"
+		v.input.Blur()
+		return v, nil
+	case "enter":
+		v.mode = "keys"
+		v.input.Blur()
+		v.pattern = strings.TrimSpace(v.input.Value())
+		v.selectedIndex = 0
+		return v, v.fetchKeysCmd()
+	}
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return v, cmd
+}
+
+func (v *RedisView) View() string {
+	if !v.ready {
+		return "Connecting to redis..."
+	}
+
+	title := fmt.Sprintf("Redis Keys: %s (%d)", v.service.Name, len(v.keys))
+	if v.pattern != "" {
+		title += fmt.Sprintf("  MATCH %s", v.pattern)
+	}
+	if v.mode == "value" {
+		title = fmt.Sprintf("Key: %s", v.valueKey)
+		if v.value != nil {
+			title += fmt.Sprintf(" (%s, %s)", v.value.Type, describeLength(v.value))
+		}
+	}
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render(title)
+
+	var hint string
+	switch v.mode {
+	case "filter":
+		hint = v.input.View() + "  [enter] scan  [esc] cancel"
+	case "value":
+		hint = "[esc] back  [r]efresh  [↑/↓] scroll"
+	default:
+		hint = "[esc] back  [/] pattern  [r]efresh  [↑/↓] navigate  [enter] view value"
+	}
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render(hint)
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
+}
+
+func (v *RedisView) updateViewportContent() {
+	if v.mode == "value" {
+		v.viewport.SetContent(renderRedisValue(v.value, v.viewport.Width-4))
+		return
+	}
+
+	if len(v.keys) == 0 {
+		v.viewport.SetContent("No keys found")
+		return
+	}
+
+	lines := []string{subtleStyle.Render(fmt.Sprintf("  %-50s %-8s %-10s %s", "KEY", "TYPE", "TTL", "MEMORY"))}
+	for i, key := range v.keys {
+		prefix := "  "
+		if i == v.selectedIndex {
+			prefix = "> "
+		}
+		memory := "-"
+		if key.Memory >= 0 {
+			memory = formatBytes(key.Memory)
+		}
+		lines = append(lines, fmt.Sprintf("%s%-50s %-8s %-10s %s",
+			prefix, truncate(key.Name, 50), key.Type, formatTTL(key.TTL), memory))
+	}
+	if len(v.keys) >= redisKeyLimit {
+		lines = append(lines, "", subtleStyle.Render(fmt.Sprintf("  showing the first %d keys; narrow the pattern with /", redisKeyLimit)))
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+	// +1 for the column header
+	ensureLineVisible(&v.viewport, v.selectedIndex+1)
+}
+
+// renders a key's value; strings are shown raw, collections as aligned columns.
+func renderRedisValue(value *db.RedisValue, width int) string {
+	if value == nil {
+		return ""
+	}
+	if value.Type == "string" {
+		if len(value.Rows) == 0 {
+			return ""
+		}
+		return lipgloss.NewStyle().Width(width).Render(value.Rows[0][0])
+	}
+	if len(value.Rows) == 0 {
+		return "(empty)"
+	}
+
+	widths := make([]int, len(value.Columns))
+	for i, col := range value.Columns {
+		widths[i] = len(col)
+	}
+	// the last column takes the remaining space, so only leading columns are measured
+	for _, row := range value.Rows {
+		for i := 0; i < len(row)-1; i++ {
+			widths[i] = min(max(widths[i], len(row[i])), 40)
+		}
+	}
+
+	format := func(row []string) string {
+		cells := make([]string, len(row))
+		used := 0
+		for i, cell := range row {
+			if i < len(row)-1 {
+				cells[i] = fmt.Sprintf("%-*s", widths[i], truncate(cell, widths[i]))
+				used += widths[i] + 2
+				continue
+			}
+			if rest := width - used; rest > 4 {
+				cell = truncate(cell, rest)
+			}
+			cells[i] = cell
+		}
+		return strings.Join(cells, "  ")
+	}
+
+	headers := make([]string, len(value.Columns))
+	for i, col := range value.Columns {
+		headers[i] = strings.ToUpper(col)
+	}
+	lines := []string{subtleStyle.Render(format(headers))}
+	for _, row := range value.Rows {
+		lines = append(lines, format(row))
+	}
+	if int64(len(value.Rows)) < value.Length {
+		lines = append(lines, "", subtleStyle.Render(fmt.Sprintf("showing %d of %d", len(value.Rows), value.Length)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// describes the size of a value in the unit that fits its type.
+func describeLength(value *db.RedisValue) string {
+	switch value.Type {
+	case "string":
+		return formatBytes(value.Length)
+	case "hash":
+		return fmt.Sprintf("%d fields", value.Length)
+	case "stream":
+		return fmt.Sprintf("%d entries", value.Length)
+	default:
+		return fmt.Sprintf("%d members", value.Length)
+	}
+}
+
+func formatTTL(ttl time.Duration) string {
+	if ttl < 0 {
+		return "-"
+	}
+	if ttl < time.Minute {
+		return ttl.Round(time.Second).String()
+	}
+	return formatUptime(ttl)
+}
+
+// discovers the connection settings, or reads the saved connection, and
+// connects to redis.
+func (v *RedisView) connectCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var config *db.ConnectionConfig
+		var err error
+		if v.connection != nil {
+			config, err = db.SavedConfig(ctx, *v.connection)
+		} else if v.dockerClient == nil {
+			err = fmt.Errorf("docker unavailable")
+		} else {
+			config, err = db.DiscoverConfig(ctx, v.dockerClient.GetRawClient(), v.service.ContainerID, v.service.DBType)
+		}
+		if err != nil {
+			return RedisConnectedMsg{Error: fmt.Errorf("discover config: %w", err)}
+		}
+		client, err := db.NewRedisClient(ctx, config)
+		if err != nil {
+			return RedisConnectedMsg{Error: err}
+		}
+		return RedisConnectedMsg{Client: client}
+	}
+}
+
+// scans keys matching the current pattern.
+func (v *RedisView) fetchKeysCmd() tea.Cmd {
+	client, pattern := v.client, v.pattern
+	if client == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		keys, err := client.ScanKeys(ctx, pattern, redisKeyLimit)
+		return RedisKeysFetchedMsg{Keys: keys, Error: err}
+	}
+}
+
+// loads the value of a key.
+func (v *RedisView) fetchValueCmd(key string) tea.Cmd {
+	client := v.client
+	if client == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		value, err := client.GetValue(ctx, key)
+		return RedisValueFetchedMsg{Key: key, Value: value, Error: err}
+	}
+}
@@ -0,0 +1,260 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+var schemaSectionStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#7D56F4")).
+	Bold(true)
+
+// SchemaView shows a table's columns, keys, indexes and DDL. Foreign keys can be
+// followed to the referenced table; esc walks back.
+type SchemaView struct {
+	service       *service.Service
+	client        *db.Client
+	table         string
+	visited       []string
+	schema        *db.TableSchema
+	selectedFK    int
+	viewport      viewport.Model
+	error         error
+	ready         bool
+	shouldExit    bool
+	statusMessage string
+}
+
+// creates a schema view for a table.
+func NewSchemaView(svc *service.Service, client *db.Client, table string, width, height int) *SchemaView {
+	vp := viewport.New(width-4, height-6)
+	vp.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+
+	return &SchemaView{
+		service:  svc,
+		client:   client,
+		table:    table,
+		viewport: vp,
+	}
+}
+
+func (v *SchemaView) Init() tea.Cmd {
+	return v.fetchSchemaCmd()
+}
+
+func (v *SchemaView) Update(msg tea.Msg) (*SchemaView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return v, tea.Quit
+		case "esc":
+			if len(v.visited) > 0 {
+				v.table = v.visited[len(v.visited)-1]
+				v.visited = v.visited[:len(v.visited)-1]
+				return v, v.fetchSchemaCmd()
+			}
+			v.shouldExit = true
+			return v, nil
+		case "r":
+			return v, v.fetchSchemaCmd()
+		case "tab":
+			v.moveFK(1)
+			return v, nil
+		case "shift+tab":
+			v.moveFK(-1)
+			return v, nil
+		case "enter":
+			if fk, ok := v.selectedForeignKey(); ok {
+				v.visited = append(v.visited, v.table)
+				v.table = fk.RefTable
+				return v, v.fetchSchemaCmd()
+			}
+			return v, nil
+		case "y":
+			if v.schema != nil {
+				v.copyToClipboard(v.schema.DDL)
+			}
+			return v, nil
+		}
+
+	case SchemaFetchedMsg:
+		if msg.Table != v.table {
+			return v, nil
+		}
+		v.ready = true
+		v.statusMessage = ""
+		if msg.Error != nil {
+			v.error = msg.Error
+			v.schema = nil
+			v.viewport.SetContent("Error reading schema: " + msg.Error.Error())
+			return v, nil
+		}
+		v.error = nil
+		v.schema = msg.Schema
+		v.selectedFK = 0
+		v.viewport.GotoTop()
+		v.updateViewportContent()
+		return v, nil
+
+	case tea.WindowSizeMsg:
+		v.viewport.Width = msg.Width - 4
+		v.viewport.Height = msg.Height - 6
+	}
+
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func (v *SchemaView) moveFK(delta int) {
+	if v.schema == nil {
+		return
+	}
+	fks := v.schema.ConstraintsOf(db.ConstraintForeignKey)
+	if len(fks) == 0 {
+		return
+	}
+	v.selectedFK = (v.selectedFK + delta + len(fks)) % len(fks)
+	v.updateViewportContent()
+}
+
+func (v *SchemaView) selectedForeignKey() (db.Constraint, bool) {
+	if v.schema == nil {
+		return db.Constraint{}, false
+	}
+	fks := v.schema.ConstraintsOf(db.ConstraintForeignKey)
+	if v.selectedFK >= len(fks) {
+		return db.Constraint{}, false
+	}
+	return fks[v.selectedFK], true
+}
+
+func (v *SchemaView) copyToClipboard(text string) {
+	if err := clipboard.WriteAll(text); err != nil {
+		v.statusMessage = fmt.Sprintf("Copy failed: %v", err)
+		return
+	}
+	v.statusMessage = "Copied DDL"
+}
+
+func (v *SchemaView) View() string {
+	if !v.ready {
+		return "Loading schema..."
+	}
+
+	title := "Schema: " + v.table
+	if len(v.visited) > 0 {
+		title = fmt.Sprintf("Schema: %s → %s", strings.Join(v.visited, " → "), v.table)
+	}
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render(title)
+
+	hint := "[esc] back  [r]efresh  [↑/↓] scroll  [tab] select foreign key  [enter] open referenced table  [y] copy DDL"
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render(hint)
+	if v.statusMessage != "" {
+		footer += "  " + subtleStyle.Render(v.statusMessage)
+	}
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
+}
+
+func (v *SchemaView) updateViewportContent() {
+	s := v.schema
+	var b strings.Builder
+
+	b.WriteString(schemaSectionStyle.Render("Columns") + "\n")
+	nameWidth := len("name")
+	for _, col := range s.Columns {
+		nameWidth = max(nameWidth, len(col.Name))
+	}
+	for _, col := range s.Columns {
+		key := "  "
+		if col.PrimaryKey {
+			key = "PK"
+		}
+		null := "NOT NULL"
+		if col.Nullable {
+			null = "NULL"
+		}
+		line := fmt.Sprintf("%s %-*s  %-28s  %-8s", key, nameWidth, col.Name, col.Type, null)
+		if col.Default != "" {
+			line += "  default " + col.Default
+		}
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	if pk := s.PrimaryKey(); len(pk) > 0 {
+		b.WriteString("\n" + schemaSectionStyle.Render("Primary key") + "\n")
+		b.WriteString("  (" + strings.Join(pk, ", ") + ")\n")
+	}
+
+	if unique := s.ConstraintsOf(db.ConstraintUnique); len(unique) > 0 {
+		b.WriteString("\n" + schemaSectionStyle.Render("Unique constraints") + "\n")
+		for _, c := range unique {
+			fmt.Fprintf(&b, "  %s (%s)\n", c.Name, strings.Join(c.Columns, ", "))
+		}
+	}
+
+	if fks := s.ConstraintsOf(db.ConstraintForeignKey); len(fks) > 0 {
+		b.WriteString("\n" + schemaSectionStyle.Render("Foreign keys") + "\n")
+		for i, c := range fks {
+			line := fmt.Sprintf("(%s) → %s (%s)",
+				strings.Join(c.Columns, ", "), c.RefTable, strings.Join(c.RefColumns, ", "))
+			if c.Name != "" {
+				line = c.Name + "  " + line
+			}
+			if i == v.selectedFK {
+				b.WriteString(selectedRowStyle.Render("> "+line) + "\n")
+			} else {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+	}
+
+	if len(s.Indexes) > 0 {
+		b.WriteString("\n" + schemaSectionStyle.Render("Indexes") + "\n")
+		for _, idx := range s.Indexes {
+			kind := ""
+			switch {
+			case idx.Primary:
+				kind = "  primary"
+			case idx.Unique:
+				kind = "  unique"
+			}
+			fmt.Fprintf(&b, "  %s (%s)%s\n", idx.Name, strings.Join(idx.Columns, ", "), subtleStyle.Render(kind))
+		}
+	}
+
+	if s.DDL != "" {
+		b.WriteString("\n" + schemaSectionStyle.Render("DDL") + "\n")
+		b.WriteString(s.DDL)
+	}
+
+	v.viewport.SetContent(b.String())
+}
+
+// reads a table's schema.
+func (v *SchemaView) fetchSchemaCmd() tea.Cmd {
+	client, table := v.client, v.table
+	return func() tea.Msg {
+		schema, err := client.Schema(context.Background(), table)
+		return SchemaFetchedMsg{Table: table, Schema: schema, Error: err}
+	}
+}
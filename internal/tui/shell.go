@@ -0,0 +1,337 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/docker"
+	"github.com/eanda22/devhud/internal/service"
+	"github.com/muesli/cancelreader"
+)
+
+// Action menu entries for running commands in a container. Custom commands
+// from the config are listed as execActionPrefix followed by their label.
+const (
+	shellAction      = "Open Shell"
+	shellAsAction    = "Open Shell As..."
+	execActionPrefix = "Exec: "
+)
+
+// execSetupTimeout bounds finding the shell and starting the command, during
+// which devhud has released the terminal and cannot be used.
+const execSetupTimeout = 15 * time.Second
+
+// containerExec runs an interactive command in a container through the Docker
+// API for tea.Exec, so the docker CLI is not needed. Without a command it opens
+// the best shell the container has, running script with -c if one is set.
+type containerExec struct {
+	client      *docker.Client
+	containerID string
+	opts        docker.ExecOptions
+	script      string
+	stdin       io.Reader
+	stdout      io.Writer
+	exitCode    int
+}
+
+func (e *containerExec) SetStdin(r io.Reader)  { e.stdin = r }
+func (e *containerExec) SetStdout(w io.Writer) { e.stdout = w }
+func (e *containerExec) SetStderr(io.Writer)   {}
+
+func (e *containerExec) Run() error {
+	if e.client == nil {
+		return fmt.Errorf("docker unavailable")
+	}
+	setupCtx, cancel := context.WithTimeout(context.Background(), execSetupTimeout)
+	defer cancel()
+	if len(e.opts.Cmd) == 0 {
+		shell, err := e.client.DetectShell(setupCtx, e.containerID)
+		if err != nil {
+			return err
+		}
+		e.opts.Cmd = []string{shell}
+		if e.script != "" {
+			e.opts.Cmd = append(e.opts.Cmd, "-c", e.script)
+		}
+	}
+
+	// the program has released the terminal; put it in raw mode so keys such
+	// as ctrl+c reach the command instead of devhud
+	var fd uintptr
+	isTerminal := false
+	width, height := 80, 24
+	if file, ok := e.stdin.(interface{ Fd() uintptr }); ok && term.IsTerminal(file.Fd()) {
+		fd, isTerminal = file.Fd(), true
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("raw terminal: %w", err)
+		}
+		defer term.Restore(fd, state)
+		if w, h, err := term.GetSize(fd); err == nil {
+			width, height = w, h
+		}
+	}
+
+	tty, err := e.client.StartTTY(setupCtx, e.containerID, e.opts, uint(width), uint(height))
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+	ctx := context.Background()
+
+	// reading stdin is cancelled when the command exits, so the key pressed
+	// next goes to devhud rather than to the closed command
+	input, err := cancelreader.NewReader(e.stdin)
+	if err != nil {
+		return fmt.Errorf("read input: %w", err)
+	}
+	defer input.Close()
+	inputDone := make(chan struct{})
+	go func() {
+		io.Copy(tty, input)
+		close(inputDone)
+	}()
+
+	done := make(chan struct{})
+	if isTerminal {
+		go followTerminalSize(ctx, fd, tty, width, height, done)
+	}
+
+	e.exitCode, err = tty.Wait(ctx, e.stdout)
+	close(done)
+	if !input.Cancel() {
+		// the read cannot be interrupted and ends at the next key, which the
+		// cancelled reader drops; wait for it so it does not race devhud
+		select {
+		case <-inputDone:
+		default:
+			fmt.Fprint(e.stdout, "\r\nPress any key to return to devhud")
+		}
+	}
+	<-inputDone
+	return err
+}
+
+// passes terminal size changes on to the command until done is closed.
+func followTerminalSize(ctx context.Context, fd uintptr, tty *docker.TTY, width, height int, done <-chan struct{}) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		w, h, err := term.GetSize(fd)
+		if err != nil || (w == width && h == height) {
+			continue
+		}
+		width, height = w, h
+		tty.Resize(ctx, uint(w), uint(h))
+	}
+}
+
+// runs a command in a container in place of the dashboard and reports how it
+// ended. label names the command in the status line.
+func (a *App) execCmd(svc *service.Service, opts docker.ExecOptions, label string) tea.Cmd {
+	return a.runExec(&containerExec{client: a.dockerClient, containerID: svc.ContainerID, opts: opts}, label)
+}
+
+func (a *App) runExec(e *containerExec, label string) tea.Cmd {
+	return tea.Exec(e, func(err error) tea.Msg {
+		switch {
+		case err != nil:
+			return OperationCompleteMsg{Success: false, Message: fmt.Sprintf("%s failed: %v", label, err)}
+		case e.exitCode != 0:
+			return OperationCompleteMsg{Success: false, Message: fmt.Sprintf("%s exited with code %d", label, e.exitCode)}
+		}
+		return OperationCompleteMsg{Success: true, Message: label + " closed"}
+	})
+}
+
+// returns the custom commands configured for a container's image.
+func (a *App) execCommands(svc *service.Service) []config.ExecCommand {
+	if a.config == nil {
+		return nil
+	}
+	return a.config.ExecCommands(svc.Image)
+}
+
+// runs a custom command in a container. Commands run with the container's
+// shell so they can use its environment and operators such as &&.
+func (a *App) runExecCommand(svc *service.Service, c config.ExecCommand) tea.Cmd {
+	e := &containerExec{
+		client:      a.dockerClient,
+		containerID: svc.ContainerID,
+		opts:        docker.ExecOptions{User: c.User, WorkingDir: c.WorkingDir},
+		script:      c.Command,
+	}
+	return a.runExec(e, c.Label())
+}
+
+// shellFields labels the inputs of the shell form, in order.
+var shellFields = []string{"Shell", "User", "Directory"}
+
+const (
+	shellFieldShell = iota
+	shellFieldUser
+	shellFieldDir
+)
+
+// ShellView asks for the shell, user and working directory to open a shell
+// with. Empty fields fall back to the detected shell and the image's user and
+// working directory, which are shown as placeholders.
+type ShellView struct {
+	service      *service.Service
+	dockerClient *docker.Client
+	inputs       []textinput.Model
+	focus        int
+	error        error
+	// run is set when the form is submitted.
+	run        *docker.ExecOptions
+	shouldExit bool
+	width      int
+	height     int
+}
+
+// creates the shell form for a running container.
+func NewShellView(svc *service.Service, dockerClient *docker.Client, width, height int) *ShellView {
+	inputs := make([]textinput.Model, len(shellFields))
+	for i := range inputs {
+		inputs[i] = textinput.New()
+		inputs[i].Prompt = ""
+		inputs[i].CharLimit = 256
+		inputs[i].Width = 40
+	}
+	inputs[shellFieldShell].Placeholder = "detecting..."
+	return &ShellView{
+		service:      svc,
+		dockerClient: dockerClient,
+		inputs:       inputs,
+		width:        width,
+		height:       height,
+	}
+}
+
+func (v *ShellView) Init() tea.Cmd {
+	return tea.Batch(v.inputs[0].Focus(), v.defaultsCmd())
+}
+
+func (v *ShellView) Update(msg tea.Msg) (*ShellView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ShellDefaultsMsg:
+		if msg.Error != nil {
+			v.error = msg.Error
+		}
+		if msg.Shell != "" {
+			v.inputs[shellFieldShell].Placeholder = msg.Shell
+		} else {
+			v.inputs[shellFieldShell].Placeholder = "bash, zsh, ash or sh"
+		}
+		v.inputs[shellFieldUser].Placeholder = orDefault(msg.User, "root")
+		v.inputs[shellFieldDir].Placeholder = orDefault(msg.WorkingDir, "/")
+		return v, nil
+
+	case tea.WindowSizeMsg:
+		v.width = msg.Width
+		v.height = msg.Height
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return v, tea.Quit
+		case "esc":
+			v.shouldExit = true
+			return v, nil
+		case "tab", "down":
+			return v, v.focusField(v.focus + 1)
+		case "shift+tab", "up":
+			return v, v.focusField(v.focus - 1)
+		case "enter":
+			opts := v.options()
+			v.run = &opts
+			v.shouldExit = true
+			return v, nil
+		}
+		var cmd tea.Cmd
+		v.inputs[v.focus], cmd = v.inputs[v.focus].Update(msg)
+		return v, cmd
+	}
+	return v, nil
+}
+
+func (v *ShellView) focusField(i int) tea.Cmd {
+	v.inputs[v.focus].Blur()
+	v.focus = (i + len(v.inputs)) % len(v.inputs)
+	return v.inputs[v.focus].Focus()
+}
+
+// reads the form. An empty shell is detected when the shell starts.
+func (v *ShellView) options() docker.ExecOptions {
+	return docker.ExecOptions{
+		Cmd:        strings.Fields(v.inputs[shellFieldShell].Value()),
+		User:       strings.TrimSpace(v.inputs[shellFieldUser].Value()),
+		WorkingDir: strings.TrimSpace(v.inputs[shellFieldDir].Value()),
+	}
+}
+
+func (v *ShellView) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render("Open Shell: " + v.service.Name)
+
+	labelStyle := lipgloss.NewStyle().Width(10)
+	var lines []string
+	for i, input := range v.inputs {
+		label := labelStyle.Render(shellFields[i])
+		if i == v.focus {
+			lines = append(lines, selectedActionStyle.Render("> "+label)+" "+input.View())
+		} else {
+			lines = append(lines, "  "+label+" "+input.View())
+		}
+	}
+	if v.error != nil {
+		lines = append(lines, "", confirmDeleteStyle.Render(" ERROR ")+" "+v.error.Error())
+	}
+
+	hint := subtleStyle.Render("[Tab] Next field   [Enter] Open   [Esc] Cancel")
+	box := actionMenuBoxStyle.Render(
+		lipgloss.JoinVertical(lipgloss.Left, title, "", strings.Join(lines, "\n"), "", hint),
+	)
+	return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// detects the shell and reads the image's user and working directory.
+func (v *ShellView) defaultsCmd() tea.Cmd {
+	return func() tea.Msg {
+		if v.dockerClient == nil {
+			return ShellDefaultsMsg{Error: fmt.Errorf("docker unavailable")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		defaults, err := v.dockerClient.ExecDefaults(ctx, v.service.ContainerID)
+		if err != nil {
+			return ShellDefaultsMsg{Error: err}
+		}
+		shell, err := v.dockerClient.DetectShell(ctx, v.service.ContainerID)
+		return ShellDefaultsMsg{Shell: shell, User: defaults.User, WorkingDir: defaults.WorkingDir, Error: err}
+	}
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/service"
+)
+
+func TestActionMenuExecCommands(t *testing.T) {
+	svc := &service.Service{Type: service.ServiceTypeDocker, Status: service.StatusRunning, Image: "postgres:16"}
+	commands := []config.ExecCommand{{Image: "postgres", Name: "psql", Command: "psql -U postgres"}}
+
+	items := getActionMenuItems(svc, commands)
+	want := []string{shellAction, shellAsAction, execActionPrefix + "psql"}
+	for _, w := range want {
+		if !slices.Contains(items, w) {
+			t.Errorf("getActionMenuItems() = %v, missing %q", items, w)
+		}
+	}
+}
+
+func TestShellViewOptions(t *testing.T) {
+	v := NewShellView(&service.Service{Name: "db"}, nil, 80, 24)
+	v.Init()
+	for _, r := range "bash -l" {
+		v, _ = v.Update(runeKey(r))
+	}
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyTab})
+	for _, r := range "postgres" {
+		v, _ = v.Update(runeKey(r))
+	}
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !v.shouldExit || v.run == nil {
+		t.Fatalf("enter did not submit the form")
+	}
+	if len(v.run.Cmd) != 2 || v.run.Cmd[0] != "bash" || v.run.Cmd[1] != "-l" {
+		t.Errorf("Cmd = %q, want [bash -l]", v.run.Cmd)
+	}
+	if v.run.User != "postgres" || v.run.WorkingDir != "" {
+		t.Errorf("User, WorkingDir = %q, %q, want postgres and empty", v.run.User, v.run.WorkingDir)
+	}
+}
+
+func TestActionMenuExecCommandByPosition(t *testing.T) {
+	svc := &service.Service{Type: service.ServiceTypeDocker, Status: service.StatusRunning, Image: "postgres:16"}
+	commands := []config.ExecCommand{
+		{Image: "postgres", Name: "psql", Command: "psql -U postgres"},
+		{Image: "postgres", Name: "psql", Command: "psql -U app"},
+	}
+	menu := NewActionMenuView(svc, nil, commands, 80, 24)
+	menu.selectedIndex = slices.Index(menu.actions, execActionPrefix+"psql") + 1
+	menu, _ = menu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if menu.execCommand == nil || menu.execCommand.Command != "psql -U app" {
+		t.Errorf("execCommand = %+v, want the second psql command", menu.execCommand)
+	}
+}
@@ -0,0 +1,333 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eanda22/devhud/internal/config"
+	"github.com/eanda22/devhud/internal/db"
+	"github.com/eanda22/devhud/internal/docker"
+	"github.com/eanda22/devhud/internal/service"
+	"github.com/eanda22/devhud/internal/snapshot"
+)
+
+// SnapshotView lists a database container's snapshots and takes, restores and
+// deletes them. Restoring and deleting ask for confirmation first.
+type SnapshotView struct {
+	service       *service.Service
+	dockerClient  *docker.Client
+	store         *snapshot.Store
+	snapshots     []snapshot.Snapshot
+	selectedIndex int
+	input         textinput.Model
+	mode          string
+	busy          string
+	viewport      viewport.Model
+	statusMessage string
+	error         error
+	ready         bool
+	shouldExit    bool
+}
+
+// creates a snapshot view for a database container.
+func NewSnapshotView(svc *service.Service, dockerClient *docker.Client, width, height int) *SnapshotView {
+	vp := viewport.New(width-4, height-6)
+	vp.Style = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+
+	ti := textinput.New()
+	ti.Prompt = "name: "
+	ti.CharLimit = 128
+
+	v := &SnapshotView{
+		service:      svc,
+		dockerClient: dockerClient,
+		input:        ti,
+		mode:         "list",
+		viewport:     vp,
+	}
+	if dir, err := config.DataDir(); err != nil {
+		v.error = err
+	} else {
+		v.store = snapshot.NewStore(dir, svc.Name)
+	}
+	return v
+}
+
+func (v *SnapshotView) Init() tea.Cmd {
+	return v.listSnapshotsCmd()
+}
+
+func (v *SnapshotView) Update(msg tea.Msg) (*SnapshotView, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return v, tea.Quit
+		}
+		switch v.mode {
+		case "name":
+			return v.updateName(msg)
+		case "restore", "delete":
+			return v.updateConfirm(msg)
+		}
+		if handled, keyCmd := v.updateList(msg); handled {
+			return v, keyCmd
+		}
+
+	case SnapshotsListedMsg:
+		v.ready = true
+		if msg.Error != nil {
+			v.error = msg.Error
+			v.viewport.SetContent("Error reading snapshots: " + msg.Error.Error())
+			return v, nil
+		}
+		v.error = nil
+		v.snapshots = msg.Snapshots
+		v.selectedIndex = min(v.selectedIndex, max(len(v.snapshots)-1, 0))
+		v.updateViewportContent()
+		return v, nil
+
+	case SnapshotDoneMsg:
+		v.busy = ""
+		if msg.Error != nil {
+			v.statusMessage = fmt.Sprintf("%s failed: %v", msg.Action, msg.Error)
+			return v, nil
+		}
+		done := "Saved snapshot"
+		if msg.Action == "Restore" {
+			done = "Restored"
+		}
+		v.statusMessage = fmt.Sprintf("%s %s in %s", done, msg.Name, msg.Duration.Round(100*time.Millisecond))
+		return v, v.listSnapshotsCmd()
+
+	case tea.WindowSizeMsg:
+		v.viewport.Width = msg.Width - 4
+		v.viewport.Height = msg.Height - 6
+	}
+
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func (v *SnapshotView) updateList(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return true, tea.Quit
+	case "esc":
+		v.shouldExit = true
+		return true, nil
+	case "r":
+		return true, v.listSnapshotsCmd()
+	case "up", "k":
+		if v.selectedIndex > 0 {
+			v.selectedIndex--
+			v.updateViewportContent()
+		}
+		return true, nil
+	case "down", "j":
+		if v.selectedIndex < len(v.snapshots)-1 {
+			v.selectedIndex++
+			v.updateViewportContent()
+		}
+		return true, nil
+	}
+
+	if v.busy != "" || v.store == nil {
+		return false, nil
+	}
+	switch msg.String() {
+	case "n":
+		v.mode = "name"
+		v.statusMessage = ""
+		v.input.SetValue(snapshot.DefaultName(time.Now()))
+		v.input.CursorEnd()
+		return true, v.input.Focus()
+	case "enter", "R":
+		if len(v.snapshots) > 0 {
+			v.mode = "restore"
+		}
+		return true, nil
+	case "D":
+		if len(v.snapshots) > 0 {
+			v.mode = "delete"
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (v *SnapshotView) updateName(msg tea.KeyMsg) (*SnapshotView, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.mode = "list"
+		v.input.Blur()
+		return v, nil
+	case "enter":
+		name := strings.TrimSpace(v.input.Value())
+		if err := snapshot.ValidateName(name); err != nil {
+			v.statusMessage = err.Error()
+			return v, nil
+		}
+		v.mode = "list"
+		v.input.Blur()
+		v.statusMessage = ""
+		v.busy = "Taking snapshot " + name + "..."
+		return v, v.takeSnapshotCmd(name)
+	}
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return v, cmd
+}
+
+func (v *SnapshotView) updateConfirm(msg tea.KeyMsg) (*SnapshotView, tea.Cmd) {
+	mode := v.mode
+	v.mode = "list"
+	if msg.String() != "y" && msg.String() != "Y" {
+		v.statusMessage = "Cancelled"
+		return v, nil
+	}
+
+	snap := v.snapshots[v.selectedIndex]
+	v.statusMessage = ""
+	if mode == "delete" {
+		if err := v.store.Delete(snap.Name); err != nil {
+			v.statusMessage = fmt.Sprintf("Delete failed: %v", err)
+			return v, nil
+		}
+		v.statusMessage = "Deleted " + snap.Name
+		return v, v.listSnapshotsCmd()
+	}
+	v.busy = "Restoring " + snap.Name + "..."
+	return v, v.restoreSnapshotCmd(snap.Name)
+}
+
+func (v *SnapshotView) selected() (snapshot.Snapshot, bool) {
+	if v.selectedIndex >= len(v.snapshots) {
+		return snapshot.Snapshot{}, false
+	}
+	return v.snapshots[v.selectedIndex], true
+}
+
+func (v *SnapshotView) View() string {
+	if !v.ready {
+		return "Loading snapshots..."
+	}
+
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render(fmt.Sprintf("Snapshots: %s", v.service.Name))
+	if v.store != nil {
+		header += "  " + subtleStyle.Render(v.store.Dir())
+	}
+
+	var hint string
+	switch v.mode {
+	case "name":
+		hint = v.input.View() + "  [enter] take snapshot  [esc] cancel"
+	case "restore":
+		snap, _ := v.selected()
+		hint = confirmDeleteStyle.Render(" RESTORE ") +
+			fmt.Sprintf("  Replace the data in %s with snapshot %s? [y/N]", snap.Database, snap.Name)
+	case "delete":
+		snap, _ := v.selected()
+		hint = confirmDeleteStyle.Render(" DELETE ") + fmt.Sprintf("  Delete snapshot %s? [y/N]", snap.Name)
+	default:
+		hint = "[esc] back  [n]ew snapshot  [enter] restore  [D]elete  [r]efresh"
+	}
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render(hint)
+	if v.busy != "" {
+		footer += "  " + subtleStyle.Render(v.busy)
+	} else if v.statusMessage != "" && (v.mode == "list" || v.mode == "name") {
+		footer += "  " + subtleStyle.Render(v.statusMessage)
+	}
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, v.viewport.View(), footer)
+}
+
+func (v *SnapshotView) updateViewportContent() {
+	if len(v.snapshots) == 0 {
+		v.viewport.SetContent("No snapshots yet. Press n to take one.")
+		return
+	}
+
+	var lines []string
+	for i, snap := range v.snapshots {
+		line := fmt.Sprintf("%-32s %-16s %10s  %s",
+			truncate(snap.Name, 32),
+			snap.Created.Local().Format("2006-01-02 15:04"),
+			formatBytes(snap.Size),
+			snap.Database)
+		if i == v.selectedIndex {
+			lines = append(lines, selectedRowStyle.Render("> "+line))
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+	ensureLineVisible(&v.viewport, v.selectedIndex)
+}
+
+func (v *SnapshotView) listSnapshotsCmd() tea.Cmd {
+	store, storeErr := v.store, v.error
+	return func() tea.Msg {
+		if store == nil {
+			return SnapshotsListedMsg{Error: storeErr}
+		}
+		snaps, err := store.List()
+		return SnapshotsListedMsg{Snapshots: snaps, Error: err}
+	}
+}
+
+// discovers the connection settings the dump and restore tools run with.
+func (v *SnapshotView) target(ctx context.Context) (snapshot.Target, error) {
+	if v.dockerClient == nil {
+		return snapshot.Target{}, fmt.Errorf("docker unavailable")
+	}
+	config, err := db.DiscoverConfig(ctx, v.dockerClient.GetRawClient(), v.service.ContainerID, v.service.DBType)
+	if err != nil {
+		return snapshot.Target{}, fmt.Errorf("discover config: %w", err)
+	}
+	return snapshot.Target{
+		ContainerID: v.service.ContainerID,
+		Container:   v.service.Name,
+		DBType:      v.service.DBType,
+		Config:      config,
+	}, nil
+}
+
+func (v *SnapshotView) takeSnapshotCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		start := time.Now()
+		target, err := v.target(ctx)
+		if err == nil {
+			_, err = snapshot.Take(ctx, v.dockerClient, v.store, target, name)
+		}
+		return SnapshotDoneMsg{Action: "Snapshot", Name: name, Duration: time.Since(start), Error: err}
+	}
+}
+
+func (v *SnapshotView) restoreSnapshotCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		start := time.Now()
+		target, err := v.target(ctx)
+		if err == nil {
+			err = snapshot.Restore(ctx, v.dockerClient, v.store, target, name)
+		}
+		return SnapshotDoneMsg{Action: "Restore", Name: name, Duration: time.Since(start), Error: err}
+	}
+}
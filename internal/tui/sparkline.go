@@ -0,0 +1,83 @@
+package tui
+
+import "strings"
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// renders the last width values as a block sparkline scaled to max.
+// A max of 0 scales to the largest value in the window.
+func sparkline(values []float64, width int, max float64) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	if max <= 0 {
+		for _, v := range values {
+			if v > max {
+				max = v
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if max > 0 {
+			idx = int(v / max * float64(len(sparkBlocks)-1))
+		}
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= len(sparkBlocks) {
+			idx = len(sparkBlocks) - 1
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+var chartBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// renders the last width values as a multi-row bar chart scaled to the largest value.
+// Rows are returned top to bottom.
+func chart(values []float64, width, height int) []string {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	var max float64
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	steps := len(chartBlocks) - 1
+	rows := make([]string, height)
+	for r := 0; r < height; r++ {
+		floor := (height - 1 - r) * steps
+		var b strings.Builder
+		for _, v := range values {
+			level := 0
+			if max > 0 {
+				level = int(v / max * float64(height*steps))
+			}
+			fill := level - floor
+			if fill < 0 {
+				fill = 0
+			}
+			if fill > steps {
+				fill = steps
+			}
+			b.WriteRune(chartBlocks[fill])
+		}
+		rows[r] = b.String()
+	}
+	return rows
+}
@@ -0,0 +1,49 @@
+package tui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		max    float64
+		want   string
+	}{
+		{name: "empty", values: nil, width: 10, want: ""},
+		{name: "zero width", values: []float64{1, 2}, width: 0, want: ""},
+		{name: "auto scale", values: []float64{0, 7, 14}, width: 10, want: "▁▄█"},
+		{name: "fixed max", values: []float64{0, 50, 100}, width: 10, max: 100, want: "▁▄█"},
+		{name: "clamped above max", values: []float64{200}, width: 10, max: 100, want: "█"},
+		{name: "keeps newest values", values: []float64{14, 0, 7, 14}, width: 3, want: "▁▄█"},
+		{name: "all zero", values: []float64{0, 0}, width: 5, want: "▁▁"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.width, tt.max); got != tt.want {
+				t.Errorf("sparkline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChart(t *testing.T) {
+	got := chart([]float64{0, 1, 2, 4}, 10, 2)
+	want := []string{"   █", " ▄██"}
+	if len(got) != len(want) {
+		t.Fatalf("chart() returned %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("chart() row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if rows := chart([]float64{1, 2, 3}, 2, 1); rows[0] != "▅█" {
+		t.Errorf("chart() should keep newest values, got %q", rows[0])
+	}
+	if rows := chart(nil, 0, 3); rows != nil {
+		t.Errorf("chart() with zero width = %v, want nil", rows)
+	}
+}
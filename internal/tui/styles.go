@@ -100,4 +100,12 @@ var (
 
 	commandErrorStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#E74C3C"))
+
+	sparkStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#2ECC71"))
+
+	alertBannerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(lipgloss.Color("#E74C3C")).
+				Bold(true)
 )
@@ -0,0 +1,274 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eanda22/devhud/internal/docker"
+)
+
+// VolumesPanel lists volumes with their size, mount point and the containers
+// mounting them, and removes and prunes them. Volumes no container mounts are
+// dimmed: they are often an old database's data waiting to be picked up by
+// the next container with the same volume name.
+type VolumesPanel struct {
+	dockerClient  *docker.Client
+	volumes       []docker.Volume
+	selectedIndex int
+	loaded        bool
+	error         error
+	// mode is "list", or "remove" or "prune" while confirming.
+	mode   string
+	target string
+	// busy names the volume being removed.
+	busy          string
+	statusMessage string
+}
+
+func NewVolumesPanel(dockerClient *docker.Client) *VolumesPanel {
+	return &VolumesPanel{dockerClient: dockerClient, mode: "list"}
+}
+
+func (p *VolumesPanel) Refresh() tea.Cmd {
+	return func() tea.Msg {
+		if p.dockerClient == nil {
+			return VolumesListedMsg{Error: fmt.Errorf("docker unavailable")}
+		}
+		volumes, err := p.dockerClient.ListVolumes()
+		return VolumesListedMsg{Volumes: volumes, Error: err}
+	}
+}
+
+func (p *VolumesPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case VolumesListedMsg:
+		p.loaded = true
+		p.error = msg.Error
+		if msg.Error != nil {
+			return nil
+		}
+		selected := ""
+		if p.selectedIndex < len(p.volumes) {
+			selected = p.volumes[p.selectedIndex].Name
+		}
+		p.volumes = msg.Volumes
+		p.selectedIndex = moveSelection(p.selectedIndex, 0, len(p.volumes))
+		for i, v := range p.volumes {
+			if v.Name == selected {
+				p.selectedIndex = i
+			}
+		}
+		return nil
+
+	case VolumeActionMsg:
+		p.busy = ""
+		if msg.Error != nil {
+			p.statusMessage = fmt.Sprintf("%s failed: %v", msg.Action, msg.Error)
+		} else {
+			p.statusMessage = msg.Message
+		}
+		return p.Refresh()
+
+	case tea.KeyMsg:
+		if p.mode != "list" {
+			return p.updateConfirm(msg)
+		}
+		return p.updateList(msg)
+	}
+	return nil
+}
+
+func (p *VolumesPanel) updateList(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		p.selectedIndex = moveSelection(p.selectedIndex, -1, len(p.volumes))
+	case "down", "j":
+		p.selectedIndex = moveSelection(p.selectedIndex, 1, len(p.volumes))
+	case "g":
+		p.selectedIndex = 0
+	case "G":
+		p.selectedIndex = moveSelection(len(p.volumes), 0, len(p.volumes))
+	case "r":
+		p.statusMessage = ""
+		return p.Refresh()
+	case "d":
+		if p.selectedIndex < len(p.volumes) {
+			p.ConfirmRemove(p.volumes[p.selectedIndex].Name)
+		}
+	case "P":
+		p.ConfirmPrune()
+	}
+	return nil
+}
+
+func (p *VolumesPanel) updateConfirm(msg tea.KeyMsg) tea.Cmd {
+	mode, target := p.mode, p.target
+	p.mode, p.target = "list", ""
+	if msg.String() != "y" && msg.String() != "Y" {
+		p.statusMessage = "Cancelled"
+		return nil
+	}
+	if p.busy != "" {
+		p.statusMessage = "busy with " + p.busy
+		return nil
+	}
+	if mode == "prune" {
+		p.busy = "prune"
+		p.statusMessage = "Pruning unused volumes..."
+		return func() tea.Msg {
+			if p.dockerClient == nil {
+				return VolumeActionMsg{Action: "Prune", Error: fmt.Errorf("docker unavailable")}
+			}
+			reclaimed, err := p.dockerClient.PruneVolumes()
+			if err != nil {
+				return VolumeActionMsg{Action: "Prune", Error: err}
+			}
+			return VolumeActionMsg{Action: "Prune", Message: fmt.Sprintf("Pruned unused volumes, reclaimed %s", formatBytes(int64(reclaimed)))}
+		}
+	}
+
+	p.busy = target
+	p.statusMessage = "Removing " + target + "..."
+	return func() tea.Msg {
+		if p.dockerClient == nil {
+			return VolumeActionMsg{Action: "Remove", Error: fmt.Errorf("docker unavailable")}
+		}
+		if err := p.dockerClient.RemoveVolume(target); err != nil {
+			return VolumeActionMsg{Action: "Remove", Error: err}
+		}
+		return VolumeActionMsg{Action: "Remove", Message: "Removed " + target}
+	}
+}
+
+// asks to remove the named volume.
+func (p *VolumesPanel) ConfirmRemove(name string) {
+	p.mode = "remove"
+	p.target = name
+}
+
+// asks to prune unused volumes, unless there are none to prune.
+func (p *VolumesPanel) ConfirmPrune() {
+	if p.loaded && p.error == nil {
+		if count, _ := p.prunable(); count == 0 {
+			p.statusMessage = "No unused volumes to prune"
+			return
+		}
+	}
+	p.mode = "prune"
+}
+
+// counts the volumes a prune deletes and the size known for them.
+func (p *VolumesPanel) prunable() (int, int64) {
+	var count int
+	var size int64
+	for _, v := range p.volumes {
+		if v.Unused() {
+			count++
+			if v.Size > 0 {
+				size += v.Size
+			}
+		}
+	}
+	return count, size
+}
+
+// Names returns the volume names, for completion.
+func (p *VolumesPanel) Names() []string {
+	names := make([]string, len(p.volumes))
+	for i, v := range p.volumes {
+		names[i] = v.Name
+	}
+	return names
+}
+
+func (p *VolumesPanel) Rows(focused bool) []string {
+	header := fmt.Sprintf("%-36s %-10s %-10s %-24s %s\n", "VOLUME", "SIZE", "CREATED", "CONTAINERS", "MOUNTPOINT")
+	rows := []string{header}
+	switch {
+	case p.error != nil:
+		return append(rows, fmt.Sprintf("Error: %v\n", p.error))
+	case !p.loaded:
+		return append(rows, subtleStyle.Render("Loading volumes...")+"\n")
+	case len(p.volumes) == 0:
+		return append(rows, subtleStyle.Render("No volumes")+"\n")
+	}
+
+	for i, v := range p.volumes {
+		size := "-"
+		if v.Size >= 0 {
+			size = formatBytes(v.Size)
+		}
+		created := "-"
+		if !v.Created.IsZero() {
+			created = formatAge(time.Since(v.Created))
+		}
+		containers := "unused"
+		if !v.Unused() {
+			containers = strings.Join(v.Containers, ", ")
+		}
+		line := fmt.Sprintf("%-36s %-10s %-10s %-24s %s",
+			truncate(v.Name, 35),
+			size,
+			created,
+			truncate(containers, 23),
+			v.Mountpoint,
+		)
+		switch {
+		case p.busy == v.Name:
+			line = operatingRowStyle.Render(line)
+		case i == p.selectedIndex && focused:
+			line = selectedRowStyle.Render(line)
+		case v.Unused():
+			line = subtleStyle.Render(line)
+		}
+		rows = append(rows, line+"\n")
+	}
+	return rows
+}
+
+func (p *VolumesPanel) Selected() int {
+	return p.selectedIndex
+}
+
+func (p *VolumesPanel) Hints() string {
+	return "[j/k] Nav  [d]el  [P]rune unused  [r]efresh  [h] Back  [:] Cmd"
+}
+
+func (p *VolumesPanel) Prompt() string {
+	switch p.mode {
+	case "remove":
+		question := fmt.Sprintf("Remove %s and its data?", p.target)
+		for _, v := range p.volumes {
+			if v.Name == p.target && !v.Unused() {
+				question = fmt.Sprintf("Remove %s (mounted by %s) and its data?", p.target, strings.Join(v.Containers, ", "))
+			}
+		}
+		return confirmDeleteStyle.Render(" REMOVE ") + "  " + question + " [y/N]"
+	case "prune":
+		if !p.loaded || p.error != nil {
+			return confirmDeleteStyle.Render(" PRUNE ") + "  Delete every unused volume and its data? [y/N]"
+		}
+		count, size := p.prunable()
+		return confirmDeleteStyle.Render(" PRUNE ") + fmt.Sprintf("  Delete %d unused volumes (%s) and their data? [y/N]", count, formatBytes(size))
+	}
+	return ""
+}
+
+func (p *VolumesPanel) Status() string {
+	return p.statusMessage
+}
+
+func (p *VolumesPanel) Summary() string {
+	if !p.loaded || p.error != nil {
+		return ""
+	}
+	var size int64
+	for _, v := range p.volumes {
+		if v.Size > 0 {
+			size += v.Size
+		}
+	}
+	return fmt.Sprintf("Volumes: %d, %s", len(p.volumes), formatBytes(size))
+}
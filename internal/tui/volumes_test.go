@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eanda22/devhud/internal/docker"
+)
+
+func TestVolumesPanelPrune(t *testing.T) {
+	p := NewVolumesPanel(nil)
+	p.Update(VolumesListedMsg{Volumes: []docker.Volume{
+		{Name: "shop_pgdata", Size: 500, Containers: []string{"shop-db-1"}},
+		{Name: "old_pgdata", Size: 300},
+		{Name: "nfs_share", Size: -1},
+	}})
+
+	if count, size := p.prunable(); count != 2 || size != 300 {
+		t.Errorf("prunable() = %d, %d, want 2, 300", count, size)
+	}
+	p.ConfirmPrune()
+	if prompt := p.Prompt(); !strings.Contains(prompt, "Delete 2 unused volumes (300 B)") {
+		t.Errorf("Prompt() = %q", prompt)
+	}
+	p.Update(runeKey('n'))
+
+	p.ConfirmRemove("shop_pgdata")
+	if prompt := p.Prompt(); !strings.Contains(prompt, "mounted by shop-db-1") {
+		t.Errorf("Prompt() = %q, want the mounting container", prompt)
+	}
+	if p.Update(runeKey('x')); p.Prompt() != "" || p.busy != "" {
+		t.Errorf("any key but y should cancel the removal")
+	}
+}